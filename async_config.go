@@ -98,6 +98,7 @@ func (acm *AsyncConfigManager) refreshConfig() {
 	acm.mu.Lock()
 	oldConfig := acm.config
 	newConfig.ManualMessageTemplates = oldConfig.ManualMessageTemplates
+	newConfig.ManualEnvelope = oldConfig.ManualEnvelope
 	acm.config = newConfig
 	acm.lastError = nil
 	callbacks := acm.callbacks
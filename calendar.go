@@ -0,0 +1,406 @@
+package goresponse
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateTimeStyle selects how much detail is rendered for the date or time part of a time parameter
+type DateTimeStyle int
+
+const (
+	// StyleDefault uses the default style (medium for dates, short for times)
+	StyleDefault DateTimeStyle = iota
+	// StyleShort renders numeric dates and times without seconds (1/2/06, 3:04 PM)
+	StyleShort
+	// StyleMedium renders abbreviated month names and seconds (Jan 2, 2006, 3:04:05 PM)
+	StyleMedium
+	// StyleLong renders full month names and the timezone abbreviation (January 2, 2006, 3:04:05 PM MST)
+	StyleLong
+	// StyleFull renders the day of week and the timezone name (Monday, January 2, 2006, 3:04:05 PM America/Denver)
+	StyleFull
+	// StyleNone omits the part entirely
+	StyleNone
+)
+
+// DateTime is a time parameter rendered with explicit date and time styles
+type DateTime struct {
+	Time      time.Time     // Time value
+	DateStyle DateTimeStyle // Style of the date part
+	TimeStyle DateTimeStyle // Style of the time part
+}
+
+// Date creates a DateTime parameter that renders only the date part
+func Date(t time.Time, style DateTimeStyle) DateTime {
+	return DateTime{Time: t, DateStyle: style, TimeStyle: StyleNone}
+}
+
+// Clock creates a DateTime parameter that renders only the time part
+func Clock(t time.Time, style DateTimeStyle) DateTime {
+	return DateTime{Time: t, DateStyle: StyleNone, TimeStyle: style}
+}
+
+// Calendar holds the localized names and patterns used to render dates and times
+// Patterns use CLDR letters: y, M, d, E, H, h, m, s, a, z; text in single quotes is copied literally
+type Calendar struct {
+	Months         []string // Wide month names, January first
+	ShortMonths    []string // Abbreviated month names, January first
+	Days           []string // Wide day names, Sunday first
+	ShortDays      []string // Abbreviated day names, Sunday first
+	DayPeriods     []string // AM and PM markers
+	DateFormats    []string // Date patterns for short, medium, long and full styles
+	TimeFormats    []string // Time patterns for short, medium, long and full styles
+	DateTimeFormat string   // Pattern joining both parts using {date} and {time}
+}
+
+var (
+	// isoCalendar renders ISO 8601 style values for languages without calendar data
+	isoCalendar = &Calendar{
+		Months:         []string{"01", "02", "03", "04", "05", "06", "07", "08", "09", "10", "11", "12"},
+		ShortMonths:    []string{"01", "02", "03", "04", "05", "06", "07", "08", "09", "10", "11", "12"},
+		Days:           []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		ShortDays:      []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		DayPeriods:     []string{"AM", "PM"},
+		DateFormats:    []string{"y-MM-dd", "y-MM-dd", "y-MM-dd", "EEEE y-MM-dd"},
+		TimeFormats:    []string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		DateTimeFormat: "{date} {time}",
+	}
+	enCalendar = &Calendar{
+		Months:         []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		ShortMonths:    []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Days:           []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		ShortDays:      []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		DayPeriods:     []string{"AM", "PM"},
+		DateFormats:    []string{"M/d/yy", "MMM d, y", "MMMM d, y", "EEEE, MMMM d, y"},
+		TimeFormats:    []string{"h:mm a", "h:mm:ss a", "h:mm:ss a z", "h:mm:ss a zzzz"},
+		DateTimeFormat: "{date}, {time}",
+	}
+	idCalendar = &Calendar{
+		Months:         []string{"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"},
+		ShortMonths:    []string{"Jan", "Feb", "Mar", "Apr", "Mei", "Jun", "Jul", "Agu", "Sep", "Okt", "Nov", "Des"},
+		Days:           []string{"Minggu", "Senin", "Selasa", "Rabu", "Kamis", "Jumat", "Sabtu"},
+		ShortDays:      []string{"Min", "Sen", "Sel", "Rab", "Kam", "Jum", "Sab"},
+		DayPeriods:     []string{"AM", "PM"},
+		DateFormats:    []string{"dd/MM/yy", "d MMM y", "d MMMM y", "EEEE, dd MMMM y"},
+		TimeFormats:    []string{"HH.mm", "HH.mm.ss", "HH.mm.ss z", "HH.mm.ss zzzz"},
+		DateTimeFormat: "{date} {time}",
+	}
+	msCalendar = &Calendar{
+		Months:         []string{"Januari", "Februari", "Mac", "April", "Mei", "Jun", "Julai", "Ogos", "September", "Oktober", "November", "Disember"},
+		ShortMonths:    []string{"Jan", "Feb", "Mac", "Apr", "Mei", "Jun", "Jul", "Ogo", "Sep", "Okt", "Nov", "Dis"},
+		Days:           []string{"Ahad", "Isnin", "Selasa", "Rabu", "Khamis", "Jumaat", "Sabtu"},
+		ShortDays:      []string{"Ahd", "Isn", "Sel", "Rab", "Kha", "Jum", "Sab"},
+		DayPeriods:     []string{"PG", "PTG"},
+		DateFormats:    []string{"d/MM/yy", "d MMM y", "d MMMM y", "EEEE, d MMMM y"},
+		TimeFormats:    []string{"h:mm a", "h:mm:ss a", "h:mm:ss a z", "h:mm:ss a zzzz"},
+		DateTimeFormat: "{date}, {time}",
+	}
+	deCalendar = &Calendar{
+		Months:         []string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		ShortMonths:    []string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		Days:           []string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		ShortDays:      []string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		DayPeriods:     []string{"AM", "PM"},
+		DateFormats:    []string{"dd.MM.yy", "dd.MM.y", "d. MMMM y", "EEEE, d. MMMM y"},
+		TimeFormats:    []string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		DateTimeFormat: "{date}, {time}",
+	}
+	frCalendar = &Calendar{
+		Months:         []string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		ShortMonths:    []string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		Days:           []string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		ShortDays:      []string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		DayPeriods:     []string{"AM", "PM"},
+		DateFormats:    []string{"dd/MM/y", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		TimeFormats:    []string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		DateTimeFormat: "{date} {time}",
+	}
+	esCalendar = &Calendar{
+		Months:         []string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		ShortMonths:    []string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		Days:           []string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		ShortDays:      []string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		DayPeriods:     []string{"a. m.", "p. m."},
+		DateFormats:    []string{"d/M/yy", "d MMM y", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		TimeFormats:    []string{"H:mm", "H:mm:ss", "H:mm:ss z", "H:mm:ss (zzzz)"},
+		DateTimeFormat: "{date}, {time}",
+	}
+	ptCalendar = &Calendar{
+		Months:         []string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		ShortMonths:    []string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+		Days:           []string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		ShortDays:      []string{"dom.", "seg.", "ter.", "qua.", "qui.", "sex.", "sáb."},
+		DayPeriods:     []string{"AM", "PM"},
+		DateFormats:    []string{"dd/MM/y", "d 'de' MMM 'de' y", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		TimeFormats:    []string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		DateTimeFormat: "{date} {time}",
+	}
+	itCalendar = &Calendar{
+		Months:         []string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		ShortMonths:    []string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		Days:           []string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		ShortDays:      []string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		DayPeriods:     []string{"AM", "PM"},
+		DateFormats:    []string{"dd/MM/yy", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		TimeFormats:    []string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		DateTimeFormat: "{date}, {time}",
+	}
+	nlCalendar = &Calendar{
+		Months:         []string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		ShortMonths:    []string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		Days:           []string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		ShortDays:      []string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		DayPeriods:     []string{"a.m.", "p.m."},
+		DateFormats:    []string{"dd-MM-y", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		TimeFormats:    []string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		DateTimeFormat: "{date} {time}",
+	}
+	ruCalendar = &Calendar{
+		Months:         []string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		ShortMonths:    []string{"янв.", "февр.", "мар.", "апр.", "мая", "июн.", "июл.", "авг.", "сент.", "окт.", "нояб.", "дек."},
+		Days:           []string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+		ShortDays:      []string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+		DayPeriods:     []string{"AM", "PM"},
+		DateFormats:    []string{"dd.MM.y", "d MMM y 'г'.", "d MMMM y 'г'.", "EEEE, d MMMM y 'г'."},
+		TimeFormats:    []string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		DateTimeFormat: "{date}, {time}",
+	}
+	trCalendar = &Calendar{
+		Months:         []string{"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran", "Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık"},
+		ShortMonths:    []string{"Oca", "Şub", "Mar", "Nis", "May", "Haz", "Tem", "Ağu", "Eyl", "Eki", "Kas", "Ara"},
+		Days:           []string{"Pazar", "Pazartesi", "Salı", "Çarşamba", "Perşembe", "Cuma", "Cumartesi"},
+		ShortDays:      []string{"Paz", "Pzt", "Sal", "Çar", "Per", "Cum", "Cmt"},
+		DayPeriods:     []string{"ÖÖ", "ÖS"},
+		DateFormats:    []string{"d.MM.y", "d MMM y", "d MMMM y", "d MMMM y EEEE"},
+		TimeFormats:    []string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		DateTimeFormat: "{date} {time}",
+	}
+	viCalendar = &Calendar{
+		Months:         []string{"tháng 1", "tháng 2", "tháng 3", "tháng 4", "tháng 5", "tháng 6", "tháng 7", "tháng 8", "tháng 9", "tháng 10", "tháng 11", "tháng 12"},
+		ShortMonths:    []string{"thg 1", "thg 2", "thg 3", "thg 4", "thg 5", "thg 6", "thg 7", "thg 8", "thg 9", "thg 10", "thg 11", "thg 12"},
+		Days:           []string{"Chủ Nhật", "Thứ Hai", "Thứ Ba", "Thứ Tư", "Thứ Năm", "Thứ Sáu", "Thứ Bảy"},
+		ShortDays:      []string{"CN", "Th 2", "Th 3", "Th 4", "Th 5", "Th 6", "Th 7"},
+		DayPeriods:     []string{"SA", "CH"},
+		DateFormats:    []string{"dd/MM/y", "d MMM, y", "d MMMM, y", "EEEE, d MMMM, y"},
+		TimeFormats:    []string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		DateTimeFormat: "{time} {date}",
+	}
+	thCalendar = &Calendar{
+		Months:         []string{"มกราคม", "กุมภาพันธ์", "มีนาคม", "เมษายน", "พฤษภาคม", "มิถุนายน", "กรกฎาคม", "สิงหาคม", "กันยายน", "ตุลาคม", "พฤศจิกายน", "ธันวาคม"},
+		ShortMonths:    []string{"ม.ค.", "ก.พ.", "มี.ค.", "เม.ย.", "พ.ค.", "มิ.ย.", "ก.ค.", "ส.ค.", "ก.ย.", "ต.ค.", "พ.ย.", "ธ.ค."},
+		Days:           []string{"วันอาทิตย์", "วันจันทร์", "วันอังคาร", "วันพุธ", "วันพฤหัสบดี", "วันศุกร์", "วันเสาร์"},
+		ShortDays:      []string{"อา.", "จ.", "อ.", "พ.", "พฤ.", "ศ.", "ส."},
+		DayPeriods:     []string{"ก่อนเที่ยง", "หลังเที่ยง"},
+		DateFormats:    []string{"d/M/yy", "d MMM y", "d MMMM y", "EEEEที่ d MMMM y"},
+		TimeFormats:    []string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		DateTimeFormat: "{date} {time}",
+	}
+	jaCalendar = &Calendar{
+		Months:         []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		ShortMonths:    []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		Days:           []string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		ShortDays:      []string{"日", "月", "火", "水", "木", "金", "土"},
+		DayPeriods:     []string{"午前", "午後"},
+		DateFormats:    []string{"y/MM/dd", "y/MM/dd", "y年M月d日", "y年M月d日EEEE"},
+		TimeFormats:    []string{"H:mm", "H:mm:ss", "H:mm:ss z", "H時mm分ss秒 zzzz"},
+		DateTimeFormat: "{date} {time}",
+	}
+	zhCalendar = &Calendar{
+		Months:         []string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		ShortMonths:    []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		Days:           []string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		ShortDays:      []string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		DayPeriods:     []string{"上午", "下午"},
+		DateFormats:    []string{"y/M/d", "y年M月d日", "y年M月d日", "y年M月d日EEEE"},
+		TimeFormats:    []string{"HH:mm", "HH:mm:ss", "z HH:mm:ss", "zzzz HH:mm:ss"},
+		DateTimeFormat: "{date} {time}",
+	}
+	koCalendar = &Calendar{
+		Months:         []string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		ShortMonths:    []string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		Days:           []string{"일요일", "월요일", "화요일", "수요일", "목요일", "금요일", "토요일"},
+		ShortDays:      []string{"일", "월", "화", "수", "목", "금", "토"},
+		DayPeriods:     []string{"오전", "오후"},
+		DateFormats:    []string{"yy. M. d.", "y. M. d.", "y년 M월 d일", "y년 M월 d일 EEEE"},
+		TimeFormats:    []string{"a h:mm", "a h:mm:ss", "a h시 m분 s초 z", "a h시 m분 s초 zzzz"},
+		DateTimeFormat: "{date} {time}",
+	}
+)
+
+// FormatDate renders the date part of t using the pattern for the given style
+func (cal *Calendar) FormatDate(t time.Time, style DateTimeStyle) string {
+	cal = cal.complete(isoCalendar)
+	return cal.formatPattern(t, cal.DateFormats[styleIndex(style, StyleMedium)])
+}
+
+// FormatTime renders the time part of t using the pattern for the given style
+func (cal *Calendar) FormatTime(t time.Time, style DateTimeStyle) string {
+	cal = cal.complete(isoCalendar)
+	return cal.formatPattern(t, cal.TimeFormats[styleIndex(style, StyleShort)])
+}
+
+// FormatDateTime renders t with the given date and time styles
+// StyleNone omits the corresponding part
+func (cal *Calendar) FormatDateTime(t time.Time, dateStyle, timeStyle DateTimeStyle) string {
+	switch {
+	case dateStyle == StyleNone && timeStyle == StyleNone:
+		return ""
+	case dateStyle == StyleNone:
+		return cal.FormatTime(t, timeStyle)
+	case timeStyle == StyleNone:
+		return cal.FormatDate(t, dateStyle)
+	}
+
+	cal = cal.complete(isoCalendar)
+	result := strings.Replace(cal.DateTimeFormat, "{date}", cal.FormatDate(t, dateStyle), 1)
+	return strings.Replace(result, "{time}", cal.FormatTime(t, timeStyle), 1)
+}
+
+// complete returns the calendar with missing or incomplete name and pattern tables taken from base
+// The calendar itself is returned when every table is complete
+func (cal *Calendar) complete(base *Calendar) *Calendar {
+	if cal == nil {
+		return base
+	}
+
+	c, changed := *cal, false
+	fill := func(table *[]string, baseTable []string, size int) {
+		if len(*table) != size {
+			*table, changed = baseTable, true
+		}
+	}
+	fill(&c.Months, base.Months, 12)
+	fill(&c.ShortMonths, base.ShortMonths, 12)
+	fill(&c.Days, base.Days, 7)
+	fill(&c.ShortDays, base.ShortDays, 7)
+	fill(&c.DayPeriods, base.DayPeriods, 2)
+	fill(&c.DateFormats, base.DateFormats, 4)
+	fill(&c.TimeFormats, base.TimeFormats, 4)
+	if c.DateTimeFormat == "" {
+		c.DateTimeFormat, changed = base.DateTimeFormat, true
+	}
+
+	if !changed {
+		return cal
+	}
+	return &c
+}
+
+// styleIndex converts a style into an index of the short, medium, long and full patterns
+func styleIndex(style, fallback DateTimeStyle) int {
+	if style < StyleShort || style > StyleFull {
+		style = fallback
+	}
+	return int(style - StyleShort)
+}
+
+// formatPattern renders t using a CLDR-style pattern
+func (cal *Calendar) formatPattern(t time.Time, pattern string) string {
+	var sb strings.Builder
+	runes := []rune(pattern)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		// Quoted literal text, '' is an escaped quote
+		if r == '\'' {
+			j := i + 1
+			if j < len(runes) && runes[j] == '\'' {
+				sb.WriteRune('\'')
+				i += 2
+				continue
+			}
+			for j < len(runes) {
+				if runes[j] == '\'' {
+					if j+1 < len(runes) && runes[j+1] == '\'' {
+						sb.WriteRune('\'')
+						j += 2
+						continue
+					}
+					break
+				}
+				sb.WriteRune(runes[j])
+				j++
+			}
+			i = j + 1
+			continue
+		}
+
+		if !strings.ContainsRune("yMdEHhmsaz", r) {
+			sb.WriteRune(r)
+			i++
+			continue
+		}
+
+		// Count repeated pattern letters
+		count := 1
+		for i+count < len(runes) && runes[i+count] == r {
+			count++
+		}
+		i += count
+
+		sb.WriteString(cal.formatField(t, r, count))
+	}
+
+	return sb.String()
+}
+
+// formatField renders a single pattern field
+func (cal *Calendar) formatField(t time.Time, field rune, count int) string {
+	switch field {
+	case 'y':
+		if count == 2 {
+			return padNumber(t.Year()%100, 2)
+		}
+		return padNumber(t.Year(), count)
+	case 'M':
+		switch {
+		case count >= 4:
+			return cal.Months[t.Month()-1]
+		case count == 3:
+			return cal.ShortMonths[t.Month()-1]
+		}
+		return padNumber(int(t.Month()), count)
+	case 'd':
+		return padNumber(t.Day(), count)
+	case 'E':
+		if count >= 4 {
+			return cal.Days[t.Weekday()]
+		}
+		return cal.ShortDays[t.Weekday()]
+	case 'H':
+		return padNumber(t.Hour(), count)
+	case 'h':
+		hour := t.Hour() % 12
+		if hour == 0 {
+			hour = 12
+		}
+		return padNumber(hour, count)
+	case 'm':
+		return padNumber(t.Minute(), count)
+	case 's':
+		return padNumber(t.Second(), count)
+	case 'a':
+		if t.Hour() < 12 {
+			return cal.DayPeriods[0]
+		}
+		return cal.DayPeriods[1]
+	case 'z':
+		if count >= 4 {
+			return t.Location().String()
+		}
+		name, _ := t.Zone()
+		return name
+	}
+	return ""
+}
+
+// padNumber renders n with at least width digits
+func padNumber(n, width int) string {
+	s := strconv.Itoa(n)
+	for len(s) < width {
+		s = "0" + s
+	}
+	return s
+}
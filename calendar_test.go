@@ -0,0 +1,240 @@
+package goresponse
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestFormatDateTime tests Formatter.FormatDateTime method
+func TestFormatDateTime(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	moment := time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		language  string
+		location  *time.Location
+		dateStyle DateTimeStyle
+		timeStyle DateTimeStyle
+		expected  string
+	}{
+		{
+			name:      "English default styles",
+			language:  "en",
+			dateStyle: StyleDefault,
+			timeStyle: StyleDefault,
+			expected:  "Mar 5, 2024, 2:07 PM",
+		},
+		{
+			name:      "English short date",
+			language:  "en",
+			dateStyle: StyleShort,
+			timeStyle: StyleNone,
+			expected:  "3/5/24",
+		},
+		{
+			name:      "English full date",
+			language:  "en",
+			dateStyle: StyleFull,
+			timeStyle: StyleNone,
+			expected:  "Tuesday, March 5, 2024",
+		},
+		{
+			name:      "Indonesian long date in timezone",
+			language:  "id",
+			location:  jakarta,
+			dateStyle: StyleLong,
+			timeStyle: StyleLong,
+			expected:  "5 Maret 2024 21.07.09 WIB",
+		},
+		{
+			name:      "Indonesian full date",
+			language:  "id",
+			dateStyle: StyleFull,
+			timeStyle: StyleNone,
+			expected:  "Selasa, 05 Maret 2024",
+		},
+		{
+			name:      "German medium time",
+			language:  "de",
+			dateStyle: StyleNone,
+			timeStyle: StyleMedium,
+			expected:  "14:07:09",
+		},
+		{
+			name:      "Spanish long date with quoted literals",
+			language:  "es",
+			dateStyle: StyleLong,
+			timeStyle: StyleNone,
+			expected:  "5 de marzo de 2024",
+		},
+		{
+			name:      "Russian long date",
+			language:  "ru",
+			dateStyle: StyleLong,
+			timeStyle: StyleNone,
+			expected:  "5 марта 2024 г.",
+		},
+		{
+			name:      "Japanese full date",
+			language:  "ja",
+			dateStyle: StyleFull,
+			timeStyle: StyleNone,
+			expected:  "2024年3月5日火曜日",
+		},
+		{
+			name:      "Unknown language uses ISO format",
+			language:  "xx",
+			dateStyle: StyleDefault,
+			timeStyle: StyleMedium,
+			expected:  "2024-03-05 14:07:09",
+		},
+		{
+			name:      "Both parts omitted",
+			language:  "en",
+			dateStyle: StyleNone,
+			timeStyle: StyleNone,
+			expected:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter := NewFormatter(tt.language).WithLocation(tt.location)
+			result := formatter.FormatDateTime(moment, tt.dateStyle, tt.timeStyle)
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestFormatterFormatTime tests rendering of time.Time and DateTime parameters
+func TestFormatterFormatTime(t *testing.T) {
+	moment := time.Date(2024, time.December, 25, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{
+			name:     "time.Time uses default styles",
+			value:    moment,
+			expected: "25 Des 2024 09.30",
+		},
+		{
+			name:     "Date helper",
+			value:    Date(moment, StyleLong),
+			expected: "25 Desember 2024",
+		},
+		{
+			name:     "Clock helper",
+			value:    Clock(moment, StyleShort),
+			expected: "09.30",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := NewFormatter("id").Format(tt.value); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestFormatPattern tests Calendar pattern rendering
+func TestFormatPattern(t *testing.T) {
+	moment := time.Date(2024, time.January, 7, 0, 5, 3, 0, time.UTC)
+
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{pattern: "yy-M-d", expected: "24-1-7"},
+		{pattern: "y-MM-dd", expected: "2024-01-07"},
+		{pattern: "EEE, MMM d", expected: "Sun, Jan 7"},
+		{pattern: "h:mm a", expected: "12:05 AM"},
+		{pattern: "HH:mm:ss z", expected: "00:05:03 UTC"},
+		{pattern: "'at' h 'o''clock'", expected: "at 12 o'clock"},
+		{pattern: "''", expected: "'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			if result := enCalendar.formatPattern(moment, tt.pattern); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestPartialCalendar tests calendars registered with only some name tables
+func TestPartialCalendar(t *testing.T) {
+	moment := time.Date(2024, time.January, 7, 15, 4, 0, 0, time.UTC)
+
+	RegisterLocale(Locale{
+		Tag:              "xx-Partial",
+		DecimalSeparator: ".",
+		Calendar:         &Calendar{Months: []string{"Jan.", "Feb.", "Mar.", "Apr.", "May.", "Jun.", "Jul.", "Aug.", "Sep.", "Oct.", "Nov.", "Dec."}, DateFormats: []string{"EEEE d MMMM"}},
+	})
+	RegisterLocale(Locale{
+		Tag:      "pt-Partial",
+		Calendar: &Calendar{Days: []string{"dom"}},
+	})
+
+	tests := []struct {
+		name     string
+		language string
+		value    any
+		expected string
+	}{
+		{name: "Missing tables use ISO values", language: "xx-Partial", value: moment, expected: "2024-01-07 15:04"},
+		{name: "Missing weekdays use ISO names", language: "xx-Partial", value: Date(moment, StyleFull), expected: "Sunday 2024-01-07"},
+		{name: "Missing tables use the base locale", language: "pt-Partial", value: Date(moment, StyleFull), expected: ptCalendar.FormatDate(moment, StyleFull)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := NewFormatter(tt.language).Format(tt.value); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+
+	t.Run("Unregistered calendar", func(t *testing.T) {
+		calendar := &Calendar{Months: enCalendar.Months, DateFormats: []string{"EEE d MMMM", "", "", ""}}
+		if result := calendar.FormatDateTime(moment, StyleShort, StyleShort); result != "Sun 7 January 15:04" {
+			t.Errorf("Expected 'Sun 7 January 15:04', got '%s'", result)
+		}
+	})
+}
+
+// TestBuildResponseTimezone tests time parameters in BuildResponse with a context timezone
+func TestBuildResponseTimezone(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"login": {
+				Key:      "login",
+				Template: "Last login: $at",
+			},
+		},
+		DefaultLanguage: "en",
+	}
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	ctx := WithTimezone(WithLanguage(context.Background(), "en"), tokyo)
+	builder := NewResponseBuilder("login").
+		WithContext(ctx).
+		SetParam("at", time.Date(2024, time.March, 5, 20, 0, 0, 0, time.UTC))
+
+	response, err := config.BuildResponse(builder)
+	if err != nil {
+		t.Fatalf("BuildResponse failed: %v", err)
+	}
+	if response.Message != "Last login: Mar 6, 2024, 5:00 AM" {
+		t.Errorf("Expected 'Last login: Mar 6, 2024, 5:00 AM', got '%s'", response.Message)
+	}
+}
@@ -0,0 +1,339 @@
+package goresponse
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// CBOR major types (RFC 8949)
+const (
+	cborUnsigned = 0 << 5
+	cborNegative = 1 << 5
+	cborBytes    = 2 << 5
+	cborText     = 3 << 5
+	cborArray    = 4 << 5
+	cborMap      = 5 << 5
+	cborTag      = 6 << 5
+	cborSimple   = 7 << 5
+)
+
+// CBOREncoder encodes values as CBOR (RFC 8949)
+// Encoding is deterministic: integers use their shortest form, floats are float64
+// and map[string]any keys are sorted by length then bytes (Object fields keep their order)
+type CBOREncoder struct{}
+
+// ContentType returns the CBOR Content-Type
+func (CBOREncoder) ContentType() string {
+	return MediaTypeCBOR
+}
+
+// Encode writes v as CBOR
+func (CBOREncoder) Encode(w io.Writer, v any) error {
+	value, err := normalizeValue(v)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	if err := writeCBOR(bw, value); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// writeCBOR writes a normalized value
+func writeCBOR(w *bufio.Writer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		w.WriteByte(cborSimple | 22)
+	case bool:
+		if v {
+			w.WriteByte(cborSimple | 21)
+		} else {
+			w.WriteByte(cborSimple | 20)
+		}
+	case int64:
+		if v >= 0 {
+			writeCBORHead(w, cborUnsigned, uint64(v))
+		} else {
+			writeCBORHead(w, cborNegative, uint64(-(v + 1)))
+		}
+	case uint64:
+		writeCBORHead(w, cborUnsigned, v)
+	case float64:
+		w.WriteByte(cborSimple | 27)
+		binary.Write(w, binary.BigEndian, math.Float64bits(v))
+	case string:
+		writeCBORHead(w, cborText, uint64(len(v)))
+		w.WriteString(v)
+	case []any:
+		writeCBORHead(w, cborArray, uint64(len(v)))
+		for _, item := range v {
+			if err := writeCBOR(w, item); err != nil {
+				return err
+			}
+		}
+	case Object:
+		writeCBORHead(w, cborMap, uint64(len(v)))
+		for _, field := range v {
+			writeCBOR(w, field.Key)
+			if err := writeCBOR(w, field.Value); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		keys := sortedKeys(v)
+		sort.SliceStable(keys, func(i, j int) bool {
+			return len(keys[i]) < len(keys[j])
+		})
+		writeCBORHead(w, cborMap, uint64(len(v)))
+		for _, key := range keys {
+			writeCBOR(w, key)
+			if err := writeCBOR(w, v[key]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("cbor: %w %T", errUnsupportedValue, value)
+	}
+	return nil
+}
+
+// writeCBORHead writes a major type with its argument in the shortest form
+func writeCBORHead(w *bufio.Writer, major byte, n uint64) {
+	switch {
+	case n < 24:
+		w.WriteByte(major | byte(n))
+	case n <= math.MaxUint8:
+		w.Write([]byte{major | 24, byte(n)})
+	case n <= math.MaxUint16:
+		w.WriteByte(major | 25)
+		binary.Write(w, binary.BigEndian, uint16(n))
+	case n <= math.MaxUint32:
+		w.WriteByte(major | 26)
+		binary.Write(w, binary.BigEndian, uint32(n))
+	default:
+		w.WriteByte(major | 27)
+		binary.Write(w, binary.BigEndian, n)
+	}
+}
+
+// Decode reads one CBOR data item
+// Byte strings are returned as strings, tags are skipped, undefined decodes as nil
+// and negative integers below the int64 range are returned as float64
+func (CBOREncoder) Decode(r io.Reader) (any, error) {
+	return readCBOR(bufio.NewReader(r), 0)
+}
+
+// errCBORBreak is returned by readCBOR for the break stop code of indefinite-length items
+var errCBORBreak = errors.New("cbor: unexpected break")
+
+// readCBOR reads one data item
+func readCBOR(r *bufio.Reader, depth int) (any, error) {
+	if depth > maxDecodeDepth {
+		return nil, errors.New("cbor: maximum nesting depth exceeded")
+	}
+	b, err := r.ReadByte()
+	if err != nil {
+		if depth > 0 {
+			return nil, unexpectedEOF(err)
+		}
+		return nil, err
+	}
+	major, info := b&0xe0, b&0x1f
+
+	if major == cborSimple {
+		return readCBORSimple(r, info)
+	}
+	if info == 31 {
+		return readCBORIndefinite(r, major, depth)
+	}
+
+	n, err := readCBORArgument(r, info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUnsigned:
+		return normalizeUint(n), nil
+	case cborNegative:
+		if n <= math.MaxInt64 {
+			return -int64(n) - 1, nil
+		}
+		return -float64(n) - 1, nil
+	case cborBytes, cborText:
+		return readMsgPackString(r, n)
+	case cborArray:
+		items := make([]any, 0, min(n, 1024))
+		for i := uint64(0); i < n; i++ {
+			item, err := readCBORItem(r, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case cborMap:
+		object := make(map[string]any, min(n, 1024))
+		for i := uint64(0); i < n; i++ {
+			if err := readCBOREntry(r, object, depth); err != nil {
+				return nil, definiteCBORError(err)
+			}
+		}
+		return object, nil
+	default:
+		// Tags annotate the following item, which is returned as is
+		return readCBORItem(r, depth+1)
+	}
+}
+
+// readCBORItem reads one data item inside a definite-length item or a tag, where a break code is invalid
+func readCBORItem(r *bufio.Reader, depth int) (any, error) {
+	item, err := readCBOR(r, depth)
+	return item, definiteCBORError(err)
+}
+
+// definiteCBORError turns the break stop code into an error, so only the indefinite-length item that owns it ends
+func definiteCBORError(err error) error {
+	if err == errCBORBreak {
+		return errors.New("cbor: unexpected break in definite-length item")
+	}
+	return err
+}
+
+// readCBORArgument reads the argument of a data item head
+func readCBORArgument(r *bufio.Reader, info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info <= 27:
+		return readBigEndian(r, 1<<(info-24))
+	}
+	return 0, fmt.Errorf("cbor: invalid additional information %d", info)
+}
+
+// readCBORSimple reads simple values and floats
+func readCBORSimple(r *bufio.Reader, info byte) (any, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		n, err := readBigEndian(r, 2)
+		if err != nil {
+			return nil, err
+		}
+		return normalizeFloat(float16ToFloat64(uint16(n))), nil
+	case 26:
+		n, err := readBigEndian(r, 4)
+		if err != nil {
+			return nil, err
+		}
+		return normalizeFloat(float64(math.Float32frombits(uint32(n)))), nil
+	case 27:
+		n, err := readBigEndian(r, 8)
+		if err != nil {
+			return nil, err
+		}
+		return normalizeFloat(math.Float64frombits(n)), nil
+	case 31:
+		return nil, errCBORBreak
+	}
+	return nil, fmt.Errorf("cbor: unsupported simple value %d", info)
+}
+
+// readCBORIndefinite reads an indefinite-length string, array or map up to its break code
+func readCBORIndefinite(r *bufio.Reader, major byte, depth int) (any, error) {
+	switch major {
+	case cborBytes, cborText:
+		var data []byte
+		for {
+			chunk, err := readCBOR(r, depth+1)
+			if err == errCBORBreak {
+				return string(data), nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			text, ok := chunk.(string)
+			if !ok {
+				return nil, fmt.Errorf("cbor: invalid chunk %T in indefinite-length string", chunk)
+			}
+			data = append(data, text...)
+		}
+	case cborArray:
+		items := []any{}
+		for {
+			item, err := readCBOR(r, depth+1)
+			if err == errCBORBreak {
+				return items, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+	case cborMap:
+		object := make(map[string]any)
+		for {
+			err := readCBOREntry(r, object, depth)
+			if err == errCBORBreak {
+				return object, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return nil, fmt.Errorf("cbor: indefinite length is not allowed for major type %d", major>>5)
+}
+
+// readCBOREntry reads a map entry with a text key into object
+func readCBOREntry(r *bufio.Reader, object map[string]any, depth int) error {
+	key, err := readCBOR(r, depth+1)
+	if err != nil {
+		return err
+	}
+	name, ok := key.(string)
+	if !ok {
+		return fmt.Errorf("cbor: map key must be a string, got %T", key)
+	}
+	value, err := readCBOR(r, depth+1)
+	if err == errCBORBreak {
+		return fmt.Errorf("cbor: missing value for key %q", name)
+	}
+	if err != nil {
+		return err
+	}
+	object[name] = value
+	return nil
+}
+
+// float16ToFloat64 converts an IEEE 754 half-precision float
+func float16ToFloat64(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+	exponent := int(h>>10) & 0x1f
+	fraction := float64(h & 0x3ff)
+
+	switch exponent {
+	case 0:
+		return sign * math.Ldexp(fraction, -24)
+	case 0x1f:
+		if fraction == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	}
+	return sign * math.Ldexp(fraction+1024, exponent-25)
+}
@@ -0,0 +1,132 @@
+package goresponse
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestCBOREncode tests deterministic encoding of values
+func TestCBOREncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{name: "Small uint", value: 10, expected: "0a"},
+		{name: "Uint8", value: 100, expected: "1864"},
+		{name: "Uint16", value: 1000, expected: "1903e8"},
+		{name: "Uint32", value: 1000000, expected: "1a000f4240"},
+		{name: "Uint64", value: uint64(math.MaxUint64), expected: "1bffffffffffffffff"},
+		{name: "Negative", value: -1000, expected: "3903e7"},
+		{name: "Min int64", value: int64(math.MinInt64), expected: "3b7fffffffffffffff"},
+		{name: "Float", value: 1.5, expected: "fb3ff8000000000000"},
+		{name: "Simple values", value: []any{false, true, nil}, expected: "83f4f5f6"},
+		{name: "Text", value: "ü", expected: "62c3bc"},
+		{name: "Map keys sorted by length", value: map[string]any{"bb": 1, "a": 2, "c": 3}, expected: "a361610261630362626201"},
+		{name: "Object keeps order", value: Object{{Key: "b", Value: 1}, {Key: "a", Value: 2}}, expected: "a2616201616102"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := (CBOREncoder{}).Encode(&buf, tt.value); err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if hex.EncodeToString(buf.Bytes()) != tt.expected {
+				t.Errorf("Expected %s, got %x", tt.expected, buf.Bytes())
+			}
+		})
+	}
+}
+
+// TestCBORDecode tests decoding of RFC 8949 Appendix A examples
+func TestCBORDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{name: "Uint64", input: "1bffffffffffffffff", expected: uint64(math.MaxUint64)},
+		{name: "Negative", input: "3863", expected: int64(-100)},
+		{name: "Negative below int64", input: "3bffffffffffffffff", expected: -18446744073709551616.0},
+		{name: "Float16 integral", input: "f93c00", expected: int64(1)},
+		{name: "Float16 fraction", input: "f93e00", expected: 1.5},
+		{name: "Float16 subnormal", input: "f90001", expected: 5.960464477539063e-8},
+		{name: "Float16 infinity", input: "f9fc00", expected: math.Inf(-1)},
+		{name: "Float32", input: "fa47c35000", expected: int64(100000)},
+		{name: "Undefined", input: "f7", expected: nil},
+		{name: "Tag is skipped", input: "c074323031332d30332d32315432303a30343a30305a", expected: "2013-03-21T20:04:00Z"},
+		{name: "Byte string", input: "4401020304", expected: "\x01\x02\x03\x04"},
+		{name: "Indefinite string", input: "7f657374726561646d696e67ff", expected: "streaming"},
+		{name: "Indefinite array", input: "9f018202039f0405ffff", expected: []any{int64(1), []any{int64(2), int64(3)}, []any{int64(4), int64(5)}}},
+		{name: "Indefinite map", input: "bf61610161629f0203ffff", expected: map[string]any{"a": int64(1), "b": []any{int64(2), int64(3)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _ := hex.DecodeString(tt.input)
+			value, err := (CBOREncoder{}).Decode(bytes.NewReader(input))
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if !reflect.DeepEqual(value, tt.expected) {
+				t.Errorf("Expected %#v, got %#v", tt.expected, value)
+			}
+		})
+	}
+
+	t.Run("NaN", func(t *testing.T) {
+		value, err := (CBOREncoder{}).Decode(bytes.NewReader([]byte{0xf9, 0x7e, 0x00}))
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if f, ok := value.(float64); !ok || !math.IsNaN(f) {
+			t.Errorf("Expected NaN, got %#v", value)
+		}
+	})
+}
+
+// TestCBORDecodeErrors tests malformed CBOR input
+func TestCBORDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		errorContains string
+	}{
+		{name: "Truncated array", input: "8301", errorContains: io.ErrUnexpectedEOF.Error()},
+		{name: "Truncated argument", input: "19", errorContains: io.ErrUnexpectedEOF.Error()},
+		{name: "Truncated text", input: "6361", errorContains: io.ErrUnexpectedEOF.Error()},
+		{name: "Integer key", input: "a10102", errorContains: "map key must be a string"},
+		{name: "Reserved additional information", input: "1c", errorContains: "invalid additional information"},
+		{name: "Indefinite integer", input: "1f", errorContains: "indefinite length is not allowed"},
+		{name: "Stray break", input: "ff", errorContains: "unexpected break"},
+		{name: "Break in definite array", input: "9f018202ffff", errorContains: "unexpected break in definite-length item"},
+		{name: "Break as definite map key", input: "9fa1ffff", errorContains: "unexpected break in definite-length item"},
+		{name: "Break after tag", input: "9fc1ffff", errorContains: "unexpected break in definite-length item"},
+		{name: "Missing map value", input: "bf6161ff", errorContains: "missing value"},
+		{name: "Non-text chunk", input: "7f01ff", errorContains: "invalid chunk"},
+		{name: "Nesting", input: strings.Repeat("81", maxDecodeDepth+2), errorContains: "maximum nesting depth"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _ := hex.DecodeString(tt.input)
+			_, err := (CBOREncoder{}).Decode(bytes.NewReader(input))
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing '%s', got %v", tt.errorContains, err)
+			}
+		})
+	}
+
+	t.Run("Empty input", func(t *testing.T) {
+		if _, err := (CBOREncoder{}).Decode(bytes.NewReader(nil)); !errors.Is(err, io.EOF) {
+			t.Errorf("Expected io.EOF, got %v", err)
+		}
+	})
+}
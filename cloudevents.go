@@ -0,0 +1,287 @@
+package goresponse
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MediaTypeCloudEventsJSON is the media type of CloudEvents in structured JSON mode
+const MediaTypeCloudEventsJSON = "application/cloudevents+json"
+
+// CloudEventsSpecVersion is the CloudEvents version written in "specversion"
+const CloudEventsSpecVersion = "1.0"
+
+// cloudEventAttributes are the context attributes of CloudEvent, which extensions cannot redefine
+var cloudEventAttributes = []string{"specversion", "id", "source", "type", "subject", "time", "datacontenttype", "language", "data", "data_base64"}
+
+// CloudEvent represents a CloudEvents 1.0 event whose data is a response envelope
+type CloudEvent struct {
+	ID              string         // Identifier of the event, unique per source
+	Source          string         // URI reference of the producer
+	Type            string         // Event type, derived from the template key
+	Subject         string         // Subject of the event in the context of the source
+	Time            time.Time      // Time of the occurrence
+	DataContentType string         // Content type of data ("application/json")
+	Data            *Response      // Response carried in data
+	Language        string         // Language of the message, written as the "language" extension
+	Extensions      map[string]any // Other extension attributes
+}
+
+// CloudEventOption configures BuildCloudEvent
+type CloudEventOption func(*cloudEventOptions)
+
+// cloudEventOptions holds the settings of BuildCloudEvent
+type cloudEventOptions struct {
+	source     string
+	typePrefix string
+	id         string
+	subject    string
+}
+
+// WithEventSource sets the source of the event, it is required
+func WithEventSource(source string) CloudEventOption {
+	return func(o *cloudEventOptions) {
+		o.source = source
+	}
+}
+
+// WithEventTypePrefix sets the prefix of the event type, e.g. "com.example.users." for "com.example.users.user_created"
+func WithEventTypePrefix(prefix string) CloudEventOption {
+	return func(o *cloudEventOptions) {
+		o.typePrefix = prefix
+	}
+}
+
+// WithEventID sets the id of the event, a random id is used by default
+// The id must be unique per source, so it is only set for an event that is built once
+func WithEventID(id string) CloudEventOption {
+	return func(o *cloudEventOptions) {
+		o.id = id
+	}
+}
+
+// WithEventSubject sets the subject of the event
+func WithEventSubject(subject string) CloudEventOption {
+	return func(o *cloudEventOptions) {
+		o.subject = subject
+	}
+}
+
+// BuildCloudEvent builds the response and wraps its envelope in a CloudEvents 1.0 event
+// The id is random unless WithEventID is set, the type is the template key with the type prefix
+// and the time is the builder timestamp (SetTimestamp) or the current time
+// The language of the message, defaulting to the default language, is written as the "language" extension
+// and the request ID of the builder context as the "requestid" extension
+func (c *ResponseConfig) BuildCloudEvent(rb *ResponseBuilder, opts ...CloudEventOption) (*CloudEvent, error) {
+	var options cloudEventOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.source == "" {
+		return nil, fmt.Errorf("cloudevent source is required")
+	}
+
+	response, err := c.BuildResponse(rb)
+	if err != nil {
+		return nil, err
+	}
+
+	id := options.id
+	if id == "" {
+		if id, err = randomEventID(); err != nil {
+			return nil, err
+		}
+	}
+	timestamp := rb.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	language := response.Language
+	if language == "" {
+		language = c.GetDefaultLanguage()
+	}
+
+	event := &CloudEvent{
+		ID:              id,
+		Source:          options.source,
+		Type:            options.typePrefix + rb.MessageKey,
+		Subject:         options.subject,
+		Time:            timestamp.UTC(),
+		DataContentType: "application/json",
+		Data:            response,
+		Language:        language,
+	}
+	// Events of one request share the request ID, so it is an extension rather than the event id
+	if requestID := GetRequestID(rb.Context); requestID != "" {
+		event.Extensions = map[string]any{"requestid": requestID}
+	}
+	return event, nil
+}
+
+// randomEventID returns a random 128-bit hex id
+func randomEventID() (string, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", fmt.Errorf("failed to generate cloudevent id: %w", err)
+	}
+	return hex.EncodeToString(id[:]), nil
+}
+
+// Members returns the event as it is encoded: the context attributes, extensions in key order and data
+func (e *CloudEvent) Members() Object {
+	members := Object{
+		{Key: "specversion", Value: CloudEventsSpecVersion},
+		{Key: "id", Value: e.ID},
+		{Key: "source", Value: e.Source},
+		{Key: "type", Value: e.Type},
+	}
+	if e.Subject != "" {
+		members = append(members, Field{Key: "subject", Value: e.Subject})
+	}
+	if !e.Time.IsZero() {
+		members = append(members, Field{Key: "time", Value: e.Time.Format(time.RFC3339Nano)})
+	}
+	if e.DataContentType != "" {
+		members = append(members, Field{Key: "datacontenttype", Value: e.DataContentType})
+	}
+	if e.Language != "" {
+		members = append(members, Field{Key: "language", Value: e.Language})
+	}
+	for _, key := range sortedKeys(e.Extensions) {
+		if !slices.Contains(cloudEventAttributes, key) {
+			members = append(members, Field{Key: key, Value: e.Extensions[key]})
+		}
+	}
+	if e.Data != nil {
+		members = append(members, Field{Key: "data", Value: e.Data.Envelope()})
+	}
+	return members
+}
+
+// MarshalJSON writes the event in structured JSON mode
+func (e CloudEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Members())
+}
+
+// UnmarshalJSON reads an event in structured JSON mode, decoding data into a Response
+func (e *CloudEvent) UnmarshalJSON(data []byte) error {
+	event, err := DecodeCloudEvent(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	*e = *event
+	return nil
+}
+
+// DecodeCloudEvent reads a CloudEvents 1.0 event in structured JSON mode
+// The response envelope of the default shape in data becomes Data, with the "language" extension as its language
+func DecodeCloudEvent(r io.Reader) (*CloudEvent, error) {
+	return decodeCloudEvent(r, nil)
+}
+
+// DecodeCloudEvent reads a CloudEvents 1.0 event whose data is a response envelope in the envelope shape of the config
+func (c *ResponseConfig) DecodeCloudEvent(r io.Reader) (*CloudEvent, error) {
+	return decodeCloudEvent(r, c.GetEnvelope())
+}
+
+// decodeCloudEvent reads a structured JSON event with the data envelope in the shape, the default shape when nil
+func decodeCloudEvent(r io.Reader, shape *EnvelopeShape) (*CloudEvent, error) {
+	value, err := (JSONEncoder{}).Decode(r)
+	if err != nil {
+		return nil, err
+	}
+	attributes, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid cloudevent: expected an object, got %T", value)
+	}
+	if version := attributes["specversion"]; version != CloudEventsSpecVersion {
+		return nil, fmt.Errorf("invalid cloudevent: unsupported specversion %v", version)
+	}
+
+	event := &CloudEvent{}
+	for key, value := range attributes {
+		var target *string
+		switch key {
+		case "specversion", "data":
+			continue
+		case "id":
+			target = &event.ID
+		case "source":
+			target = &event.Source
+		case "type":
+			target = &event.Type
+		case "subject":
+			target = &event.Subject
+		case "datacontenttype":
+			target = &event.DataContentType
+		case "language":
+			target = &event.Language
+		case "time":
+			text, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid cloudevent: time has an invalid type %T", value)
+			}
+			if event.Time, err = time.Parse(time.RFC3339Nano, text); err != nil {
+				return nil, fmt.Errorf("invalid cloudevent: time %q is not RFC 3339", text)
+			}
+			continue
+		case "data_base64":
+			return nil, fmt.Errorf("invalid cloudevent: binary data is not supported")
+		default:
+			if event.Extensions == nil {
+				event.Extensions = make(map[string]any)
+			}
+			event.Extensions[key] = value
+			continue
+		}
+		if *target, ok = value.(string); !ok {
+			return nil, fmt.Errorf("invalid cloudevent: %s has an invalid type %T", key, value)
+		}
+	}
+	for _, attribute := range []struct{ key, value string }{{"id", event.ID}, {"source", event.Source}, {"type", event.Type}} {
+		if attribute.value == "" {
+			return nil, fmt.Errorf("invalid cloudevent: %s is required", attribute.key)
+		}
+	}
+
+	if data, exists := attributes["data"]; exists && data != nil {
+		if !isJSONMediaType(event.DataContentType) {
+			return nil, fmt.Errorf("invalid cloudevent: unsupported datacontenttype %s", event.DataContentType)
+		}
+		if event.Data, err = responseFromEnvelope(data, shape); err != nil {
+			return nil, err
+		}
+		event.Data.Language = event.Language
+	}
+	return event, nil
+}
+
+// isJSONMediaType reports whether a content type is JSON, an empty content type means JSON in structured mode
+func isJSONMediaType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	return mediaType == "" || mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// WriteTo encodes the event as application/cloudevents+json to w
+// When w is an http.ResponseWriter the status is set from the response in Data, along with Content-Type and Content-Language
+func (e *CloudEvent) WriteTo(w io.Writer) (int64, error) {
+	var body bytes.Buffer
+	if err := (JSONEncoder{}).Encode(&body, e.Members()); err != nil {
+		return 0, err
+	}
+	status := http.StatusOK
+	if e.Data != nil {
+		status = e.Data.httpStatus()
+	}
+	writeHTTPHeader(w, MediaTypeCloudEventsJSON, e.Language, status)
+	return body.WriteTo(w)
+}
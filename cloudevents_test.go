@@ -0,0 +1,205 @@
+package goresponse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestBuildCloudEvent tests wrapping responses in CloudEvents
+func TestBuildCloudEvent(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"export_done": {
+				Key:          "export_done",
+				Template:     "Export of $count rows finished",
+				Translations: map[string]string{"id": "Ekspor $count baris selesai"},
+				CodeMappings: map[string]int{"http": 200},
+			},
+			"export_failed": {
+				Key:          "export_failed",
+				Template:     "Export failed",
+				CodeMappings: map[string]int{"http": 500},
+			},
+		},
+		DefaultLanguage: "en",
+	}
+	now := time.Date(2025, 3, 1, 10, 30, 0, 0, time.FixedZone("WIB", 7*60*60))
+
+	tests := []struct {
+		name     string
+		builder  *ResponseBuilder
+		opts     []CloudEventOption
+		expected string
+	}{
+		{
+			name: "Localized result",
+			builder: NewResponseBuilder("export_done").SetProtocol("http").SetLanguage("id").SetTimestamp(now).
+				SetParam("count", 3).SetData("file", "export.csv"),
+			opts: []CloudEventOption{WithEventSource("/workers/export"), WithEventTypePrefix("com.example.export."), WithEventID("evt-1"), WithEventSubject("exports/9")},
+			expected: `{"specversion":"1.0","id":"evt-1","source":"/workers/export","type":"com.example.export.export_done","subject":"exports/9",` +
+				`"time":"2025-03-01T03:30:00Z","datacontenttype":"application/json","language":"id",` +
+				`"data":{"code":200,"message":"Ekspor 3 baris selesai","data":{"file":"export.csv"}}}`,
+		},
+		{
+			name:    "Request id extension",
+			builder: NewResponseBuilder("export_failed").SetProtocol("http").WithContext(WithRequestID(context.Background(), "req-7")).SetTimestamp(now).SetError(errors.New("disk full")),
+			opts:    []CloudEventOption{WithEventSource("/workers/export"), WithEventID("evt-2")},
+			expected: `{"specversion":"1.0","id":"evt-2","source":"/workers/export","type":"export_failed",` +
+				`"time":"2025-03-01T03:30:00Z","datacontenttype":"application/json","language":"en","requestid":"req-7",` +
+				`"data":{"code":500,"message":"Export failed"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := config.BuildCloudEvent(tt.builder, tt.opts...)
+			if err != nil {
+				t.Fatalf("BuildCloudEvent failed: %v", err)
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+
+	t.Run("Random id", func(t *testing.T) {
+		event, err := config.BuildCloudEvent(NewResponseBuilder("export_done").SetParam("count", 1), WithEventSource("/workers"))
+		if err != nil {
+			t.Fatalf("BuildCloudEvent failed: %v", err)
+		}
+		if len(event.ID) != 32 || event.Time.IsZero() {
+			t.Errorf("Expected random id and current time, got %q and %v", event.ID, event.Time)
+		}
+	})
+
+	t.Run("Time is independent of the reference time", func(t *testing.T) {
+		pinned := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		event, err := config.BuildCloudEvent(NewResponseBuilder("export_done").SetNow(pinned), WithEventSource("/workers"))
+		if err != nil {
+			t.Fatalf("BuildCloudEvent failed: %v", err)
+		}
+		if event.Time.Equal(pinned) || time.Since(event.Time) > time.Minute {
+			t.Errorf("Expected current time, got %v", event.Time)
+		}
+	})
+
+	t.Run("Unique ids within a request", func(t *testing.T) {
+		ctx := WithRequestID(context.Background(), "req-1")
+		first, err := config.BuildCloudEvent(NewResponseBuilder("export_done").WithContext(ctx), WithEventSource("/workers"))
+		if err != nil {
+			t.Fatalf("BuildCloudEvent failed: %v", err)
+		}
+		second, err := config.BuildCloudEvent(NewResponseBuilder("export_done").WithContext(ctx), WithEventSource("/workers"))
+		if err != nil {
+			t.Fatalf("BuildCloudEvent failed: %v", err)
+		}
+		if first.ID == second.ID || first.ID == "req-1" {
+			t.Errorf("Expected distinct random ids, got %q and %q", first.ID, second.ID)
+		}
+		if first.Extensions["requestid"] != "req-1" || second.Extensions["requestid"] != "req-1" {
+			t.Errorf("Expected requestid extension req-1, got %v and %v", first.Extensions, second.Extensions)
+		}
+	})
+
+	t.Run("Missing source", func(t *testing.T) {
+		_, err := config.BuildCloudEvent(NewResponseBuilder("export_done"))
+		if err == nil || !strings.Contains(err.Error(), "source is required") {
+			t.Errorf("Expected source error, got %v", err)
+		}
+	})
+
+	t.Run("Missing template", func(t *testing.T) {
+		if _, err := config.BuildCloudEvent(NewResponseBuilder("missing"), WithEventSource("/workers")); err == nil {
+			t.Error("Expected error for missing template")
+		}
+	})
+}
+
+// TestDecodeCloudEvent tests turning CloudEvents back into responses
+func TestDecodeCloudEvent(t *testing.T) {
+	data := `{"specversion":"1.0","id":"evt-1","source":"/workers/export","type":"export_done",` +
+		`"time":"2025-03-01T03:30:00Z","datacontenttype":"application/json","language":"id","traceparent":"00-abc",` +
+		`"data":{"code":200,"message":"Ekspor 3 baris selesai","data":{"rows":3}}}`
+
+	event, err := DecodeCloudEvent(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("DecodeCloudEvent failed: %v", err)
+	}
+	expected := &CloudEvent{
+		ID:              "evt-1",
+		Source:          "/workers/export",
+		Type:            "export_done",
+		Time:            time.Date(2025, 3, 1, 3, 30, 0, 0, time.UTC),
+		DataContentType: "application/json",
+		Data:            &Response{Code: 200, Message: "Ekspor 3 baris selesai", Data: map[string]any{"rows": int64(3)}, Language: "id"},
+		Language:        "id",
+		Extensions:      map[string]any{"traceparent": "00-abc"},
+	}
+	if !reflect.DeepEqual(event, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, event)
+	}
+
+	var unmarshaled CloudEvent
+	if err := json.Unmarshal([]byte(data), &unmarshaled); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	encoded, _ := json.Marshal(unmarshaled)
+	if string(encoded) != data {
+		t.Errorf("Expected round trip %s, got %s", data, encoded)
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			data          string
+			errorContains string
+		}{
+			{name: "Not an object", data: `[]`, errorContains: "expected an object"},
+			{name: "Wrong version", data: `{"specversion":"0.3","id":"1","source":"s","type":"t"}`, errorContains: "unsupported specversion"},
+			{name: "Missing id", data: `{"specversion":"1.0","source":"s","type":"t"}`, errorContains: "id is required"},
+			{name: "Invalid source", data: `{"specversion":"1.0","id":"1","source":1,"type":"t"}`, errorContains: "source has an invalid type"},
+			{name: "Invalid time", data: `{"specversion":"1.0","id":"1","source":"s","type":"t","time":"yesterday"}`, errorContains: "not RFC 3339"},
+			{name: "Binary data", data: `{"specversion":"1.0","id":"1","source":"s","type":"t","data_base64":"AA=="}`, errorContains: "binary data"},
+			{name: "Non-JSON data", data: `{"specversion":"1.0","id":"1","source":"s","type":"t","datacontenttype":"text/plain","data":"x"}`, errorContains: "unsupported datacontenttype"},
+			{name: "Invalid envelope", data: `{"specversion":"1.0","id":"1","source":"s","type":"t","data":{"code":"x"}}`, errorContains: "code must be an integer"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := DecodeCloudEvent(strings.NewReader(tt.data))
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("Expected error containing '%s', got %v", tt.errorContains, err)
+				}
+			})
+		}
+	})
+}
+
+// TestCloudEventWriteTo tests writing events over HTTP
+func TestCloudEventWriteTo(t *testing.T) {
+	rec := httptest.NewRecorder()
+	event := &CloudEvent{ID: "1", Source: "/s", Type: "t", Data: &Response{Code: 404, Message: "Tidak ada"}, Language: "id"}
+	if _, err := event.WriteTo(rec); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if rec.Code != 404 {
+		t.Errorf("Expected status 404, got %d", rec.Code)
+	}
+	if rec.Header().Get("Content-Type") != MediaTypeCloudEventsJSON || rec.Header().Get("Content-Language") != "id" {
+		t.Errorf("Unexpected headers %v", rec.Header())
+	}
+	expected := `{"specversion":"1.0","id":"1","source":"/s","type":"t","language":"id","data":{"code":404,"message":"Tidak ada"}}` + "\n"
+	if rec.Body.String() != expected {
+		t.Errorf("Expected body %q, got %q", expected, rec.Body.String())
+	}
+}
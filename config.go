@@ -89,7 +89,19 @@ func loadTranslationSources(config *ResponseConfig) error {
 
 	// Load translations for each language
 	for lang, source := range config.TranslationSources {
-		translations, err := loadTranslationFromSource(source)
+		if source.sourceFormat() == SourceFormatFluent {
+			bundle, err := loadFluentFromSource(lang, source)
+			if err != nil {
+				return fmt.Errorf("failed to load translations for language %s: %w", lang, err)
+			}
+			if config.FluentBundles == nil {
+				config.FluentBundles = make(map[string]*FluentBundle)
+			}
+			config.FluentBundles[lang] = bundle
+			continue
+		}
+
+		translations, variants, err := loadTranslationFromSource(source)
 		if err != nil {
 			return fmt.Errorf("failed to load translations for language %s: %w", lang, err)
 		}
@@ -102,6 +114,19 @@ func loadTranslationSources(config *ResponseConfig) error {
 		// Update/override with translations from source
 		for key, value := range translations {
 			config.Translations[lang][key] = value
+			delete(config.TranslationVariants[lang], key)
+		}
+
+		// Update/override with translation variants from source
+		for key, forms := range variants {
+			if config.TranslationVariants == nil {
+				config.TranslationVariants = make(map[string]map[string]Variants)
+			}
+			if config.TranslationVariants[lang] == nil {
+				config.TranslationVariants[lang] = make(map[string]Variants)
+			}
+			config.TranslationVariants[lang][key] = forms
+			delete(config.Translations[lang], key)
 		}
 	}
 
@@ -109,41 +134,99 @@ func loadTranslationSources(config *ResponseConfig) error {
 }
 
 // loadTranslationFromSource loads translations from source (file or URL)
-func loadTranslationFromSource(source TranslationSource) (map[string]string, error) {
-	var data []byte
-	var err error
+// Each translation may be a string or an object of variants keyed by plural category
+func loadTranslationFromSource(source TranslationSource) (map[string]string, map[string]Variants, error) {
+	if format := source.sourceFormat(); format != SourceFormatJSON {
+		return nil, nil, fmt.Errorf("unsupported translation source format: %s. Supported formats: json, fluent", format)
+	}
 
-	switch strings.ToLower(source.Method) {
-	case "file":
-		data, err = loadFromFile(source.Path)
-	case "url":
-		data, err = loadFromURL(source.Path)
-	default:
-		return nil, fmt.Errorf("unsupported translation source method: %s. Supported methods: file, url", source.Method)
+	data, err := loadSourceData(source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal translations: %w", err)
 	}
 
+	translations, variants, err := splitTranslations(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal translations: %w", err)
+	}
+
+	return translations, variants, nil
+}
+
+// loadFluentFromSource loads a Fluent (.ftl) catalog from source (file or URL)
+func loadFluentFromSource(lang string, source TranslationSource) (*FluentBundle, error) {
+	data, err := loadSourceData(source)
 	if err != nil {
 		return nil, err
 	}
 
-	var translations map[string]string
-	if err := json.Unmarshal(data, &translations); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal translations: %w", err)
+	bundle, err := NewFluentBundle(lang, string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Fluent translations: %w", err)
 	}
+	return bundle, nil
+}
 
-	return translations, nil
+// loadSourceData reads the raw data of a translation source
+func loadSourceData(source TranslationSource) ([]byte, error) {
+	switch strings.ToLower(source.Method) {
+	case "file":
+		return loadFromFile(source.Path)
+	case "url":
+		return loadFromURL(source.Path)
+	default:
+		return nil, fmt.Errorf("unsupported translation source method: %s. Supported methods: file, url", source.Method)
+	}
 }
 
 // GetTranslation gets translation based on language and key
+// The tag's parents and configured language fallbacks are tried in order (zh-Hant-TW -> zh-Hant -> zh)
+// For translations with plural variants the "other" variant is returned
+// Fluent messages take priority and are rendered without arguments
 func (c *ResponseConfig) GetTranslation(lang, key string) (string, bool) {
-	if translations, exists := c.Translations[lang]; exists {
-		if translation, exists := translations[key]; exists {
+	for _, tag := range c.languageChain(lang, false) {
+		bundle, _ := lookupLanguage(c.FluentBundles, tag)
+		if translation, exists := bundle.Format(key, nil); exists {
 			return translation, true
 		}
+		if translations, exists := lookupLanguage(c.Translations, tag); exists {
+			if translation, exists := translations[key]; exists {
+				return translation, true
+			}
+		}
+		if variants, exists := c.translationVariants(tag, key); exists {
+			return variants.Select()
+		}
 	}
 	return "", false
 }
 
+// GetTranslationVariants gets translation variants based on language and key
+// The tag's parents and configured language fallbacks are tried in order
+func (c *ResponseConfig) GetTranslationVariants(lang, key string) (Variants, bool) {
+	for _, tag := range c.languageChain(lang, false) {
+		if variants, exists := c.translationVariants(tag, key); exists {
+			return variants, true
+		}
+	}
+	return nil, false
+}
+
+// translationVariants gets translation variants for exactly one language
+func (c *ResponseConfig) translationVariants(lang, key string) (Variants, bool) {
+	if variants, exists := lookupLanguage(c.TranslationVariants, lang); exists {
+		if forms, exists := variants[key]; exists {
+			return forms, true
+		}
+	}
+	return nil, false
+}
+
 // GetMessageTemplate gets message template based on key (manual priority > async)
 func (c *ResponseConfig) GetMessageTemplate(key string) (*MessageTemplate, bool) {
 	// Priority 1: Manual templates (added manually)
@@ -163,6 +246,11 @@ func (c *ResponseConfig) GetMessageTemplate(key string) (*MessageTemplate, bool)
 	return nil, false
 }
 
+// GetConfig returns the configuration itself so a ResponseConfig can be used as a Provider
+func (c *ResponseConfig) GetConfig() *ResponseConfig {
+	return c
+}
+
 // GetSupportedLanguages returns list of supported languages
 func (c *ResponseConfig) GetSupportedLanguages() []string {
 	return c.Languages
@@ -236,6 +324,7 @@ func (c *ResponseConfig) AddMessageTemplate(template *MessageTemplate) {
 		c.ManualMessageTemplates = make(map[string]MessageTemplate)
 	}
 	c.ManualMessageTemplates[template.Key] = *template
+	c.textTemplateCache().invalidate(template.Key)
 }
 
 // AddMessageTemplates adds multiple message templates
@@ -250,6 +339,7 @@ func (c *ResponseConfig) RemoveMessageTemplate(key string) {
 	if c.ManualMessageTemplates != nil {
 		delete(c.ManualMessageTemplates, key)
 	}
+	c.textTemplateCache().invalidate(key)
 }
 
 // UpdateMessageTemplate updates existing message template (manual priority)
@@ -258,34 +348,28 @@ func (c *ResponseConfig) UpdateMessageTemplate(template *MessageTemplate) {
 }
 
 // GetMessageTemplateTranslation gets translation from message template (manual priority > async)
+// Languages are tried along the language chain, ending with the default language
 func (c *ResponseConfig) GetMessageTemplateTranslation(templateKey, lang string) (string, bool) {
-	// Priority 1: Manual templates
-	if c.ManualMessageTemplates != nil {
-		if template, exists := c.ManualMessageTemplates[templateKey]; exists {
-			if translations := template.Translations; translations != nil {
-				if translation, exists := translations[lang]; exists {
+	for _, tag := range c.languageChain(lang, true) {
+		// Priority 1: Manual templates
+		if c.ManualMessageTemplates != nil {
+			if template, exists := c.ManualMessageTemplates[templateKey]; exists {
+				if translation, exists := template.translation(tag); exists {
 					return translation, true
 				}
 			}
 		}
-	}
 
-	// Priority 2: Async templates
-	if c.MessageTemplates != nil {
-		if template, exists := c.MessageTemplates[templateKey]; exists {
-			if translations := template.Translations; translations != nil {
-				if translation, exists := translations[lang]; exists {
+		// Priority 2: Async templates
+		if c.MessageTemplates != nil {
+			if template, exists := c.MessageTemplates[templateKey]; exists {
+				if translation, exists := template.translation(tag); exists {
 					return translation, true
 				}
 			}
 		}
 	}
 
-	// Fallback to default language
-	if lang != c.GetDefaultLanguage() {
-		return c.GetMessageTemplateTranslation(templateKey, c.GetDefaultLanguage())
-	}
-
 	// Fallback to template string
 	if template, exists := c.GetMessageTemplate(templateKey); exists {
 		return template.Template, true
@@ -470,6 +470,41 @@ func TestLoadTranslationSources(t *testing.T) {
 	}
 }
 
+// TestLoadTranslationSourceVariants tests translation sources with plural variants
+func TestLoadTranslationSourceVariants(t *testing.T) {
+	filename := "variants_en.json"
+	content := `{"files": {"one": "$count file", "other": "$count files"}, "hello": "Hello", "bye": {"other": "Bye"}}`
+	if err := ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file %s: %v", filename, err)
+	}
+	defer os.Remove(filename)
+
+	config := &ResponseConfig{
+		Translations: map[string]map[string]string{
+			"en": {"files": "Files", "bye": "Goodbye"},
+		},
+		TranslationSources: map[string]TranslationSource{
+			"en": {Method: "file", Path: filename},
+		},
+	}
+	if err := loadTranslationSources(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if forms, exists := config.GetTranslationVariants("en", "files"); !exists || forms[PluralOne] != "$count file" {
+		t.Errorf("Expected variants for files, got %v", forms)
+	}
+	if _, exists := config.Translations["en"]["files"]; exists {
+		t.Error("Expected inline string translation to be overridden by source variants")
+	}
+	if translation, _ := config.GetTranslation("en", "bye"); translation != "Bye" {
+		t.Errorf("Expected GetTranslation to return the other variant, got %q", translation)
+	}
+	if translation, _ := config.GetTranslation("en", "hello"); translation != "Hello" {
+		t.Errorf("Expected 'Hello', got %q", translation)
+	}
+}
+
 // TestResponseConfigMethods tests ResponseConfig methods
 func TestResponseConfigMethods(t *testing.T) {
 	config := &ResponseConfig{
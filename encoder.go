@@ -0,0 +1,525 @@
+package goresponse
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Media types of the built-in encoders
+const (
+	MediaTypeJSON    = "application/json"
+	MediaTypeXML     = "application/xml"
+	MediaTypeMsgPack = "application/msgpack"
+	MediaTypeCBOR    = "application/cbor"
+)
+
+// Encoder encodes and decodes response envelopes for a media type
+// Values are encoded as JSON-compatible trees: nil, bool, int64, uint64, float64, string, []any,
+// map[string]any (written with sorted keys) and Object (written in field order)
+// Other values, such as structs in Data, are converted through their JSON encoding
+// iter.Seq and iter.Seq2 values are encoded as arrays and objects; JSONEncoder streams them element by element
+// Decode returns the same kinds of values with objects as map[string]any
+type Encoder interface {
+	ContentType() string             // Content-Type header of encoded responses
+	Encode(w io.Writer, v any) error // Encode writes v to w
+	Decode(r io.Reader) (any, error) // Decode reads one value from r
+}
+
+// Field is a key and value of an Object
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Object is an ordered set of fields, encoded in field order
+// Response envelopes are Objects so "code" and "message" come first in every format
+type Object []Field
+
+// Get returns the value of a field
+func (o Object) Get(key string) (any, bool) {
+	for _, field := range o {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+// MarshalJSON writes the fields as a JSON object in field order
+func (o Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	if err := writeJSON(w, o); err != nil {
+		return nil, err
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encoderRegistry stores encoders keyed by lowercase media type, in registration order
+var encoderRegistry = struct {
+	mu         sync.RWMutex
+	encoders   map[string]Encoder
+	mediaTypes []string
+}{
+	encoders: map[string]Encoder{
+		MediaTypeJSON:             JSONEncoder{},
+		MediaTypeXML:              XMLEncoder{},
+		"text/xml":                XMLEncoder{},
+		MediaTypeMsgPack:          MsgPackEncoder{},
+		"application/x-msgpack":   MsgPackEncoder{},
+		"application/vnd.msgpack": MsgPackEncoder{},
+		MediaTypeCBOR:             CBOREncoder{},
+		MediaTypeProblemJSON:      JSONEncoder{},
+		MediaTypeJSONAPI:          JSONEncoder{},
+	},
+	mediaTypes: []string{MediaTypeJSON, MediaTypeXML, "text/xml", MediaTypeMsgPack, "application/x-msgpack", "application/vnd.msgpack", MediaTypeCBOR, MediaTypeProblemJSON, MediaTypeJSONAPI},
+}
+
+// RegisterEncoder adds or replaces the encoder of a media type
+func RegisterEncoder(mediaType string, encoder Encoder) {
+	mediaType = normalizeMediaType(mediaType)
+
+	encoderRegistry.mu.Lock()
+	defer encoderRegistry.mu.Unlock()
+	if _, exists := encoderRegistry.encoders[mediaType]; !exists {
+		encoderRegistry.mediaTypes = append(encoderRegistry.mediaTypes, mediaType)
+	}
+	encoderRegistry.encoders[mediaType] = encoder
+}
+
+// LookupEncoder finds the encoder of a media type, ignoring parameters such as charset
+func LookupEncoder(mediaType string) (Encoder, bool) {
+	encoderRegistry.mu.RLock()
+	defer encoderRegistry.mu.RUnlock()
+	encoder, exists := encoderRegistry.encoders[normalizeMediaType(mediaType)]
+	return encoder, exists
+}
+
+// NegotiateEncoder picks the encoder for an Accept header
+// Media ranges are tried by quality, with exact types before "type/*" and "*/*" at equal quality
+// Types excluded with q=0 are skipped, JSON is used when nothing matches
+func NegotiateEncoder(accept string) (string, Encoder) {
+	type mediaRange struct {
+		mediaType string
+		quality   float64
+	}
+
+	var ranges []mediaRange
+	excluded := make(map[string]bool)
+	for _, element := range strings.Split(accept, ",") {
+		parts := strings.Split(element, ";")
+		mediaType := normalizeMediaType(parts[0])
+		if !strings.Contains(mediaType, "/") {
+			continue
+		}
+		quality := 1.0
+		for _, param := range parts[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(strings.TrimSpace(name), "q") {
+				if q, ok := parseQuality(strings.TrimSpace(value)); ok {
+					quality = q
+				}
+			}
+		}
+		if quality == 0 {
+			excluded[mediaType] = true
+			continue
+		}
+		ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+	}
+
+	specificity := func(mediaType string) int {
+		switch {
+		case mediaType == "*/*":
+			return 0
+		case strings.HasSuffix(mediaType, "/*"):
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].quality != ranges[j].quality {
+			return ranges[i].quality > ranges[j].quality
+		}
+		return specificity(ranges[i].mediaType) > specificity(ranges[j].mediaType)
+	})
+
+	encoderRegistry.mu.RLock()
+	defer encoderRegistry.mu.RUnlock()
+	for _, r := range ranges {
+		prefix, wildcard := strings.CutSuffix(r.mediaType, "*")
+		for _, mediaType := range encoderRegistry.mediaTypes {
+			if excluded[mediaType] {
+				continue
+			}
+			if mediaType == r.mediaType || (wildcard && strings.HasPrefix(mediaType, prefix)) {
+				return mediaType, encoderRegistry.encoders[mediaType]
+			}
+		}
+	}
+	return MediaTypeJSON, encoderRegistry.encoders[MediaTypeJSON]
+}
+
+// normalizeMediaType lowercases a media type and strips its parameters
+func normalizeMediaType(mediaType string) string {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// Envelope returns the response as it is encoded: code, message, and data, meta and field errors when not empty
+// A configured Shape renames, nests and adds members (see EnvelopeShape)
+func (r *Response) Envelope() Object {
+	if r.Shape != nil {
+		return r.Shape.envelope(r, r.Data, len(r.Data) > 0)
+	}
+	envelope := Object{{Key: "code", Value: r.Code}, {Key: "message", Value: r.Message}}
+	if len(r.Data) > 0 {
+		envelope = append(envelope, Field{Key: "data", Value: r.Data})
+	}
+	if len(r.Meta) > 0 {
+		envelope = append(envelope, Field{Key: "meta", Value: r.Meta})
+	}
+	if len(r.FieldErrors) > 0 {
+		envelope = append(envelope, Field{Key: "errors", Value: fieldErrorObjects(r.FieldErrors)})
+	}
+	return envelope
+}
+
+// WriteEncoded encodes the response envelope with an encoder
+// When w is an http.ResponseWriter the status and headers are set as in WriteTo, with the encoder's Content-Type
+// Envelopes holding iter.Seq or iter.Seq2 data are streamed by JSONEncoder without buffering,
+// so the headers are written first and an error while iterating leaves a truncated body
+func (r *Response) WriteEncoded(w io.Writer, encoder Encoder) (int64, error) {
+	envelope := r.Envelope()
+	if _, ok := encoder.(JSONEncoder); ok && containsSequence(envelope) {
+		r.writeHeader(w, encoder.ContentType())
+		counter := &countingWriter{w: w}
+		err := encoder.Encode(counter, envelope)
+		return counter.n, err
+	}
+
+	var body bytes.Buffer
+	if err := encoder.Encode(&body, envelope); err != nil {
+		return 0, err
+	}
+	r.writeHeader(w, encoder.ContentType())
+	return body.WriteTo(w)
+}
+
+// DecodeResponse decodes a response envelope of the default shape written with an encoder
+// Envelopes without the code and message members fail, use ResponseConfig.DecodeResponse for shaped envelopes
+func DecodeResponse(r io.Reader, encoder Encoder) (*Response, error) {
+	return decodeResponse(r, encoder, nil)
+}
+
+// DecodeResponse decodes a response envelope written with an encoder in the envelope shape of the config
+func (c *ResponseConfig) DecodeResponse(r io.Reader, encoder Encoder) (*Response, error) {
+	return decodeResponse(r, encoder, c.GetEnvelope())
+}
+
+// decodeResponse decodes a response envelope in the shape, the default shape when nil
+func decodeResponse(r io.Reader, encoder Encoder, shape *EnvelopeShape) (*Response, error) {
+	value, err := encoder.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+	return responseFromEnvelope(value, shape)
+}
+
+// responseFromEnvelope converts a decoded envelope in the shape to a response, the default shape when nil
+func responseFromEnvelope(value any, shape *EnvelopeShape) (*Response, error) {
+	envelope, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid response envelope: expected an object, got %T", value)
+	}
+	s := shape
+	if s == nil {
+		s = &EnvelopeShape{}
+	}
+
+	// Failed responses of shapes with an error member hold the code, message and field errors in it
+	status := envelope
+	if s.ErrorField != "" {
+		if nested, exists := envelope[s.ErrorField]; exists {
+			if status, ok = nested.(map[string]any); !ok {
+				return nil, fmt.Errorf("invalid response envelope: %s must be an object, got %T", s.ErrorField, nested)
+			}
+		}
+	}
+
+	var err error
+	response := &Response{Shape: shape}
+	codeField, messageField := envelopeField(s.CodeField, "code"), envelopeField(s.MessageField, "message")
+	code, hasCode := status[codeField]
+	if hasCode = hasCode && codeField != ""; hasCode {
+		n, ok := code.(int64)
+		if !ok {
+			return nil, fmt.Errorf("invalid response envelope: %s must be an integer, got %v", codeField, code)
+		}
+		response.Code = int(n)
+	}
+	message, hasMessage := status[messageField]
+	if hasMessage = hasMessage && messageField != ""; hasMessage {
+		if response.Message, ok = message.(string); !ok {
+			return nil, fmt.Errorf("invalid response envelope: %s must be a string, got %T", messageField, message)
+		}
+	}
+	if name := envelopeField(s.DataField, "data"); name != "" {
+		if response.Data, err = envelopeObject(envelope, name); err != nil {
+			return nil, err
+		}
+	}
+	if name := envelopeField(s.MetaField, "meta"); name != "" {
+		if response.Meta, err = envelopeObject(envelope, name); err != nil {
+			return nil, err
+		}
+	}
+	if name := envelopeField(s.ErrorsField, "errors"); name != "" {
+		if response.FieldErrors, err = envelopeFieldErrors(status, name); err != nil {
+			return nil, err
+		}
+	}
+	if response.MessageKey, err = envelopeString(envelope, s.KeyField); err != nil {
+		return nil, err
+	}
+	if response.Language, err = envelopeString(envelope, s.LanguageField); err != nil {
+		return nil, err
+	}
+	timestamp, err := envelopeString(envelope, s.TimestampField)
+	if err != nil {
+		return nil, err
+	}
+	if timestamp != "" {
+		if response.Timestamp, err = time.Parse(time.RFC3339Nano, timestamp); err != nil {
+			return nil, fmt.Errorf("invalid response envelope: %s %q is not RFC 3339", s.TimestampField, timestamp)
+		}
+	}
+
+	// An envelope of another shape would otherwise decode into an empty response
+	switch {
+	case hasCode || hasMessage:
+	case codeField != "" && messageField != "":
+		return nil, fmt.Errorf("invalid response envelope: missing %s and %s", codeField, messageField)
+	case codeField != "" || messageField != "":
+		return nil, fmt.Errorf("invalid response envelope: missing %s", codeField+messageField)
+	}
+	return response, nil
+}
+
+// envelopeFieldErrors returns the field errors of a decoded envelope, nil when missing
+func envelopeFieldErrors(envelope map[string]any, key string) ([]ResponseFieldError, error) {
+	value, exists := envelope[key]
+	if !exists || value == nil {
+		return nil, nil
+	}
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid response envelope: %s must be an array, got %T", key, value)
+	}
+
+	fieldErrors := make([]ResponseFieldError, 0, len(items))
+	for _, item := range items {
+		object, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid response envelope: %s must hold objects, got %T", key, item)
+		}
+		var fieldError ResponseFieldError
+		fieldError.Field, _ = object["field"].(string)
+		fieldError.MessageKey, _ = object["code"].(string)
+		fieldError.Message, _ = object["message"].(string)
+		fieldErrors = append(fieldErrors, fieldError)
+	}
+	return fieldErrors, nil
+}
+
+// envelopeString returns a string field of a decoded envelope, empty when the field is unnamed or missing
+func envelopeString(envelope map[string]any, key string) (string, error) {
+	value, exists := envelope[key]
+	if key == "" || !exists || value == nil {
+		return "", nil
+	}
+	text, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid response envelope: %s must be a string, got %T", key, value)
+	}
+	return text, nil
+}
+
+// envelopeObject returns an object field of a decoded envelope, nil when missing
+func envelopeObject(envelope map[string]any, key string) (map[string]any, error) {
+	value, exists := envelope[key]
+	if !exists || value == nil {
+		return nil, nil
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid response envelope: %s must be an object, got %T", key, value)
+	}
+	return object, nil
+}
+
+// JSONEncoder encodes values as JSON
+type JSONEncoder struct{}
+
+// ContentType returns the JSON Content-Type
+func (JSONEncoder) ContentType() string {
+	return ContentTypeJSON
+}
+
+// Encode writes v as JSON followed by a newline, streaming iter.Seq and iter.Seq2 values as they are yielded
+func (JSONEncoder) Encode(w io.Writer, v any) error {
+	buffered := bufio.NewWriter(w)
+	if err := writeJSON(buffered, v); err != nil {
+		return err
+	}
+	buffered.WriteByte('\n')
+	return buffered.Flush()
+}
+
+// Decode reads a JSON value, numbers are returned as int64, uint64 or float64
+func (JSONEncoder) Decode(r io.Reader) (any, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return normalizeValue(value)
+}
+
+// normalizeValue converts a value to the tree written by encoders
+// Integral floats become int64 so every format decodes numbers the way JSON does
+func normalizeValue(value any) (any, error) {
+	switch v := value.(type) {
+	case nil, string, bool, int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case uint:
+		return normalizeUint(uint64(v)), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return normalizeUint(v), nil
+	case float32:
+		return normalizeFloat(float64(v)), nil
+	case float64:
+		return normalizeFloat(v), nil
+	case json.Number:
+		return parseNumber(string(v))
+	case Object:
+		object := make(Object, len(v))
+		for i, field := range v {
+			normalized, err := normalizeValue(field.Value)
+			if err != nil {
+				return nil, err
+			}
+			object[i] = Field{Key: field.Key, Value: normalized}
+		}
+		return object, nil
+	case map[string]any:
+		object := make(map[string]any, len(v))
+		for key, item := range v {
+			normalized, err := normalizeValue(item)
+			if err != nil {
+				return nil, err
+			}
+			object[key] = normalized
+		}
+		return object, nil
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			normalized, err := normalizeValue(item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = normalized
+		}
+		return items, nil
+	}
+
+	if seq, arity := sequenceOf(value); arity > 0 {
+		return collectSequence(seq, arity)
+	}
+
+	// Other values are converted through their JSON encoding, honouring json tags and MarshalJSON
+	body, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	return normalizeValue(decoded)
+}
+
+// normalizeUint returns values that fit in int64 as int64
+func normalizeUint(v uint64) any {
+	if v <= math.MaxInt64 {
+		return int64(v)
+	}
+	return v
+}
+
+// normalizeFloat returns integral floats in the int64 range as int64
+func normalizeFloat(v float64) any {
+	if v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 && !(v == 0 && math.Signbit(v)) {
+		return int64(v)
+	}
+	return v
+}
+
+// parseNumber parses a number as int64, uint64 or float64
+func parseNumber(s string) (any, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeFloat(f), nil
+}
+
+// sortedKeys returns the keys of an object in sorted order
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// errUnsupportedValue is returned when a normalized tree holds a value an encoder cannot write
+var errUnsupportedValue = errors.New("unsupported value")
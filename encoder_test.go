@@ -0,0 +1,310 @@
+package goresponse
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// updateGolden rewrites the golden files of the encoder tests: go test -run TestEncoderGolden -update
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// goldenItem is a struct in response data, encoded through its json tags
+type goldenItem struct {
+	SKU      string    `json:"sku"`
+	Quantity int       `json:"qty"`
+	Price    float64   `json:"price"`
+	Internal string    `json:"-"`
+	Shipped  time.Time `json:"shipped_at"`
+}
+
+// goldenResponse is the response written by every encoder in the golden tests
+func goldenResponse() *Response {
+	return &Response{
+		Code:    200,
+		Message: "Order <#42> & \"friends\" — ready ✓",
+		Data: map[string]any{
+			"order_id":   int64(42),
+			"total":      1234.5,
+			"whole":      10.0,
+			"paid":       true,
+			"coupon":     nil,
+			"small":      -5,
+			"int8":       -100,
+			"int16":      -1000,
+			"int32":      -100000,
+			"int64":      int64(math.MinInt64),
+			"uint8":      uint8(200),
+			"uint16":     60000,
+			"uint32":     uint32(4000000000),
+			"uint64":     uint64(math.MaxUint64),
+			"first name": "Budi",
+			"1st":        "key that is not an XML name",
+			"xmlns":      "reserved XML name",
+			"notes":      "line one\nline two\ttabbed",
+			"long":       strings.Repeat("abcdefghij", 30),
+			"tags":       []string{"new", "priority"},
+			"matrix":     [][]int{{1, 2}, {3}},
+			"empty":      map[string]any{},
+			"none":       []any{},
+			"items": []goldenItem{
+				{SKU: "A-1", Quantity: 2, Price: 9.99, Internal: "hidden", Shipped: time.Date(2024, time.March, 5, 10, 30, 0, 0, time.UTC)},
+			},
+			"sequence": []any{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+		},
+		Meta: map[string]any{
+			"request_id": "req-1",
+			"page":       map[string]any{"number": 1, "size": 20},
+		},
+	}
+}
+
+// TestEncoderGolden tests every built-in encoder against its golden file and decodes it back
+func TestEncoderGolden(t *testing.T) {
+	tests := []struct {
+		mediaType string
+		file      string
+	}{
+		{mediaType: MediaTypeJSON, file: "response.json"},
+		{mediaType: MediaTypeXML, file: "response.xml"},
+		{mediaType: MediaTypeMsgPack, file: "response.msgpack"},
+		{mediaType: MediaTypeCBOR, file: "response.cbor"},
+	}
+
+	response := goldenResponse()
+	data, err := normalizeValue(response.Data)
+	if err != nil {
+		t.Fatalf("normalizeValue failed: %v", err)
+	}
+	meta, err := normalizeValue(response.Meta)
+	if err != nil {
+		t.Fatalf("normalizeValue failed: %v", err)
+	}
+	expected := &Response{Code: response.Code, Message: response.Message, Data: data.(map[string]any), Meta: meta.(map[string]any)}
+
+	for _, tt := range tests {
+		t.Run(tt.mediaType, func(t *testing.T) {
+			encoder, exists := LookupEncoder(tt.mediaType)
+			if !exists {
+				t.Fatalf("Encoder for %s not registered", tt.mediaType)
+			}
+
+			var buf bytes.Buffer
+			if _, err := response.WriteEncoded(&buf, encoder); err != nil {
+				t.Fatalf("WriteEncoded failed: %v", err)
+			}
+
+			golden := filepath.Join("testdata", "golden", tt.file)
+			if *updateGolden {
+				if err := os.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+					t.Fatalf("Failed to update golden file: %v", err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("Failed to read golden file: %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Errorf("Encoded output differs from %s (run with -update to refresh)\ngot:  %q\nwant: %q", golden, buf.Bytes(), want)
+			}
+
+			decoded, err := DecodeResponse(bytes.NewReader(want), encoder)
+			if err != nil {
+				t.Fatalf("DecodeResponse failed: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, expected) {
+				t.Errorf("Round trip mismatch\ngot:  %#v\nwant: %#v", decoded, expected)
+			}
+		})
+	}
+}
+
+// TestNormalizeValue tests conversion of Go values to encoder trees
+func TestNormalizeValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected any
+	}{
+		{name: "Int", value: 7, expected: int64(7)},
+		{name: "Integral float", value: 3.0, expected: int64(3)},
+		{name: "Fraction", value: float32(0.5), expected: 0.5},
+		{name: "Large uint", value: uint64(math.MaxUint64), expected: uint64(math.MaxUint64)},
+		{name: "Small uint", value: uint(5), expected: int64(5)},
+		{name: "Negative zero", value: math.Copysign(0, -1), expected: math.Copysign(0, -1)},
+		{name: "Slice", value: []string{"a"}, expected: []any{"a"}},
+		{name: "Struct", value: struct {
+			A int `json:"a"`
+		}{A: 1}, expected: map[string]any{"a": int64(1)}},
+		{name: "Object keeps order", value: Object{{Key: "b", Value: 2}, {Key: "a", Value: 1.5}}, expected: Object{{Key: "b", Value: int64(2)}, {Key: "a", Value: 1.5}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := normalizeValue(tt.value)
+			if err != nil {
+				t.Fatalf("normalizeValue failed: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %#v, got %#v", tt.expected, result)
+			}
+		})
+	}
+
+	t.Run("Unsupported value", func(t *testing.T) {
+		if _, err := normalizeValue(make(chan int)); err == nil {
+			t.Error("Expected error for a channel")
+		}
+	})
+}
+
+// TestObjectMarshalJSON tests field order of Object in JSON
+func TestObjectMarshalJSON(t *testing.T) {
+	var buf bytes.Buffer
+	object := Object{{Key: "z", Value: 1}, {Key: "a", Value: Object{{Key: "y", Value: "<"}}}}
+	if err := (JSONEncoder{}).Encode(&buf, object); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if buf.String() != `{"z":1,"a":{"y":"\u003c"}}`+"\n" {
+		t.Errorf("Unexpected JSON %q", buf.String())
+	}
+	if value, exists := object.Get("a"); !exists || len(value.(Object)) != 1 {
+		t.Errorf("Expected Get to find field a, got %v", value)
+	}
+	if _, exists := object.Get("missing"); exists {
+		t.Error("Expected missing field to not exist")
+	}
+}
+
+// TestNegotiateEncoder tests Accept header negotiation
+func TestNegotiateEncoder(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected string
+	}{
+		{accept: "", expected: MediaTypeJSON},
+		{accept: "application/xml", expected: MediaTypeXML},
+		{accept: "text/xml", expected: "text/xml"},
+		{accept: "Application/CBOR; charset=binary", expected: MediaTypeCBOR},
+		{accept: "application/x-msgpack", expected: "application/x-msgpack"},
+		{accept: "text/html, application/xml;q=0.9, */*;q=0.8", expected: MediaTypeXML},
+		{accept: "application/cbor;q=0.5, application/msgpack", expected: MediaTypeMsgPack},
+		{accept: "*/*, application/cbor", expected: MediaTypeCBOR},
+		{accept: "text/*", expected: "text/xml"},
+		{accept: "application/*, application/json;q=0", expected: MediaTypeXML},
+		{accept: "image/png", expected: MediaTypeJSON},
+		{accept: "garbage", expected: MediaTypeJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			mediaType, encoder := NegotiateEncoder(tt.accept)
+			if mediaType != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, mediaType)
+			}
+			if registered, _ := LookupEncoder(tt.expected); encoder != registered {
+				t.Errorf("Expected the registered encoder of %s, got %T", tt.expected, encoder)
+			}
+		})
+	}
+}
+
+// csvEncoder is a custom encoder registered in tests
+type csvEncoder struct{}
+
+func (csvEncoder) ContentType() string { return "text/csv" }
+
+func (csvEncoder) Encode(w io.Writer, v any) error {
+	message, _ := v.(Object).Get("message")
+	_, err := io.WriteString(w, "message\n"+message.(string)+"\n")
+	return err
+}
+
+func (csvEncoder) Decode(r io.Reader) (any, error) {
+	return nil, errors.New("not supported")
+}
+
+// TestRegisterEncoder tests custom encoders and negotiation in WriteHTTP
+func TestRegisterEncoder(t *testing.T) {
+	RegisterEncoder("Text/CSV", csvEncoder{})
+	if _, exists := LookupEncoder("text/csv; header=present"); !exists {
+		t.Fatal("Expected registered encoder to be found")
+	}
+
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"ok": {Key: "ok", Template: "All good", CodeMappings: map[string]int{"http": 200}},
+		},
+		DefaultLanguage: "en",
+	}
+
+	tests := []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		{accept: "text/csv", contentType: "text/csv", body: "message\nAll good\n"},
+		{accept: "application/xml", contentType: "application/xml; charset=utf-8", body: xmlHeaderLine + `<response><code type="number">200</code><message>All good</message></response>` + "\n"},
+		{accept: "", contentType: ContentTypeJSON, body: `{"code":200,"message":"All good"}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept", tt.accept)
+			rec := httptest.NewRecorder()
+			if err := WriteHTTP(rec, req, config, NewResponseBuilder("ok")); err != nil {
+				t.Fatalf("WriteHTTP failed: %v", err)
+			}
+			if rec.Header().Get("Content-Type") != tt.contentType {
+				t.Errorf("Expected Content-Type %q, got %q", tt.contentType, rec.Header().Get("Content-Type"))
+			}
+			if rec.Header().Get("Vary") != "Accept" {
+				t.Errorf("Expected Vary Accept, got %q", rec.Header().Get("Vary"))
+			}
+			if rec.Body.String() != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, rec.Body.String())
+			}
+		})
+	}
+}
+
+// xmlHeaderLine is the XML declaration written by XMLEncoder
+const xmlHeaderLine = `<?xml version="1.0" encoding="UTF-8"?>` + "\n"
+
+// TestDecodeResponseErrors tests invalid response envelopes
+func TestDecodeResponseErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		errorContains string
+	}{
+		{name: "Not an object", body: `[1]`, errorContains: "expected an object"},
+		{name: "String code", body: `{"code":"200"}`, errorContains: "code must be an integer"},
+		{name: "Fractional code", body: `{"code":2.5}`, errorContains: "code must be an integer"},
+		{name: "Message type", body: `{"message":1}`, errorContains: "message must be a string"},
+		{name: "Data type", body: `{"data":[]}`, errorContains: "data must be an object"},
+		{name: "Meta type", body: `{"meta":"x"}`, errorContains: "meta must be an object"},
+		{name: "Missing code and message", body: `{"data":{"id":1}}`, errorContains: "missing code and message"},
+		{name: "Invalid JSON", body: `{`, errorContains: "unexpected EOF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeResponse(strings.NewReader(tt.body), JSONEncoder{})
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing '%s', got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
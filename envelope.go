@@ -0,0 +1,118 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EnvelopeShape configures the members of encoded response envelopes
+// Empty names keep the default member, "-" omits it; optional members are only written when named
+type EnvelopeShape struct {
+	CodeField      string `json:"code_field,omitempty"`      // Member of the code ("code" when empty)
+	MessageField   string `json:"message_field,omitempty"`   // Member of the message ("message" when empty)
+	DataField      string `json:"data_field,omitempty"`      // Member of the data ("data" when empty)
+	MetaField      string `json:"meta_field,omitempty"`      // Member of the meta ("meta" when empty)
+	ErrorsField    string `json:"errors_field,omitempty"`    // Member of the field errors ("errors" when empty)
+	ErrorField     string `json:"error_field,omitempty"`     // Member nesting the code and message of failed responses
+	SuccessField   string `json:"success_field,omitempty"`   // Member of the success boolean computed from the code of the protocol
+	KeyField       string `json:"key_field,omitempty"`       // Member of the template key
+	LanguageField  string `json:"language_field,omitempty"`  // Member of the message language
+	TimestampField string `json:"timestamp_field,omitempty"` // Member of the RFC 3339 build time
+}
+
+// GetEnvelope returns the envelope shape of responses, ManualEnvelope taking priority over Envelope
+// Returns nil for the default shape
+func (c *ResponseConfig) GetEnvelope() *EnvelopeShape {
+	if c.ManualEnvelope != nil {
+		return c.ManualEnvelope
+	}
+	return c.Envelope
+}
+
+// SetEnvelope sets the envelope shape programmatically, it is kept when an async config is refreshed
+// A nil shape falls back to the Envelope of the loaded config
+func (c *ResponseConfig) SetEnvelope(shape *EnvelopeShape) {
+	c.ManualEnvelope = shape
+}
+
+// Success reports whether the response is successful for its protocol
+// gRPC responses are successful with the OK code and JSON-RPC responses without an error code, error or field errors
+// Other codes in the HTTP range are successful below 400, the rest when the response carries no error and no field errors
+func (r *Response) Success() bool {
+	switch r.Protocol {
+	case ProtocolGRPC:
+		return r.Code == GRPCOK
+	case ProtocolJSONRPC:
+		return r.Code == 0 && r.Error == nil && len(r.FieldErrors) == 0
+	}
+	if r.Code >= 100 && r.Code <= 599 {
+		return r.Code < 400
+	}
+	return r.Error == nil && len(r.FieldErrors) == 0
+}
+
+// MarshalJSON writes the response envelope in its configured shape
+func (r Response) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Envelope())
+}
+
+// envelopeField returns the member name of a field, the default name when empty and "" when omitted
+func envelopeField(name, defaultName string) string {
+	switch name {
+	case "":
+		return defaultName
+	case "-":
+		return ""
+	}
+	return name
+}
+
+// envelope returns the members of a response in the shape, with data as the data member when hasData is set
+func (s *EnvelopeShape) envelope(r *Response, data any, hasData bool) Object {
+	success := r.Success()
+	var envelope Object
+	if s.SuccessField != "" {
+		envelope = append(envelope, Field{Key: s.SuccessField, Value: success})
+	}
+
+	var status Object
+	if name := envelopeField(s.CodeField, "code"); name != "" {
+		status = append(status, Field{Key: name, Value: r.Code})
+	}
+	if name := envelopeField(s.MessageField, "message"); name != "" {
+		status = append(status, Field{Key: name, Value: r.Message})
+	}
+	var fieldErrors Field
+	if name := envelopeField(s.ErrorsField, "errors"); name != "" && len(r.FieldErrors) > 0 {
+		fieldErrors = Field{Key: name, Value: fieldErrorObjects(r.FieldErrors)}
+	}
+	nested := s.ErrorField != "" && !success
+	if nested {
+		if fieldErrors.Key != "" {
+			status = append(status, fieldErrors)
+		}
+		envelope = append(envelope, Field{Key: s.ErrorField, Value: status})
+	} else {
+		envelope = append(envelope, status...)
+	}
+
+	if name := envelopeField(s.DataField, "data"); name != "" && hasData {
+		envelope = append(envelope, Field{Key: name, Value: data})
+	}
+	if name := envelopeField(s.MetaField, "meta"); name != "" && len(r.Meta) > 0 {
+		envelope = append(envelope, Field{Key: name, Value: r.Meta})
+	}
+	if !nested && fieldErrors.Key != "" {
+		envelope = append(envelope, fieldErrors)
+	}
+	if s.KeyField != "" && r.MessageKey != "" {
+		envelope = append(envelope, Field{Key: s.KeyField, Value: r.MessageKey})
+	}
+	if s.LanguageField != "" && r.Language != "" {
+		envelope = append(envelope, Field{Key: s.LanguageField, Value: r.Language})
+	}
+	if s.TimestampField != "" && !r.Timestamp.IsZero() {
+		envelope = append(envelope, Field{Key: s.TimestampField, Value: r.Timestamp.Format(time.RFC3339Nano)})
+	}
+	return envelope
+}
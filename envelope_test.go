@@ -0,0 +1,339 @@
+package goresponse
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestEnvelopeShape tests renaming, nesting and optional envelope members
+func TestEnvelopeShape(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"user_found": {
+				Key:          "user_found",
+				Template:     "User found",
+				Translations: map[string]string{"id": "Pengguna ditemukan"},
+				CodeMappings: map[string]int{"http": 200, "grpc": 0},
+			},
+			"user_not_found": {
+				Key:          "user_not_found",
+				Template:     "User not found",
+				CodeMappings: map[string]int{"http": 404, "grpc": 5},
+			},
+			"grpc_not_found": {
+				Key:          "grpc_not_found",
+				Template:     "Not found",
+				CodeMappings: map[string]int{"grpc": 404},
+			},
+		},
+		DefaultLanguage: "en",
+	}
+	now := time.Date(2025, 3, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		shape    *EnvelopeShape
+		builder  *ResponseBuilder
+		expected string
+	}{
+		{
+			name:     "Default shape",
+			builder:  NewResponseBuilder("user_found").SetProtocol("http").SetData("id", 1),
+			expected: `{"code":200,"message":"User found","data":{"id":1}}`,
+		},
+		{
+			name:     "Renamed members",
+			shape:    &EnvelopeShape{CodeField: "status", MessageField: "msg", DataField: "result"},
+			builder:  NewResponseBuilder("user_found").SetProtocol("http").SetData("id", 1).SetMeta("page", 1),
+			expected: `{"status":200,"msg":"User found","result":{"id":1},"meta":{"page":1}}`,
+		},
+		{
+			name:     "Success with nested error",
+			shape:    &EnvelopeShape{SuccessField: "success", ErrorField: "error"},
+			builder:  NewResponseBuilder("user_not_found").SetProtocol("http").SetError(errors.New("missing")),
+			expected: `{"success":false,"error":{"code":404,"message":"User not found"}}`,
+		},
+		{
+			name:     "Success is not nested",
+			shape:    &EnvelopeShape{SuccessField: "success", ErrorField: "error"},
+			builder:  NewResponseBuilder("user_found").SetProtocol("http"),
+			expected: `{"success":true,"code":200,"message":"User found"}`,
+		},
+		{
+			name:     "Success of gRPC codes",
+			shape:    &EnvelopeShape{SuccessField: "ok", CodeField: "-"},
+			builder:  NewResponseBuilder("user_not_found").SetProtocol("grpc").SetError(errors.New("missing")),
+			expected: `{"ok":false,"message":"User not found"}`,
+		},
+		{
+			name:     "Success of gRPC status 404",
+			shape:    &EnvelopeShape{SuccessField: "ok"},
+			builder:  NewResponseBuilder("grpc_not_found").SetProtocol("grpc"),
+			expected: `{"ok":false,"code":404,"message":"Not found"}`,
+		},
+		{
+			name:     "Key, language and timestamp",
+			shape:    &EnvelopeShape{KeyField: "key", LanguageField: "lang", TimestampField: "timestamp", MetaField: "-"},
+			builder:  NewResponseBuilder("user_found").SetProtocol("http").SetLanguage("id").SetTimestamp(now).SetMeta("page", 1),
+			expected: `{"code":200,"message":"Pengguna ditemukan","key":"user_found","lang":"id","timestamp":"2025-03-01T10:30:00Z"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shaped := *config
+			shaped.Envelope = tt.shape
+			response, err := shaped.BuildResponse(tt.builder)
+			if err != nil {
+				t.Fatalf("BuildResponse failed: %v", err)
+			}
+			data, err := json.Marshal(response)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+
+	t.Run("Timestamp is independent of the reference time", func(t *testing.T) {
+		shaped := *config
+		shaped.Envelope = &EnvelopeShape{TimestampField: "timestamp"}
+		response, err := shaped.BuildResponse(NewResponseBuilder("user_found").SetNow(now))
+		if err != nil {
+			t.Fatalf("BuildResponse failed: %v", err)
+		}
+		if response.Timestamp.Equal(now) || time.Since(response.Timestamp) > time.Minute {
+			t.Errorf("Expected current time, got %v", response.Timestamp)
+		}
+	})
+
+	t.Run("Timestamp defaults to build time", func(t *testing.T) {
+		shaped := *config
+		shaped.Envelope = &EnvelopeShape{TimestampField: "timestamp"}
+		response, err := shaped.BuildResponse(NewResponseBuilder("user_found"))
+		if err != nil {
+			t.Fatalf("BuildResponse failed: %v", err)
+		}
+		if time.Since(response.Timestamp) > time.Minute {
+			t.Errorf("Expected current time, got %v", response.Timestamp)
+		}
+	})
+}
+
+// TestResponseSuccess tests the success computed from the code of the protocol
+func TestResponseSuccess(t *testing.T) {
+	tests := []struct {
+		name     string
+		response Response
+		expected bool
+	}{
+		{name: "HTTP 200", response: Response{Code: 200}, expected: true},
+		{name: "HTTP 302", response: Response{Code: 302}, expected: true},
+		{name: "HTTP 404", response: Response{Code: 404, Error: errors.New("missing")}, expected: false},
+		{name: "HTTP 500 without error", response: Response{Code: 500}, expected: false},
+		{name: "HTTP protocol 201", response: Response{Code: 201, Protocol: "http"}, expected: true},
+		{name: "gRPC OK", response: Response{Code: 0, Protocol: ProtocolGRPC}, expected: true},
+		{name: "gRPC error", response: Response{Code: 5, Protocol: ProtocolGRPC, Error: errors.New("missing")}, expected: false},
+		{name: "gRPC code in the HTTP range", response: Response{Code: 200, Protocol: ProtocolGRPC}, expected: false},
+		{name: "gRPC field errors", response: Response{Code: 3, Protocol: ProtocolGRPC, FieldErrors: []ResponseFieldError{{Field: "a"}}}, expected: false},
+		{name: "JSON-RPC result", response: Response{Protocol: ProtocolJSONRPC}, expected: true},
+		{name: "JSON-RPC error code", response: Response{Code: -32001, Protocol: ProtocolJSONRPC}, expected: false},
+		{name: "JSON-RPC error without code", response: Response{Protocol: ProtocolJSONRPC, Error: errors.New("boom")}, expected: false},
+		{name: "Unknown protocol without error", response: Response{Code: 7}, expected: true},
+		{name: "Unknown protocol with field errors", response: Response{Code: 3, FieldErrors: []ResponseFieldError{{Field: "a"}}}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if success := tt.response.Success(); success != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, success)
+			}
+		})
+	}
+}
+
+// TestEnvelopeConfig tests loading and overriding the envelope shape
+func TestEnvelopeConfig(t *testing.T) {
+	var config ResponseConfig
+	data := `{"default_language":"en","envelope":{"code_field":"status","message_field":"msg","data_field":"result","success_field":"success"}}`
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	expected := EnvelopeShape{CodeField: "status", MessageField: "msg", DataField: "result", SuccessField: "success"}
+	if config.GetEnvelope() == nil || *config.GetEnvelope() != expected {
+		t.Fatalf("Expected envelope %+v, got %+v", expected, config.GetEnvelope())
+	}
+
+	exported, err := config.Printer().WithIndent(false).Export()
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !strings.Contains(exported, `"envelope":{"code_field":"status"`) {
+		t.Errorf("Expected exported envelope, got %s", exported)
+	}
+
+	manual := &EnvelopeShape{ErrorField: "error"}
+	config.SetEnvelope(manual)
+	if config.GetEnvelope() != manual {
+		t.Errorf("Expected manual envelope to take priority")
+	}
+	config.SetEnvelope(nil)
+	if config.GetEnvelope() != config.Envelope {
+		t.Errorf("Expected envelope of the config after clearing the manual one")
+	}
+}
+
+// TestDecodeEnvelopeShape tests decoding shaped envelopes back into responses
+func TestDecodeEnvelopeShape(t *testing.T) {
+	timestamp := time.Date(2025, 3, 1, 10, 30, 0, 0, time.UTC)
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"user_found": {
+				Key:          "user_found",
+				Template:     "User found",
+				Translations: map[string]string{"id": "Pengguna ditemukan"},
+				CodeMappings: map[string]int{"http": 200},
+			},
+			"user_not_found": {
+				Key:          "user_not_found",
+				Template:     "User not found",
+				CodeMappings: map[string]int{"http": 404},
+			},
+			"required": {
+				Key:      "required",
+				Template: "$field is required",
+			},
+		},
+		DefaultLanguage: "en",
+	}
+	config.SetEnvelope(&EnvelopeShape{
+		SuccessField: "success", ErrorField: "error", CodeField: "status", MessageField: "msg", DataField: "result",
+		ErrorsField: "fields", KeyField: "key", LanguageField: "lang", TimestampField: "timestamp",
+	})
+
+	tests := []struct {
+		name    string
+		builder *ResponseBuilder
+	}{
+		{
+			name:    "Success",
+			builder: NewResponseBuilder("user_found").SetProtocol("http").SetLanguage("id").SetTimestamp(timestamp).SetData("id", 1).SetMeta("page", 2),
+		},
+		{
+			name: "Nested error",
+			builder: NewResponseBuilder("user_not_found").SetProtocol("http").SetTimestamp(timestamp).SetError(errors.New("missing")).
+				AddFieldError("id", "required", map[string]any{"field": "Id"}),
+		},
+	}
+
+	for _, tt := range tests {
+		response, err := config.BuildResponse(tt.builder)
+		if err != nil {
+			t.Fatalf("BuildResponse failed: %v", err)
+		}
+		for _, mediaType := range []string{MediaTypeJSON, MediaTypeXML, MediaTypeMsgPack, MediaTypeCBOR} {
+			t.Run(tt.name+" "+mediaType, func(t *testing.T) {
+				encoder, _ := LookupEncoder(mediaType)
+				var body bytes.Buffer
+				if err := encoder.Encode(&body, response.Envelope()); err != nil {
+					t.Fatalf("Encode failed: %v", err)
+				}
+				decoded, err := config.DecodeResponse(&body, encoder)
+				if err != nil {
+					t.Fatalf("DecodeResponse failed: %v", err)
+				}
+				if decoded.Code != response.Code || decoded.Message != response.Message || decoded.MessageKey != response.MessageKey ||
+					decoded.Language != response.Language || !decoded.Timestamp.Equal(timestamp) || !slices.Equal(decoded.FieldErrors, response.FieldErrors) {
+					t.Errorf("Expected %+v, got %+v", response, decoded)
+				}
+				if len(decoded.Data) != len(response.Data) || len(decoded.Meta) != len(response.Meta) {
+					t.Errorf("Expected data %v and meta %v, got %v and %v", response.Data, response.Meta, decoded.Data, decoded.Meta)
+				}
+
+				reencoded, err := json.Marshal(decoded)
+				if err != nil {
+					t.Fatalf("Marshal failed: %v", err)
+				}
+				original, _ := json.Marshal(response)
+				if string(reencoded) != string(original) {
+					t.Errorf("Expected round trip %s, got %s", original, reencoded)
+				}
+			})
+		}
+	}
+
+	t.Run("CloudEvent", func(t *testing.T) {
+		event, err := config.BuildCloudEvent(NewResponseBuilder("user_found").SetLanguage("id").SetTimestamp(timestamp).SetData("id", 1), WithEventSource("/users"))
+		if err != nil {
+			t.Fatalf("BuildCloudEvent failed: %v", err)
+		}
+		data, err := json.Marshal(event)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		decoded, err := config.DecodeCloudEvent(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("DecodeCloudEvent failed: %v", err)
+		}
+		if decoded.Data.Message != "Pengguna ditemukan" || decoded.Data.MessageKey != "user_found" || decoded.Data.Data["id"] != int64(1) {
+			t.Errorf("Unexpected decoded data %+v", decoded.Data)
+		}
+
+		if _, err := DecodeCloudEvent(bytes.NewReader(data)); err == nil || !strings.Contains(err.Error(), "missing code and message") {
+			t.Errorf("Expected the default shape to fail on the shaped envelope, got %v", err)
+		}
+	})
+
+	t.Run("Default shape fails on shaped envelopes", func(t *testing.T) {
+		body := `{"success":true,"status":200,"msg":"User found","result":{"id":1}}`
+		if _, err := DecodeResponse(strings.NewReader(body), JSONEncoder{}); err == nil || !strings.Contains(err.Error(), "missing code and message") {
+			t.Errorf("Expected missing members error, got %v", err)
+		}
+	})
+}
+
+// TestWriteHTTPEnvelopeShape tests shaped envelopes in every encoder
+func TestWriteHTTPEnvelopeShape(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"user_found": {
+				Key:          "user_found",
+				Template:     "User found",
+				CodeMappings: map[string]int{"http": 200},
+			},
+		},
+		DefaultLanguage: "en",
+	}
+	config.SetEnvelope(&EnvelopeShape{SuccessField: "success", CodeField: "status", MessageField: "msg", DataField: "result"})
+
+	tests := []struct {
+		accept string
+		body   string
+	}{
+		{accept: "application/json", body: `{"success":true,"status":200,"msg":"User found","result":{"id":1}}`},
+		{accept: "application/xml", body: xmlHeaderLine + `<response><success type="boolean">true</success><status type="number">200</status><msg>User found</msg><result type="object"><id type="number">1</id></result></response>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+			req.Header.Set("Accept", tt.accept)
+			rec := httptest.NewRecorder()
+			if err := WriteHTTP(rec, req, config, NewResponseBuilder("user_found").SetData("id", 1)); err != nil {
+				t.Fatalf("WriteHTTP failed: %v", err)
+			}
+			if body := strings.TrimSpace(rec.Body.String()); body != tt.body {
+				t.Errorf("Expected body %s, got %s", tt.body, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,920 @@
+package goresponse
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxFluentDepth limits nested message and term references to guard against cycles
+const maxFluentDepth = 32
+
+// FluentBundle holds the messages and terms of a Fluent (.ftl) resource for one language
+type FluentBundle struct {
+	Language string
+	messages map[string]*ftlEntry
+	terms    map[string]*ftlEntry
+}
+
+// ftlEntry is a parsed message or term
+type ftlEntry struct {
+	Value      ftlPattern
+	Attributes map[string]ftlPattern
+}
+
+// ftlPattern is a sequence of text and placeables
+type ftlPattern []any
+
+// ftlStringLiteral is a quoted string literal ("text")
+type ftlStringLiteral string
+
+// ftlNumberLiteral is a number literal, Raw keeps its fraction digits
+type ftlNumberLiteral struct {
+	Value float64
+	Raw   string
+}
+
+// ftlVariableRef is a reference to an argument ($name)
+type ftlVariableRef string
+
+// ftlMessageRef is a reference to another message or its attribute (message.attr)
+type ftlMessageRef struct {
+	ID   string
+	Attr string
+}
+
+// ftlTermRef is a reference to a term or its attribute, with optional named arguments (-term.attr(case: "x"))
+type ftlTermRef struct {
+	ID    string
+	Attr  string
+	Named map[string]any
+}
+
+// ftlFunctionCall is a call to a built-in function (NUMBER, DATETIME)
+type ftlFunctionCall struct {
+	Name       string
+	Positional []any
+	Named      map[string]any
+}
+
+// ftlSelect is a select expression with its variants
+type ftlSelect struct {
+	Selector any
+	Variants []ftlVariant
+	Default  int
+}
+
+// ftlVariant is a variant of a select expression
+type ftlVariant struct {
+	Key     string
+	Numeric bool
+	Value   ftlPattern
+}
+
+// ftlIndent is a line break with indentation, resolved to text after the pattern is dedented
+type ftlIndent struct {
+	Value  string
+	Length int
+}
+
+// ftlNumber is a number produced by the NUMBER function
+type ftlNumber struct {
+	Value     float64
+	MinDigits int
+	MaxDigits int
+	Style     string
+	Currency  string
+}
+
+// NewFluentBundle parses Fluent (.ftl) source into a bundle for the given language
+// Messages, terms, attributes, select expressions and the NUMBER and DATETIME functions are supported
+func NewFluentBundle(language, source string) (*FluentBundle, error) {
+	p := &ftlParser{input: []rune(strings.ReplaceAll(source, "\r\n", "\n"))}
+	bundle := &FluentBundle{
+		Language: language,
+		messages: make(map[string]*ftlEntry),
+		terms:    make(map[string]*ftlEntry),
+	}
+
+	for {
+		p.skipBlankLines()
+		if p.eof() {
+			return bundle, nil
+		}
+
+		switch ch := p.peek(); {
+		case ch == '#':
+			p.skipLine()
+		case ch == '-':
+			p.pos++
+			id, entry, err := p.parseEntry(true)
+			if err != nil {
+				return nil, err
+			}
+			bundle.terms[id] = entry
+		case isFluentIdentifierStart(ch):
+			id, entry, err := p.parseEntry(false)
+			if err != nil {
+				return nil, err
+			}
+			bundle.messages[id] = entry
+		default:
+			return nil, p.errorf("expected a message, term or comment")
+		}
+	}
+}
+
+// HasMessage reports whether the bundle has a message, or a message attribute written as "message.attr"
+func (b *FluentBundle) HasMessage(id string) bool {
+	_, exists := b.lookupMessage(id)
+	return exists
+}
+
+// Format renders a message, or a message attribute written as "message.attr", with the given arguments
+func (b *FluentBundle) Format(id string, args map[string]any) (string, bool) {
+	if b == nil {
+		return "", false
+	}
+	return b.format(id, args, NewFormatter(b.Language))
+}
+
+// format renders a message using a formatter for arguments and numbers
+func (b *FluentBundle) format(id string, args map[string]any, f *Formatter) (string, bool) {
+	pattern, exists := b.lookupMessage(id)
+	if !exists {
+		return "", false
+	}
+
+	var sb strings.Builder
+	b.resolvePattern(&sb, pattern, args, f, 0)
+	return sb.String(), true
+}
+
+// lookupMessage finds the pattern of a message or a message attribute
+func (b *FluentBundle) lookupMessage(id string) (ftlPattern, bool) {
+	if b == nil {
+		return nil, false
+	}
+
+	messageID, attr, _ := strings.Cut(id, ".")
+	entry, exists := b.messages[messageID]
+	if !exists {
+		return nil, false
+	}
+	if attr != "" {
+		pattern, exists := entry.Attributes[attr]
+		return pattern, exists
+	}
+	return entry.Value, entry.Value != nil
+}
+
+// resolvePattern writes a resolved pattern to the builder
+func (b *FluentBundle) resolvePattern(sb *strings.Builder, pattern ftlPattern, args map[string]any, f *Formatter, depth int) {
+	if depth > maxFluentDepth {
+		sb.WriteString("{???}")
+		return
+	}
+
+	for _, element := range pattern {
+		if text, isText := element.(string); isText {
+			sb.WriteString(text)
+			continue
+		}
+		sb.WriteString(b.formatValue(b.resolveExpression(element, args, f, depth), f))
+	}
+}
+
+// resolveExpression evaluates an expression to a value
+// Text results are strings, NUMBER results are ftlNumber and arguments keep their own type
+func (b *FluentBundle) resolveExpression(expr any, args map[string]any, f *Formatter, depth int) any {
+	switch e := expr.(type) {
+	case ftlStringLiteral:
+		return string(e)
+	case ftlNumberLiteral:
+		_, fraction, _ := strings.Cut(e.Raw, ".")
+		return ftlNumber{Value: e.Value, MinDigits: len(fraction), MaxDigits: max(len(fraction), maxFractionDigits)}
+	case ftlVariableRef:
+		value, exists := args[string(e)]
+		if !exists {
+			return "{$" + string(e) + "}"
+		}
+		return value
+	case ftlMessageRef:
+		id := e.ID
+		if e.Attr != "" {
+			id += "." + e.Attr
+		}
+		pattern, exists := b.lookupMessage(id)
+		if !exists {
+			return "{" + id + "}"
+		}
+		var sb strings.Builder
+		b.resolvePattern(&sb, pattern, args, f, depth+1)
+		return sb.String()
+	case ftlTermRef:
+		return b.resolveTerm(e, args, f, depth)
+	case ftlFunctionCall:
+		return b.callFunction(e, args, f, depth)
+	case ftlSelect:
+		selector := b.resolveExpression(e.Selector, args, f, depth)
+		var sb strings.Builder
+		b.resolvePattern(&sb, e.Variants[b.selectVariant(e, selector)].Value, args, f, depth+1)
+		return sb.String()
+	case ftlPattern:
+		var sb strings.Builder
+		b.resolvePattern(&sb, e, args, f, depth+1)
+		return sb.String()
+	}
+	return ""
+}
+
+// resolveTerm evaluates a term reference; terms only see the named arguments passed to them
+func (b *FluentBundle) resolveTerm(ref ftlTermRef, args map[string]any, f *Formatter, depth int) any {
+	name := "-" + ref.ID
+	if ref.Attr != "" {
+		name += "." + ref.Attr
+	}
+
+	entry, exists := b.terms[ref.ID]
+	if !exists {
+		return "{" + name + "}"
+	}
+	pattern := entry.Value
+	if ref.Attr != "" {
+		if pattern, exists = entry.Attributes[ref.Attr]; !exists {
+			return "{" + name + "}"
+		}
+	}
+
+	termArgs := make(map[string]any, len(ref.Named))
+	for key, value := range ref.Named {
+		termArgs[key] = b.resolveExpression(value, args, f, depth)
+	}
+
+	var sb strings.Builder
+	b.resolvePattern(&sb, pattern, termArgs, f, depth+1)
+	return sb.String()
+}
+
+// callFunction evaluates the built-in NUMBER and DATETIME functions
+func (b *FluentBundle) callFunction(call ftlFunctionCall, args map[string]any, f *Formatter, depth int) any {
+	if len(call.Positional) == 0 {
+		return "{" + call.Name + "()}"
+	}
+	value := b.resolveExpression(call.Positional[0], args, f, depth)
+	options := make(map[string]string, len(call.Named))
+	for key, option := range call.Named {
+		options[key] = fmt.Sprint(b.resolveOption(option))
+	}
+
+	switch call.Name {
+	case "NUMBER":
+		number, ok := value.(ftlNumber)
+		if !ok {
+			n, isNumber := icuNumber(value)
+			if !isNumber {
+				return value
+			}
+			number = ftlNumber{Value: n, MaxDigits: maxFractionDigits}
+		}
+		if digits, err := strconv.Atoi(options["minimumFractionDigits"]); err == nil {
+			number.MinDigits = digits
+			number.MaxDigits = max(number.MaxDigits, digits)
+		}
+		if digits, err := strconv.Atoi(options["maximumFractionDigits"]); err == nil {
+			number.MaxDigits = digits
+			number.MinDigits = min(number.MinDigits, digits)
+		}
+		if style, exists := options["style"]; exists {
+			number.Style = style
+		}
+		if currency, exists := options["currency"]; exists {
+			number.Currency = currency
+		}
+		return number
+	case "DATETIME":
+		dt, ok := value.(DateTime)
+		if t, isTime := value.(time.Time); isTime {
+			dt, ok = DateTime{Time: t}, true
+		}
+		if !ok {
+			return value
+		}
+		dateStyle, hasDate := options["dateStyle"]
+		timeStyle, hasTime := options["timeStyle"]
+		dt.DateStyle, dt.TimeStyle = icuDateTimeStyle(dateStyle), icuDateTimeStyle(timeStyle)
+		if !hasTime {
+			dt.TimeStyle = StyleNone
+		} else if !hasDate {
+			dt.DateStyle = StyleNone
+		}
+		return dt
+	}
+	return "{" + call.Name + "()}"
+}
+
+// resolveOption returns the literal value of a named function option
+func (b *FluentBundle) resolveOption(option any) any {
+	switch o := option.(type) {
+	case ftlStringLiteral:
+		return string(o)
+	case ftlNumberLiteral:
+		return o.Raw
+	}
+	return ""
+}
+
+// selectVariant picks a variant by exact key, then by plural category, then the default
+func (b *FluentBundle) selectVariant(sel ftlSelect, selector any) int {
+	n, isNumber := ftlNumeric(selector)
+	for i, variant := range sel.Variants {
+		if variant.Numeric {
+			if key, err := strconv.ParseFloat(variant.Key, 64); err == nil && isNumber && key == n {
+				return i
+			}
+			continue
+		}
+		if text, isText := selector.(string); isText && text == variant.Key {
+			return i
+		}
+	}
+
+	if isNumber {
+		category := PluralCategory(b.Language, ftlPluralOperand(selector))
+		for i, variant := range sel.Variants {
+			if !variant.Numeric && variant.Key == category {
+				return i
+			}
+		}
+	}
+	return sel.Default
+}
+
+// formatValue renders a resolved value as text
+func (b *FluentBundle) formatValue(value any, f *Formatter) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case ftlNumber:
+		switch {
+		case v.Style == "percent":
+			return f.FormatPercent(v.Value)
+		case v.Style == "currency" && v.Currency != "":
+			return f.FormatCurrency(v.Value, v.Currency)
+		}
+		return f.formatFloat(v.Value, v.MinDigits, v.MaxDigits)
+	}
+	// Fluent formats numeric variables as numbers
+	return f.formatGrouped(value)
+}
+
+// ftlNumeric returns the numeric value of a selector
+func ftlNumeric(value any) (float64, bool) {
+	switch v := value.(type) {
+	case ftlNumber:
+		return v.Value, true
+	case string:
+		return 0, false
+	}
+	return icuNumber(value)
+}
+
+// ftlPluralOperand returns a value for PluralCategory that keeps NUMBER fraction digits
+func ftlPluralOperand(value any) any {
+	if number, ok := value.(ftlNumber); ok {
+		return Decimal{Value: number.Value, Digits: number.MinDigits}
+	}
+	return value
+}
+
+// ftlParser is a recursive descent parser for Fluent syntax
+type ftlParser struct {
+	input []rune
+	pos   int
+}
+
+// errorf returns a parse error annotated with the current line
+func (p *ftlParser) errorf(format string, args ...any) error {
+	line := 1
+	for _, ch := range p.input[:min(p.pos, len(p.input))] {
+		if ch == '\n' {
+			line++
+		}
+	}
+	return fmt.Errorf("invalid Fluent resource at line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+// parseEntry parses a message or term after its optional "-" prefix
+func (p *ftlParser) parseEntry(isTerm bool) (string, *ftlEntry, error) {
+	id := p.parseIdentifier()
+	if id == "" {
+		return "", nil, p.errorf("expected an identifier")
+	}
+	p.skipBlankInline()
+	if !p.consume('=') {
+		return "", nil, p.errorf("expected '=' after %s", id)
+	}
+
+	value, err := p.parsePattern()
+	if err != nil {
+		return "", nil, err
+	}
+	entry := &ftlEntry{Value: value, Attributes: make(map[string]ftlPattern)}
+
+	for {
+		start := p.pos
+		p.skipBlankLines()
+		p.skipBlankInline()
+		if !p.consume('.') {
+			p.pos = start
+			break
+		}
+		attr := p.parseIdentifier()
+		if attr == "" {
+			return "", nil, p.errorf("expected an attribute name in %s", id)
+		}
+		p.skipBlankInline()
+		if !p.consume('=') {
+			return "", nil, p.errorf("expected '=' after attribute %s.%s", id, attr)
+		}
+		pattern, err := p.parsePattern()
+		if err != nil {
+			return "", nil, err
+		}
+		if pattern == nil {
+			return "", nil, p.errorf("attribute %s.%s has no value", id, attr)
+		}
+		entry.Attributes[attr] = pattern
+	}
+
+	if value == nil && (isTerm || len(entry.Attributes) == 0) {
+		return "", nil, p.errorf("%s has no value", id)
+	}
+	p.skipLine()
+	return id, entry, nil
+}
+
+// parsePattern parses inline text, placeables and indented continuation lines
+func (p *ftlParser) parsePattern() (ftlPattern, error) {
+	var elements []any
+	commonIndent := -1
+	p.skipBlankInline()
+
+	for !p.eof() {
+		ch := p.peek()
+		switch {
+		case ch == '\n':
+			indent, ok := p.parseContinuation()
+			if !ok {
+				return finishPattern(elements, commonIndent), nil
+			}
+			if commonIndent < 0 || indent.Length < commonIndent {
+				commonIndent = indent.Length
+			}
+			elements = append(elements, indent)
+		case ch == '{':
+			placeable, err := p.parsePlaceable()
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, placeable)
+		case ch == '}':
+			return nil, p.errorf("unbalanced closing brace")
+		default:
+			start := p.pos
+			for !p.eof() && p.peek() != '{' && p.peek() != '}' && p.peek() != '\n' {
+				p.pos++
+			}
+			elements = append(elements, string(p.input[start:p.pos]))
+		}
+	}
+	return finishPattern(elements, commonIndent), nil
+}
+
+// parseContinuation consumes line breaks up to an indented continuation line of a pattern
+// Lines starting with "[", "*", "." or "}" end the pattern, as do unindented lines other than "{"
+func (p *ftlParser) parseContinuation() (ftlIndent, bool) {
+	start := p.pos
+	newlines := 0
+	for {
+		if p.eof() || p.peek() != '\n' {
+			break
+		}
+		p.pos++
+		newlines++
+		lineStart := p.pos
+		p.skipBlankInline()
+		if p.eof() || p.peek() != '\n' {
+			indentLength := p.pos - lineStart
+			if p.eof() {
+				break
+			}
+			ch := p.peek()
+			isText := indentLength > 0 && ch != '[' && ch != '*' && ch != '.' && ch != '}'
+			if !isText && ch != '{' {
+				break
+			}
+			return ftlIndent{Value: strings.Repeat("\n", newlines) + strings.Repeat(" ", indentLength), Length: indentLength}, true
+		}
+	}
+	p.pos = start
+	return ftlIndent{}, false
+}
+
+// finishPattern removes the common indentation and trailing blank space of a pattern
+func finishPattern(elements []any, commonIndent int) ftlPattern {
+	var pattern ftlPattern
+	for i, element := range elements {
+		if indent, isIndent := element.(ftlIndent); isIndent {
+			text := indent.Value[:len(indent.Value)-commonIndent]
+			if i == 0 {
+				text = strings.TrimLeft(text, "\n")
+			}
+			element = text
+		}
+
+		text, isText := element.(string)
+		if !isText {
+			pattern = append(pattern, element)
+			continue
+		}
+		if last := len(pattern) - 1; last >= 0 {
+			if previous, ok := pattern[last].(string); ok {
+				pattern[last] = previous + text
+				continue
+			}
+		}
+		pattern = append(pattern, text)
+	}
+
+	if last := len(pattern) - 1; last >= 0 {
+		if text, ok := pattern[last].(string); ok {
+			if text = strings.TrimRight(text, " \n"); text == "" {
+				pattern = pattern[:last]
+			} else {
+				pattern[last] = text
+			}
+		}
+	}
+	if len(pattern) == 0 {
+		return nil
+	}
+	return pattern
+}
+
+// parsePlaceable parses "{ expression }" or "{ selector -> variants }"
+func (p *ftlParser) parsePlaceable() (any, error) {
+	p.pos++ // skip '{'
+	p.skipBlank()
+
+	expr, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+
+	if p.consumeString("->") {
+		sel, err := p.parseVariants(expr)
+		if err != nil {
+			return nil, err
+		}
+		expr = sel
+	}
+
+	p.skipBlank()
+	if !p.consume('}') {
+		return nil, p.errorf("expected '}'")
+	}
+	return expr, nil
+}
+
+// parseVariants parses the variants of a select expression
+func (p *ftlParser) parseVariants(selector any) (ftlSelect, error) {
+	sel := ftlSelect{Selector: selector, Default: -1}
+	for {
+		p.skipBlank()
+		isDefault := p.consume('*')
+		if !p.consume('[') {
+			if isDefault {
+				return sel, p.errorf("expected '[' after '*'")
+			}
+			break
+		}
+
+		p.skipBlank()
+		variant := ftlVariant{}
+		if ch := p.peek(); ch == '-' || (ch >= '0' && ch <= '9') {
+			number, err := p.parseNumber()
+			if err != nil {
+				return sel, err
+			}
+			variant.Key, variant.Numeric = number.Raw, true
+		} else {
+			variant.Key = p.parseIdentifier()
+		}
+		if variant.Key == "" {
+			return sel, p.errorf("expected a variant key")
+		}
+		p.skipBlank()
+		if !p.consume(']') {
+			return sel, p.errorf("expected ']' after variant key %s", variant.Key)
+		}
+
+		value, err := p.parsePattern()
+		if err != nil {
+			return sel, err
+		}
+		variant.Value = value
+
+		if isDefault {
+			if sel.Default >= 0 {
+				return sel, p.errorf("select expression has more than one default variant")
+			}
+			sel.Default = len(sel.Variants)
+		}
+		sel.Variants = append(sel.Variants, variant)
+	}
+
+	if sel.Default < 0 {
+		return sel, p.errorf("select expression has no default variant")
+	}
+	return sel, nil
+}
+
+// parseExpression parses an inline expression
+func (p *ftlParser) parseExpression() (any, error) {
+	if p.eof() {
+		return nil, p.errorf("expected an expression")
+	}
+
+	switch ch := p.peek(); {
+	case ch == '"':
+		return p.parseString()
+	case ch >= '0' && ch <= '9' || (ch == '-' && p.peekAt(1) >= '0' && p.peekAt(1) <= '9'):
+		return p.parseNumber()
+	case ch == '$':
+		p.pos++
+		name := p.parseIdentifier()
+		if name == "" {
+			return nil, p.errorf("expected a variable name")
+		}
+		return ftlVariableRef(name), nil
+	case ch == '-':
+		p.pos++
+		ref := ftlTermRef{ID: p.parseIdentifier()}
+		if ref.ID == "" {
+			return nil, p.errorf("expected a term name")
+		}
+		if p.consume('.') {
+			ref.Attr = p.parseIdentifier()
+		}
+		p.skipBlank()
+		if p.peek() == '(' {
+			_, named, err := p.parseArguments()
+			if err != nil {
+				return nil, err
+			}
+			ref.Named = named
+		}
+		return ref, nil
+	case ch == '{':
+		placeable, err := p.parsePlaceable()
+		if err != nil {
+			return nil, err
+		}
+		if _, isPattern := placeable.(ftlPattern); isPattern {
+			return placeable, nil
+		}
+		return ftlPattern{placeable}, nil
+	case isFluentIdentifierStart(ch):
+		id := p.parseIdentifier()
+		start := p.pos
+		p.skipBlank()
+		if p.peek() == '(' {
+			if strings.ToUpper(id) != id {
+				return nil, p.errorf("function names must be upper case: %s", id)
+			}
+			positional, named, err := p.parseArguments()
+			if err != nil {
+				return nil, err
+			}
+			return ftlFunctionCall{Name: id, Positional: positional, Named: named}, nil
+		}
+		p.pos = start
+		ref := ftlMessageRef{ID: id}
+		if p.consume('.') {
+			ref.Attr = p.parseIdentifier()
+		}
+		return ref, nil
+	}
+	return nil, p.errorf("unexpected character %q in expression", p.peek())
+}
+
+// parseArguments parses "(positional, name: literal)" call arguments
+func (p *ftlParser) parseArguments() ([]any, map[string]any, error) {
+	p.pos++ // skip '('
+	var positional []any
+	named := make(map[string]any)
+
+	for {
+		p.skipBlank()
+		if p.consume(')') {
+			return positional, named, nil
+		}
+
+		start := p.pos
+		if name := p.parseIdentifier(); name != "" {
+			p.skipBlank()
+			if p.consume(':') {
+				p.skipBlank()
+				value, err := p.parseLiteral()
+				if err != nil {
+					return nil, nil, err
+				}
+				named[name] = value
+				p.skipBlank()
+				p.consume(',')
+				continue
+			}
+		}
+		p.pos = start
+
+		value, err := p.parseExpression()
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(named) > 0 {
+			return nil, nil, p.errorf("positional arguments must come before named arguments")
+		}
+		positional = append(positional, value)
+		p.skipBlank()
+		if !p.consume(',') && p.peek() != ')' {
+			return nil, nil, p.errorf("expected ',' or ')' in call arguments")
+		}
+	}
+}
+
+// parseLiteral parses a string or number literal
+func (p *ftlParser) parseLiteral() (any, error) {
+	if p.peek() == '"' {
+		return p.parseString()
+	}
+	return p.parseNumber()
+}
+
+// parseString parses a quoted string literal with \" \\ \uXXXX and \UXXXXXX escapes
+func (p *ftlParser) parseString() (ftlStringLiteral, error) {
+	p.pos++ // skip '"'
+	var sb strings.Builder
+	for !p.eof() {
+		ch := p.peek()
+		p.pos++
+		switch ch {
+		case '"':
+			return ftlStringLiteral(sb.String()), nil
+		case '\n':
+			return "", p.errorf("unterminated string literal")
+		case '\\':
+			if p.eof() {
+				return "", p.errorf("unterminated string literal")
+			}
+			escape := p.peek()
+			p.pos++
+			switch escape {
+			case '"', '\\':
+				sb.WriteRune(escape)
+			case 'u', 'U':
+				size := 4
+				if escape == 'U' {
+					size = 6
+				}
+				if p.pos+size > len(p.input) {
+					return "", p.errorf("invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(string(p.input[p.pos:p.pos+size]), 16, 32)
+				if err != nil || !utf8.ValidRune(rune(code)) {
+					return "", p.errorf("invalid unicode escape")
+				}
+				sb.WriteRune(rune(code))
+				p.pos += size
+			default:
+				return "", p.errorf("unknown escape sequence \\%c", escape)
+			}
+		default:
+			sb.WriteRune(ch)
+		}
+	}
+	return "", p.errorf("unterminated string literal")
+}
+
+// parseNumber parses a number literal such as -1, 3 or 0.50
+func (p *ftlParser) parseNumber() (ftlNumberLiteral, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for !p.eof() && (p.peek() >= '0' && p.peek() <= '9' || p.peek() == '.') {
+		p.pos++
+	}
+	raw := string(p.input[start:p.pos])
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsInf(value, 0) {
+		return ftlNumberLiteral{}, p.errorf("invalid number literal %q", raw)
+	}
+	return ftlNumberLiteral{Value: value, Raw: raw}, nil
+}
+
+// parseIdentifier parses [a-zA-Z][a-zA-Z0-9_-]*
+func (p *ftlParser) parseIdentifier() string {
+	start := p.pos
+	if p.eof() || !isFluentIdentifierStart(p.peek()) {
+		return ""
+	}
+	for !p.eof() {
+		ch := p.peek()
+		if !isFluentIdentifierStart(ch) && !(ch >= '0' && ch <= '9') && ch != '_' && ch != '-' {
+			break
+		}
+		p.pos++
+	}
+	return string(p.input[start:p.pos])
+}
+
+// isFluentIdentifierStart reports whether ch may start an identifier
+func isFluentIdentifierStart(ch rune) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z'
+}
+
+// eof reports whether the parser reached the end of input
+func (p *ftlParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+// peek returns the current character, or 0 at the end of input
+func (p *ftlParser) peek() rune {
+	return p.peekAt(0)
+}
+
+// peekAt returns the character at an offset from the current position, or 0 past the end of input
+func (p *ftlParser) peekAt(offset int) rune {
+	if p.pos+offset >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos+offset]
+}
+
+// consume skips the given character if it is next
+func (p *ftlParser) consume(ch rune) bool {
+	if p.peek() == ch && !p.eof() {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// consumeString skips the given text if it is next
+func (p *ftlParser) consumeString(s string) bool {
+	runes := []rune(s)
+	if p.pos+len(runes) > len(p.input) || string(p.input[p.pos:p.pos+len(runes)]) != s {
+		return false
+	}
+	p.pos += len(runes)
+	return true
+}
+
+// skipBlankInline skips spaces on the current line
+func (p *ftlParser) skipBlankInline() {
+	for !p.eof() && p.peek() == ' ' {
+		p.pos++
+	}
+}
+
+// skipBlank skips spaces and line breaks
+func (p *ftlParser) skipBlank() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\n') {
+		p.pos++
+	}
+}
+
+// skipBlankLines skips lines that contain only spaces
+func (p *ftlParser) skipBlankLines() {
+	for !p.eof() {
+		start := p.pos
+		p.skipBlankInline()
+		if !p.consume('\n') {
+			p.pos = start
+			return
+		}
+	}
+}
+
+// skipLine skips to the start of the next line
+func (p *ftlParser) skipLine() {
+	for !p.eof() && p.peek() != '\n' {
+		p.pos++
+	}
+	p.consume('\n')
+}
@@ -0,0 +1,263 @@
+package goresponse
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testFluentSource is a Fluent resource used across Fluent tests
+const testFluentSource = `# Product terms
+-brand = Risoft
+    .gender = feminine
+-brand-case = { $case ->
+    [genitive] Risofts
+   *[nominative] Risoft
+}
+
+## Messages
+hello = Hello, { $name }!
+welcome = Welcome to { -brand }.
+about = About { -brand-case(case: "genitive") } apps
+emails = { $count ->
+    [0] You have no emails.
+    [one] You have one email.
+   *[other] You have { $count } emails.
+}
+brand-pronoun = { -brand.gender ->
+    [feminine] She
+   *[other] It
+} is ready.
+multiline =
+    First line
+      indented line
+
+    Last line
+login = Sign in
+    .placeholder = Your email
+    .title = Sign in to { -brand }
+refers = { hello } Again.
+literal = { "{" }braces{ "}" } and { "A" }
+total = Total: { NUMBER($amount, minimumFractionDigits: 2) }
+ratio = { NUMBER($ratio, style: "percent") }
+price = { NUMBER($amount, style: "currency", currency: "EUR") }
+decimal-select = { NUMBER($n, minimumFractionDigits: 1) ->
+    [one] one
+   *[other] other
+}
+due = Due { DATETIME($date, dateStyle: "long") }
+loop = { loop }
+missing = { $unknown } { unknown-message } { -unknown-term }
+`
+
+// TestFluentBundleFormat tests Fluent message rendering
+func TestFluentBundleFormat(t *testing.T) {
+	bundle, err := NewFluentBundle("en", testFluentSource)
+	if err != nil {
+		t.Fatalf("NewFluentBundle failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		id       string
+		args     map[string]any
+		expected string
+	}{
+		{name: "Variable", id: "hello", args: map[string]any{"name": "Budi"}, expected: "Hello, Budi!"},
+		{name: "Term", id: "welcome", expected: "Welcome to Risoft."},
+		{name: "Parameterized term", id: "about", expected: "About Risofts apps"},
+		{name: "Selector exact number", id: "emails", args: map[string]any{"count": 0}, expected: "You have no emails."},
+		{name: "Selector plural one", id: "emails", args: map[string]any{"count": 1}, expected: "You have one email."},
+		{name: "Selector default", id: "emails", args: map[string]any{"count": 1500}, expected: "You have 1,500 emails."},
+		{name: "Term attribute selector", id: "brand-pronoun", expected: "She is ready."},
+		{name: "Multiline", id: "multiline", expected: "First line\n  indented line\n\nLast line"},
+		{name: "Message value", id: "login", expected: "Sign in"},
+		{name: "Attribute", id: "login.placeholder", expected: "Your email"},
+		{name: "Attribute with term", id: "login.title", expected: "Sign in to Risoft"},
+		{name: "Message reference", id: "refers", args: map[string]any{"name": "Ana"}, expected: "Hello, Ana! Again."},
+		{name: "String literals", id: "literal", expected: "{braces} and A"},
+		{name: "NUMBER fraction digits", id: "total", args: map[string]any{"amount": 5}, expected: "Total: 5.00"},
+		{name: "NUMBER percent", id: "ratio", args: map[string]any{"ratio": 0.25}, expected: "25%"},
+		{name: "NUMBER currency", id: "price", args: map[string]any{"amount": 9.5}, expected: "€9.50"},
+		{name: "NUMBER keeps digits for selection", id: "decimal-select", args: map[string]any{"n": 1}, expected: "other"},
+		{name: "DATETIME", id: "due", args: map[string]any{"date": time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)}, expected: "Due March 5, 2024"},
+		{name: "Cyclic reference", id: "loop", expected: "{???}"},
+		{name: "Missing references", id: "missing", expected: "{$unknown} {unknown-message} {-unknown-term}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, exists := bundle.Format(tt.id, tt.args)
+			if !exists {
+				t.Fatalf("Expected message %s to exist", tt.id)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+
+	t.Run("Unknown message", func(t *testing.T) {
+		if _, exists := bundle.Format("nope", nil); exists {
+			t.Error("Expected unknown message to not exist")
+		}
+		if bundle.HasMessage("login.missing") {
+			t.Error("Expected unknown attribute to not exist")
+		}
+		if !bundle.HasMessage("login.title") {
+			t.Error("Expected attribute to exist")
+		}
+	})
+
+	t.Run("Nil bundle", func(t *testing.T) {
+		var nilBundle *FluentBundle
+		if _, exists := nilBundle.Format("hello", nil); exists {
+			t.Error("Expected nil bundle to have no messages")
+		}
+	})
+}
+
+// TestFluentBundleLanguage tests locale-aware selection and formatting in Fluent messages
+func TestFluentBundleLanguage(t *testing.T) {
+	bundle, err := NewFluentBundle("ru", `files = { $count ->
+    [one] { $count } файл
+    [few] { $count } файла
+   *[many] { $count } файлов
+}
+`)
+	if err != nil {
+		t.Fatalf("NewFluentBundle failed: %v", err)
+	}
+
+	tests := map[int]string{
+		1:    "1 файл",
+		3:    "3 файла",
+		11:   "11 файлов",
+		1002: "1\u00a0002 файла",
+	}
+	for count, expected := range tests {
+		if result, _ := bundle.Format("files", map[string]any{"count": count}); result != expected {
+			t.Errorf("count %d: expected %q, got %q", count, expected, result)
+		}
+	}
+}
+
+// TestNewFluentBundleErrors tests Fluent parse errors
+func TestNewFluentBundleErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		source        string
+		errorContains string
+	}{
+		{name: "Missing equals", source: "hello Hello", errorContains: "expected '=' after hello"},
+		{name: "Missing value", source: "hello =\n", errorContains: "hello has no value"},
+		{name: "Term without value", source: "-brand =\n    .gender = x\n", errorContains: "brand has no value"},
+		{name: "Unclosed placeable", source: "hello = { $name", errorContains: "expected '}'"},
+		{name: "Unbalanced brace", source: "hello = a } b", errorContains: "unbalanced closing brace"},
+		{name: "No default variant", source: "a = { $n ->\n    [one] x\n    [other] y\n}\n", errorContains: "no default variant"},
+		{name: "Two default variants", source: "a = { $n ->\n   *[one] x\n   *[other] y\n}\n", errorContains: "more than one default variant"},
+		{name: "Lowercase function", source: "a = { number($n) }", errorContains: "function names must be upper case"},
+		{name: "Bad escape", source: `a = { "\q" }`, errorContains: "unknown escape sequence"},
+		{name: "Junk", source: "  indented = x", errorContains: "line 1: expected a message, term or comment"},
+		{name: "Error line", source: "a = b\n\nc d", errorContains: "line 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFluentBundle("en", tt.source)
+			if err == nil {
+				t.Fatal("Expected error but got none")
+			}
+			if !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error to contain '%s', got: %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+// TestBuildResponseFluent tests Fluent translation sources in BuildResponse
+func TestBuildResponseFluent(t *testing.T) {
+	files := map[string]string{
+		"fluent_de.ftl":  "-app = Konto\nwelcome = Willkommen bei { -app }, { $name }!\nitems = { $count ->\n    [one] Ein Artikel\n   *[other] { $count } Artikel\n}\n",
+		"fluent_id.txt":  "welcome = Selamat datang, { $name }!\n",
+		"fluent_bad.ftl": "welcome = { $name\n",
+	}
+	for filename, content := range files {
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file %s: %v", filename, err)
+		}
+		defer os.Remove(filename)
+	}
+
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"welcome": {Key: "welcome", Template: "Welcome $name", CodeMappings: map[string]int{"http": 200}},
+			"items":   {Key: "items", Template: "$count items", Translations: map[string]string{"de": "$count Elemente"}},
+		},
+		DefaultLanguage: "en",
+		TranslationSources: map[string]TranslationSource{
+			"de": {Method: "file", Path: "fluent_de.ftl"},
+			"id": {Method: "file", Path: "fluent_id.txt", Format: SourceFormatFluent},
+		},
+	}
+	if err := loadTranslationSources(config); err != nil {
+		t.Fatalf("loadTranslationSources failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		language string
+		params   map[string]any
+		expected string
+	}{
+		{name: "Fluent message with term", key: "welcome", language: "de", params: map[string]any{"name": "Jonas"}, expected: "Willkommen bei Konto, Jonas!"},
+		{name: "Explicit fluent format", key: "welcome", language: "id", params: map[string]any{"name": "Budi"}, expected: "Selamat datang, Budi!"},
+		{name: "Template translation takes priority", key: "items", language: "de", params: map[string]any{"count": 1}, expected: "1 Elemente"},
+		{name: "Default language template", key: "welcome", language: "en", params: map[string]any{"name": "Ann"}, expected: "Welcome Ann"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewResponseBuilder(tt.key).SetLanguage(tt.language).SetParams(tt.params)
+			response, err := config.BuildResponse(builder)
+			if err != nil {
+				t.Fatalf("BuildResponse failed: %v", err)
+			}
+			if response.Message != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, response.Message)
+			}
+		})
+	}
+
+	t.Run("GetTranslation", func(t *testing.T) {
+		if translation, exists := config.GetTranslation("de", "items"); !exists || translation != "{$count} Artikel" {
+			t.Errorf("Expected Fluent translation without arguments, got %q", translation)
+		}
+	})
+
+	t.Run("Invalid Fluent source", func(t *testing.T) {
+		badConfig := &ResponseConfig{
+			TranslationSources: map[string]TranslationSource{
+				"de": {Method: "file", Path: "fluent_bad.ftl"},
+			},
+		}
+		err := loadTranslationSources(badConfig)
+		if err == nil || !strings.Contains(err.Error(), "failed to parse Fluent translations") {
+			t.Errorf("Expected Fluent parse error, got %v", err)
+		}
+	})
+
+	t.Run("Unsupported format", func(t *testing.T) {
+		badConfig := &ResponseConfig{
+			TranslationSources: map[string]TranslationSource{
+				"de": {Method: "file", Path: "fluent_de.ftl", Format: "yaml"},
+			},
+		}
+		err := loadTranslationSources(badConfig)
+		if err == nil || !strings.Contains(err.Error(), "unsupported translation source format: yaml") {
+			t.Errorf("Expected unsupported format error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,290 @@
+package goresponse
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Percent is a ratio parameter rendered as a localized percentage (0.25 -> 25%)
+type Percent float64
+
+// Decimal is a numeric parameter rendered with a fixed number of fraction digits
+type Decimal struct {
+	Value  float64 // Numeric value
+	Digits int     // Number of fraction digits to render
+}
+
+// Money is a monetary parameter rendered with the locale's currency placement
+type Money struct {
+	Amount   float64 // Monetary amount
+	Currency string  // ISO 4217 currency code (USD, IDR, EUR, etc.)
+}
+
+// Integer is an integer parameter rendered with the locale's group separator
+// Plain integer params are only grouped when the template sets GroupIntegers, as they are often IDs, years or codes
+type Integer int64
+
+// maxFractionDigits is the number of fraction digits kept when rendering plain floats
+const maxFractionDigits = 3
+
+// Formatter renders parameter values using the locale data of a language
+// A nil Formatter or a Formatter without locale data renders values with fmt's default format
+type Formatter struct {
+	Language string         // Language tag used for formatting
+	Locale   *Locale        // Locale data, nil when the language has no known data
+	Location *time.Location // Timezone for time values, nil keeps the value's own timezone
+	Now      time.Time      // Reference time for relative phrases, zero uses the current time
+
+	GroupIntegers bool // Render plain integer params with the locale's group separator
+}
+
+// NewFormatter creates Formatter instance for the given language
+func NewFormatter(language string) *Formatter {
+	locale, _ := LookupLocale(language)
+	return &Formatter{
+		Language: language,
+		Locale:   locale,
+	}
+}
+
+// WithLocation sets the timezone used to render time values
+func (f *Formatter) WithLocation(loc *time.Location) *Formatter {
+	f.Location = loc
+	return f
+}
+
+// WithNow sets the reference time used to render relative phrases
+func (f *Formatter) WithNow(now time.Time) *Formatter {
+	f.Now = now
+	return f
+}
+
+// WithGroupIntegers sets whether plain integer params are rendered with the locale's group separator
+func (f *Formatter) WithGroupIntegers(group bool) *Formatter {
+	f.GroupIntegers = group
+	return f
+}
+
+// Format renders a parameter value as a localized string
+// Floats, times, durations, slices, Integer, Percent, Decimal, Money, DateTime, Relative and List values
+// use the locale data when available; plain integers only when GroupIntegers is set
+func (f *Formatter) Format(value any) string {
+	switch v := value.(type) {
+	case Integer:
+		return f.FormatInt(int64(v))
+	case Percent:
+		return f.FormatPercent(float64(v))
+	case Decimal:
+		return f.FormatDecimal(v.Value, v.Digits)
+	case Money:
+		return f.FormatCurrency(v.Amount, v.Currency)
+	case DateTime:
+		return f.FormatDateTime(v.Time, v.DateStyle, v.TimeStyle)
+	case List:
+		items, _ := listItems(v.Items)
+		return f.FormatList(items, v.Style)
+	case Relative:
+		if v.Time.IsZero() {
+			return f.FormatRelativeDuration(v.Offset)
+		}
+		return f.FormatRelative(v.Time)
+	case time.Time:
+		if f != nil {
+			return f.FormatDateTime(v, StyleDefault, StyleDefault)
+		}
+	case time.Duration:
+		if f != nil {
+			return f.FormatDuration(v)
+		}
+	}
+
+	if items, ok := listItems(value); ok && f != nil {
+		return f.FormatList(items, ListAnd)
+	}
+
+	if f == nil || f.Locale == nil {
+		return formatDefault(value)
+	}
+
+	switch v := value.(type) {
+	case float32:
+		return f.FormatNumber(float64(v))
+	case float64:
+		return f.FormatNumber(v)
+	}
+	if !f.GroupIntegers {
+		return formatDefault(value)
+	}
+
+	switch v := value.(type) {
+	case int:
+		return f.FormatInt(int64(v))
+	case int8:
+		return f.FormatInt(int64(v))
+	case int16:
+		return f.FormatInt(int64(v))
+	case int32:
+		return f.FormatInt(int64(v))
+	case int64:
+		return f.FormatInt(v)
+	case uint:
+		return f.FormatUint(uint64(v))
+	case uint8:
+		return f.FormatUint(uint64(v))
+	case uint16:
+		return f.FormatUint(uint64(v))
+	case uint32:
+		return f.FormatUint(uint64(v))
+	case uint64:
+		return f.FormatUint(v)
+	}
+
+	return formatDefault(value)
+}
+
+// formatGrouped renders a value like Format with integers grouped, for syntaxes formatting numbers explicitly
+func (f *Formatter) formatGrouped(value any) string {
+	if f == nil || f.GroupIntegers {
+		return f.Format(value)
+	}
+	grouped := *f
+	grouped.GroupIntegers = true
+	return grouped.Format(value)
+}
+
+// FormatInt renders an integer with the locale's group separator
+func (f *Formatter) FormatInt(v int64) string {
+	if f == nil || f.Locale == nil {
+		return strconv.FormatInt(v, 10)
+	}
+	digits := strconv.FormatInt(v, 10)
+	if v < 0 {
+		return "-" + groupDigits(digits[1:], f.Locale.GroupSeparator)
+	}
+	return groupDigits(digits, f.Locale.GroupSeparator)
+}
+
+// FormatUint renders an unsigned integer with the locale's group separator
+func (f *Formatter) FormatUint(v uint64) string {
+	if f == nil || f.Locale == nil {
+		return strconv.FormatUint(v, 10)
+	}
+	return groupDigits(strconv.FormatUint(v, 10), f.Locale.GroupSeparator)
+}
+
+// FormatNumber renders a float with up to three fraction digits, dropping trailing zeros
+// Numbers with a fraction part keep at least the locale's MinFractionDigits (1.234.567,50 in id)
+func (f *Formatter) FormatNumber(v float64) string {
+	minDigits := 0
+	if f != nil && f.Locale != nil && v != math.Trunc(v) {
+		minDigits = f.Locale.MinFractionDigits
+	}
+	return f.formatFloat(v, minDigits, max(minDigits, maxFractionDigits))
+}
+
+// FormatDecimal renders a float with exactly the given number of fraction digits
+func (f *Formatter) FormatDecimal(v float64, digits int) string {
+	if digits < 0 {
+		digits = 0
+	}
+	return f.formatFloat(v, digits, digits)
+}
+
+// FormatPercent renders a ratio as a percentage using the locale's percent pattern
+func (f *Formatter) FormatPercent(ratio float64) string {
+	number := f.formatFloat(ratio*100, 0, 2)
+	if f == nil || f.Locale == nil {
+		return number + "%"
+	}
+	return strings.Replace(f.Locale.PercentPattern, "#", number, 1)
+}
+
+// FormatCurrency renders a monetary amount using the locale's currency pattern
+// The number of fraction digits follows the currency (JPY has none, USD has two)
+func (f *Formatter) FormatCurrency(amount float64, currency string) string {
+	digits := CurrencyDigits(currency)
+	number := f.formatFloat(math.Abs(amount), digits, digits)
+
+	// Without locale data the ISO code is used, as symbols like "$" are ambiguous
+	pattern, symbol := "¤ #", strings.ToUpper(currency)
+	if f != nil && f.Locale != nil {
+		pattern, symbol = f.Locale.CurrencyPattern, CurrencySymbol(currency)
+	}
+
+	result := strings.Replace(strings.Replace(pattern, "#", number, 1), "¤", symbol, 1)
+	if amount < 0 && strings.ContainsAny(number, "123456789") {
+		return "-" + result
+	}
+	return result
+}
+
+// FormatDateTime renders a time with localized month and day names in the formatter's timezone
+// StyleDefault renders a medium date and a short time, StyleNone omits the part
+func (f *Formatter) FormatDateTime(t time.Time, dateStyle, timeStyle DateTimeStyle) string {
+	if f != nil && f.Location != nil {
+		t = t.In(f.Location)
+	}
+
+	if f == nil || f.Locale == nil || f.Locale.Calendar == nil {
+		return isoCalendar.FormatDateTime(t, dateStyle, timeStyle)
+	}
+	return f.Locale.Calendar.FormatDateTime(t, dateStyle, timeStyle)
+}
+
+// formatFloat renders a float with between minDigits and maxDigits fraction digits
+func (f *Formatter) formatFloat(v float64, minDigits, maxDigits int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	s := strconv.FormatFloat(v, 'f', maxDigits, 64)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	for len(fracPart) > minDigits && strings.HasSuffix(fracPart, "0") {
+		fracPart = fracPart[:len(fracPart)-1]
+	}
+
+	decimalSep, groupSep := ".", ""
+	if f != nil && f.Locale != nil {
+		decimalSep, groupSep = f.Locale.DecimalSeparator, f.Locale.GroupSeparator
+	}
+
+	result := groupDigits(intPart, groupSep)
+	if fracPart != "" {
+		result += decimalSep + fracPart
+	}
+	if negative && strings.ContainsAny(intPart+fracPart, "123456789") {
+		result = "-" + result
+	}
+	return result
+}
+
+// groupDigits inserts the separator between groups of three digits
+func groupDigits(digits, separator string) string {
+	if separator == "" || len(digits) <= 3 {
+		return digits
+	}
+
+	var sb strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		sb.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if sb.Len() > 0 {
+			sb.WriteString(separator)
+		}
+		sb.WriteString(digits[i : i+3])
+	}
+	return sb.String()
+}
+
+// formatDefault renders a value with fmt's default format
+func formatDefault(value any) string {
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,284 @@
+package goresponse
+
+import (
+	"testing"
+)
+
+// TestFormatterFormat tests Formatter.Format method
+func TestFormatterFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		value    any
+		expected string
+	}{
+		{
+			name:     "English integer",
+			language: "en",
+			value:    Integer(1234567),
+			expected: "1,234,567",
+		},
+		{
+			name:     "Indonesian integer",
+			language: "id",
+			value:    Integer(1234567),
+			expected: "1.234.567",
+		},
+		{
+			name:     "Negative integer",
+			language: "en",
+			value:    Integer(-1234),
+			expected: "-1,234",
+		},
+		{
+			name:     "Plain integer is not grouped",
+			language: "en",
+			value:    1234567,
+			expected: "1234567",
+		},
+		{
+			name:     "Small integer",
+			language: "en",
+			value:    uint8(25),
+			expected: "25",
+		},
+		{
+			name:     "Indonesian float",
+			language: "id",
+			value:    1234567.5,
+			expected: "1.234.567,50",
+		},
+		{
+			name:     "Indonesian float without fraction",
+			language: "id",
+			value:    1500.0,
+			expected: "1.500",
+		},
+		{
+			name:     "Float rounded to three digits",
+			language: "en",
+			value:    3.14159,
+			expected: "3.142",
+		},
+		{
+			name:     "Indonesian decimal",
+			language: "id",
+			value:    Decimal{Value: 1234567.5, Digits: 2},
+			expected: "1.234.567,50",
+		},
+		{
+			name:     "German percent",
+			language: "de",
+			value:    Percent(0.125),
+			expected: "12,5\u00a0%",
+		},
+		{
+			name:     "English percent",
+			language: "en",
+			value:    Percent(0.5),
+			expected: "50%",
+		},
+		{
+			name:     "Indonesian currency",
+			language: "id",
+			value:    Money{Amount: 1234567.5, Currency: "IDR"},
+			expected: "Rp1.234.567,50",
+		},
+		{
+			name:     "German currency",
+			language: "de",
+			value:    Money{Amount: 1234.5, Currency: "EUR"},
+			expected: "1.234,50\u00a0€",
+		},
+		{
+			name:     "Negative currency",
+			language: "en",
+			value:    Money{Amount: -12.5, Currency: "USD"},
+			expected: "-$12.50",
+		},
+		{
+			name:     "Currency without fraction digits",
+			language: "ja",
+			value:    Money{Amount: 1500, Currency: "JPY"},
+			expected: "¥1,500",
+		},
+		{
+			name:     "Region falls back to base data",
+			language: "pt-BR",
+			value:    1234.5,
+			expected: "1.234,5",
+		},
+		{
+			name:     "Unknown language keeps default format",
+			language: "xx",
+			value:    1234567.5,
+			expected: "1.2345675e+06",
+		},
+		{
+			name:     "Unknown language currency uses code",
+			language: "xx",
+			value:    Money{Amount: 10, Currency: "usd"},
+			expected: "USD 10.00",
+		},
+		{
+			name:     "String is unchanged",
+			language: "id",
+			value:    "1234",
+			expected: "1234",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewFormatter(tt.language).Format(tt.value)
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestFormatterGroupIntegers tests grouping plain integer params
+func TestFormatterGroupIntegers(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{name: "int", value: 1234567, expected: "1.234.567"},
+		{name: "int64", value: int64(-1234), expected: "-1.234"},
+		{name: "uint16", value: uint16(65535), expected: "65.535"},
+		{name: "Small integer", value: uint8(25), expected: "25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := NewFormatter("id").WithGroupIntegers(true).Format(tt.value); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestNilFormatter tests that a nil Formatter uses fmt's default format
+func TestNilFormatter(t *testing.T) {
+	var formatter *Formatter
+
+	if result := formatter.Format(1234567); result != "1234567" {
+		t.Errorf("Expected '1234567', got '%s'", result)
+	}
+	if result := formatter.Format(98.5); result != "98.5" {
+		t.Errorf("Expected '98.5', got '%s'", result)
+	}
+	if result := formatter.Format(Percent(0.25)); result != "25%" {
+		t.Errorf("Expected '25%%', got '%s'", result)
+	}
+}
+
+// TestGroupDigits tests groupDigits function
+func TestGroupDigits(t *testing.T) {
+	tests := []struct {
+		digits   string
+		expected string
+	}{
+		{digits: "1", expected: "1"},
+		{digits: "123", expected: "123"},
+		{digits: "1234", expected: "1,234"},
+		{digits: "123456", expected: "123,456"},
+		{digits: "1234567", expected: "1,234,567"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.digits, func(t *testing.T) {
+			if result := groupDigits(tt.digits, ","); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestBuildResponseLocalizedNumbers tests locale-aware number substitution in BuildResponse
+func TestBuildResponseLocalizedNumbers(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"balance": {
+				Key:      "balance",
+				Template: "Balance: $amount",
+				Translations: map[string]string{
+					"id": "Saldo: $amount",
+				},
+				GroupIntegers: true,
+			},
+			"order": {
+				Key:      "order",
+				Template: "Order $amount",
+			},
+		},
+		DefaultLanguage: "en",
+		Languages:       []string{"en", "id"},
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		language string
+		amount   any
+		expected string
+	}{
+		{
+			name:     "Indonesian number",
+			language: "id",
+			amount:   1234567.5,
+			expected: "Saldo: 1.234.567,50",
+		},
+		{
+			name:     "Indonesian money",
+			language: "id",
+			amount:   Money{Amount: 1234567.5, Currency: "IDR"},
+			expected: "Saldo: Rp1.234.567,50",
+		},
+		{
+			name:     "English number",
+			language: "en",
+			amount:   1234567.5,
+			expected: "Balance: 1,234,567.5",
+		},
+		{
+			name:     "Default language is used when empty",
+			language: "",
+			amount:   1234567,
+			expected: "Balance: 1,234,567",
+		},
+		{
+			name:     "Integers are not grouped without opt-in",
+			key:      "order",
+			language: "en",
+			amount:   1234567,
+			expected: "Order 1234567",
+		},
+		{
+			name:     "Integer param opts in",
+			key:      "order",
+			language: "id",
+			amount:   Integer(1234567),
+			expected: "Order 1.234.567",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := tt.key
+			if key == "" {
+				key = "balance"
+			}
+			builder := NewResponseBuilder(key).SetLanguage(tt.language).SetParam("amount", tt.amount)
+			response, err := config.BuildResponse(builder)
+			if err != nil {
+				t.Fatalf("BuildResponse failed: %v", err)
+			}
+			if response.Message != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, response.Message)
+			}
+		})
+	}
+}
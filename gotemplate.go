@@ -0,0 +1,190 @@
+package goresponse
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// textTemplateKey identifies a parsed text/template by message key and language
+type textTemplateKey struct {
+	key      string
+	language string
+}
+
+// textTemplateEntry is a parsed text/template with the source it was parsed from
+// Executions use clones from the pool, so the locale-aware functions of a request are bound to a clone
+// that no other request is using
+type textTemplateEntry struct {
+	source   string
+	template *template.Template
+	clones   sync.Pool
+}
+
+// textTemplateCache caches parsed text/template messages per key and language
+// Each ResponseConfig has its own cache, so configs with the same keys do not share templates
+type textTemplateCache struct {
+	mu      sync.RWMutex
+	entries map[textTemplateKey]*textTemplateEntry
+}
+
+// textTemplateCacheMu guards the creation of the text/template cache of configs
+var textTemplateCacheMu sync.Mutex
+
+// textTemplateCache returns the cache of parsed text/template messages of the config, creating it on first use
+func (c *ResponseConfig) textTemplateCache() *textTemplateCache {
+	textTemplateCacheMu.Lock()
+	defer textTemplateCacheMu.Unlock()
+	if c.textTemplates == nil {
+		c.textTemplates = &textTemplateCache{entries: make(map[textTemplateKey]*textTemplateEntry)}
+	}
+	return c.textTemplates
+}
+
+// get returns the parsed template for a key and language, parsing and caching it if needed
+// A cached template is reused only if it was parsed from the same source
+func (tc *textTemplateCache) get(key, language, source string) (*textTemplateEntry, error) {
+	cacheKey := textTemplateKey{key: key, language: language}
+
+	tc.mu.RLock()
+	entry, exists := tc.entries[cacheKey]
+	tc.mu.RUnlock()
+	if exists && entry.source == source {
+		return entry, nil
+	}
+
+	parsed, err := template.New(key).Funcs(templateFuncs(nil)).Parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	entry = &textTemplateEntry{source: source, template: parsed}
+	tc.mu.Lock()
+	tc.entries[cacheKey] = entry
+	tc.mu.Unlock()
+	return entry, nil
+}
+
+// invalidate removes the parsed templates of a key in every language
+func (tc *textTemplateCache) invalidate(key string) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	for cacheKey := range tc.entries {
+		if cacheKey.key == key {
+			delete(tc.entries, cacheKey)
+		}
+	}
+}
+
+// execute renders a message as text/template with params as data
+func (tc *textTemplateCache) execute(key, message string, params map[string]any, f *Formatter) (string, error) {
+	entry, err := tc.get(key, f.language(), message)
+	if err != nil {
+		return "", err
+	}
+
+	// Clones are made once and reused, the parsed template itself is never executed
+	clone, _ := entry.clones.Get().(*template.Template)
+	if clone == nil {
+		if clone, err = entry.template.Clone(); err != nil {
+			return "", err
+		}
+	}
+	defer entry.clones.Put(clone)
+
+	var sb strings.Builder
+	if err := clone.Funcs(templateFuncs(f)).Execute(&sb, params); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// templateFuncs returns the locale-aware functions available in text/template messages
+//
+//	format      any value rendered like a $param
+//	number      number with up to three fraction digits
+//	decimal     number with a fixed number of fraction digits
+//	percent     ratio rendered as a percentage
+//	currency    amount and ISO 4217 code
+//	date, time  time.Time with an optional style (short, medium, long, full)
+//	datetime    time.Time with medium date and short time
+//	relative    time.Time or time.Duration rendered as "3 minutes ago"
+//	duration    time.Duration rendered as "2 hours"
+//	list, orList  slice joined as a conjunction or disjunction
+//	plural, ordinal  CLDR plural or ordinal category of a number
+//	lang        language of the request
+func templateFuncs(f *Formatter) template.FuncMap {
+	return template.FuncMap{
+		"format": f.Format,
+		"number": func(value any) string {
+			if n, ok := icuNumber(value); ok {
+				return f.FormatNumber(n)
+			}
+			return f.Format(value)
+		},
+		"decimal": func(value any, digits int) string {
+			if n, ok := icuNumber(value); ok {
+				return f.FormatDecimal(n, digits)
+			}
+			return f.Format(value)
+		},
+		"percent": func(value any) string {
+			if n, ok := icuNumber(value); ok {
+				return f.FormatPercent(n)
+			}
+			return f.Format(value)
+		},
+		"currency": func(value any, code string) string {
+			if n, ok := icuNumber(value); ok {
+				return f.FormatCurrency(n, code)
+			}
+			return f.Format(value)
+		},
+		"date": func(t time.Time, style ...string) string {
+			return f.FormatDateTime(t, icuDateTimeStyle(firstString(style)), StyleNone)
+		},
+		"time": func(t time.Time, style ...string) string {
+			timeStyle := icuDateTimeStyle(firstString(style))
+			if timeStyle == StyleDefault {
+				timeStyle = StyleShort
+			}
+			return f.FormatDateTime(t, StyleNone, timeStyle)
+		},
+		"datetime": func(t time.Time) string {
+			return f.FormatDateTime(t, StyleDefault, StyleDefault)
+		},
+		"relative": func(value any) (string, error) {
+			switch v := value.(type) {
+			case time.Time:
+				return f.FormatRelative(v), nil
+			case time.Duration:
+				return f.FormatRelativeDuration(v), nil
+			}
+			return "", fmt.Errorf("relative expects time.Time or time.Duration, got %T", value)
+		},
+		"duration": f.FormatDuration,
+		"list": func(items any) string {
+			return f.Format(AndList(items))
+		},
+		"orList": func(items any) string {
+			return f.Format(OrList(items))
+		},
+		"plural": func(count any) string {
+			return PluralCategory(f.language(), count)
+		},
+		"ordinal": func(count any) string {
+			return OrdinalCategory(f.language(), count)
+		},
+		"lang": f.language,
+	}
+}
+
+// firstString returns the first string of an optional argument list
+func firstString(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,225 @@
+package goresponse
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+// TestExecuteTextTemplate tests text/template messages and their locale-aware functions
+func TestExecuteTextTemplate(t *testing.T) {
+	now := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		language string
+		message  string
+		params   map[string]any
+		expected string
+	}{
+		{
+			name:     "Range over failed items",
+			language: "en",
+			message:  "Failed:{{range .items}} {{.}};{{end}}",
+			params:   map[string]any{"items": []string{"a.csv", "b.csv"}},
+			expected: "Failed: a.csv; b.csv;",
+		},
+		{
+			name:     "Conditional",
+			language: "en",
+			message:  "{{if .retry}}Retrying{{else}}Giving up{{end}}",
+			params:   map[string]any{"retry": false},
+			expected: "Giving up",
+		},
+		{name: "Format", language: "de", message: "{{format .n}}", params: map[string]any{"n": 1234.5}, expected: "1.234,5"},
+		{name: "Number", language: "id", message: "{{number .n}}", params: map[string]any{"n": 1500}, expected: "1.500"},
+		{name: "Decimal", language: "en", message: "{{decimal .n 2}}", params: map[string]any{"n": 3}, expected: "3.00"},
+		{name: "Percent", language: "en", message: "{{percent .n}}", params: map[string]any{"n": 0.5}, expected: "50%"},
+		{name: "Currency", language: "en", message: "{{currency .n \"USD\"}}", params: map[string]any{"n": 12.5}, expected: "$12.50"},
+		{name: "Date", language: "id", message: "{{date .t \"long\"}}", params: map[string]any{"t": now}, expected: "5 Maret 2024"},
+		{name: "Time", language: "de", message: "{{time .t}}", params: map[string]any{"t": now}, expected: "12:00"},
+		{name: "Relative", language: "en", message: "{{relative .t}}", params: map[string]any{"t": now.Add(-3 * time.Minute)}, expected: "3 minutes ago"},
+		{name: "Duration", language: "en", message: "{{duration .d}}", params: map[string]any{"d": 2 * time.Hour}, expected: "2 hours"},
+		{name: "List", language: "id", message: "{{list .fields}}", params: map[string]any{"fields": []string{"nama", "email", "telepon"}}, expected: "nama, email, dan telepon"},
+		{name: "Or list", language: "en", message: "{{orList .fields}}", params: map[string]any{"fields": []string{"a", "b"}}, expected: "a or b"},
+		{
+			name:     "Plural",
+			language: "en",
+			message:  "{{.count}} {{if eq (plural .count) \"one\"}}file{{else}}files{{end}}",
+			params:   map[string]any{"count": 1},
+			expected: "1 file",
+		},
+		{name: "Ordinal", language: "en", message: "{{ordinal .n}}", params: map[string]any{"n": 22}, expected: "two"},
+		{name: "Language", language: "fr", message: "{{lang}}", expected: "fr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter := NewFormatter(tt.language).WithNow(now)
+			result, err := (&ResponseConfig{}).textTemplateCache().execute("test_"+tt.name, tt.message, tt.params, formatter)
+			if err != nil {
+				t.Fatalf("execute failed: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestTextTemplateCache tests caching and invalidation of parsed text/template messages
+func TestTextTemplateCache(t *testing.T) {
+	cache := (&ResponseConfig{}).textTemplateCache()
+
+	first, err := cache.get("cached", "en", "Hello {{.name}}")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	second, _ := cache.get("cached", "en", "Hello {{.name}}")
+	if first != second {
+		t.Error("Expected the parsed template to be reused")
+	}
+
+	other, _ := cache.get("cached", "id", "Halo {{.name}}")
+	if other == first {
+		t.Error("Expected a separate template per language")
+	}
+
+	changed, _ := cache.get("cached", "en", "Hi {{.name}}")
+	if changed == first {
+		t.Error("Expected a changed source to be parsed again")
+	}
+
+	t.Run("Clones are reused", func(t *testing.T) {
+		entry, _ := cache.get("reused", "en", "{{.n}}")
+		for range 3 {
+			if _, err := cache.execute("reused", "{{.n}}", map[string]any{"n": 1}, NewFormatter("en")); err != nil {
+				t.Fatalf("execute failed: %v", err)
+			}
+		}
+		if clone, _ := entry.clones.Get().(*template.Template); clone == nil || clone == entry.template {
+			t.Error("Expected an executed clone to be kept for reuse")
+		}
+	})
+
+	t.Run("Remove invalidates", func(t *testing.T) {
+		config := &ResponseConfig{textTemplates: cache}
+		config.RemoveMessageTemplate("cached")
+		cache.mu.RLock()
+		defer cache.mu.RUnlock()
+		for key := range cache.entries {
+			if key.key == "cached" {
+				t.Errorf("Expected removing the template to invalidate its cache entries, %v remains", key)
+			}
+		}
+	})
+}
+
+// TestTextTemplateCachePerConfig tests that configs with the same keys render their own templates
+func TestTextTemplateCachePerConfig(t *testing.T) {
+	filename := "text_template_config.json"
+	content := `{"default_language": "en", "message_templates": {"report": {"key": "report", "syntax": "template", "template": "{{len .items}} failed"}}}`
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	defer os.Remove(filename)
+
+	loaded, err := LoadConfig(ConfigSource{Method: "file", Path: filename})
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	other := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"report": {Key: "report", Syntax: SyntaxTemplate, Template: "{{len .items}} succeeded"},
+		},
+		DefaultLanguage: "en",
+	}
+
+	build := func(config *ResponseConfig) string {
+		response, err := config.BuildResponse(NewResponseBuilder("report").SetParam("items", []int{1, 2}))
+		if err != nil {
+			t.Fatalf("BuildResponse failed: %v", err)
+		}
+		return response.Message
+	}
+
+	if message := build(loaded); message != "2 failed" {
+		t.Errorf("Expected '2 failed', got %q", message)
+	}
+	if message := build(other); message != "2 succeeded" {
+		t.Errorf("Expected '2 succeeded', got %q", message)
+	}
+
+	if _, err := LoadConfig(ConfigSource{Method: "file", Path: filename}); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	other.textTemplates.mu.RLock()
+	remaining := len(other.textTemplates.entries)
+	other.textTemplates.mu.RUnlock()
+	if remaining != 1 {
+		t.Errorf("Expected loading another config to keep cached templates, %d remain", remaining)
+	}
+}
+
+// TestBuildResponseTextTemplate tests message templates with text/template syntax in BuildResponse
+func TestBuildResponseTextTemplate(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"import_failed": {
+				Key:      "import_failed",
+				Syntax:   SyntaxTemplate,
+				Template: "{{len .rows}} rows failed:{{range .rows}} row {{.}}{{end}}",
+				Translations: map[string]string{
+					"id": "{{len .rows}} baris gagal: {{list .rows}}",
+				},
+				GroupIntegers: true,
+			},
+			"broken": {
+				Key:      "broken",
+				Syntax:   SyntaxTemplate,
+				Template: "{{if .x}}unclosed",
+			},
+			"exec_error": {
+				Key:      "exec_error",
+				Syntax:   SyntaxTemplate,
+				Template: "{{relative .x}}",
+			},
+		},
+		DefaultLanguage: "en",
+	}
+
+	tests := []struct {
+		name          string
+		key           string
+		language      string
+		params        map[string]any
+		expected      string
+		errorContains string
+	}{
+		{name: "Default template", key: "import_failed", language: "en", params: map[string]any{"rows": []int{3, 7}}, expected: "2 rows failed: row 3 row 7"},
+		{name: "Translation", key: "import_failed", language: "id", params: map[string]any{"rows": []int{3, 7, 1200}}, expected: "3 baris gagal: 3, 7, dan 1.200"},
+		{name: "Parse error", key: "broken", language: "en", errorContains: "failed to render message template broken"},
+		{name: "Execution error", key: "exec_error", language: "en", params: map[string]any{"x": "soon"}, errorContains: "relative expects time.Time or time.Duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewResponseBuilder(tt.key).SetLanguage(tt.language).SetParams(tt.params)
+			response, err := config.BuildResponse(builder)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("Expected error containing '%s', got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildResponse failed: %v", err)
+			}
+			if response.Message != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, response.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package goresponse
+
+import (
+	"context"
+	"errors"
+	"maps"
+)
+
+// ProtocolGraphQL is the protocol of GraphQL errors, used as the CodeMappings key of extension codes
+const ProtocolGraphQL = "graphql"
+
+// GraphQLLocation is a position in the GraphQL document associated with an error
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLError represents an error in the "errors" array of a GraphQL response
+type GraphQLError struct {
+	Message    string            `json:"message"`              // Localized message
+	Locations  []GraphQLLocation `json:"locations,omitempty"`  // Positions in the GraphQL document
+	Path       []any             `json:"path,omitempty"`       // Path of the response field (field names and list indices)
+	Extensions map[string]any    `json:"extensions,omitempty"` // Template key, "graphql" code, meta and field errors
+}
+
+// GraphQLPathError attaches the path and locations of a GraphQL field to an error
+// FormatGraphQLError passes them through to the GraphQL error
+type GraphQLPathError struct {
+	Err       error
+	Path      []any
+	Locations []GraphQLLocation
+}
+
+// Error returns the message of the wrapped error
+func (e *GraphQLPathError) Error() string {
+	if e.Err == nil {
+		return "graphql error"
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error, so ParseResponseBuilderError finds builders through it
+func (e *GraphQLPathError) Unwrap() error {
+	return e.Err
+}
+
+// WithGraphQLPath wraps an error with the path and locations of the GraphQL field that failed
+func WithGraphQLPath(err error, path []any, locations ...GraphQLLocation) error {
+	return &GraphQLPathError{Err: err, Path: path, Locations: locations}
+}
+
+// FormatGraphQLError converts an error into a GraphQL error
+// Errors carrying a ResponseBuilder (see ParseResponseBuilderError) are built with protocol "graphql"
+// and the language of the builder or ctx; extensions hold the template "key", the "code" mapped for "graphql",
+// Meta entries and rendered field errors under "errors"
+// Other errors, and builders that cannot be built, use the internal error template so internal messages do not leak;
+// the build error is returned so the caller can log it
+// Path and locations are taken from GraphQLPathError in the error chain
+func (c *ResponseConfig) FormatGraphQLError(ctx context.Context, err error) (GraphQLError, error) {
+	var graphqlError GraphQLError
+	var pathError *GraphQLPathError
+	if errors.As(err, &pathError) {
+		graphqlError.Path = pathError.Path
+		graphqlError.Locations = pathError.Locations
+	}
+
+	language := GetLanguage(ctx)
+	var buildErr error
+	if rb, ok := ParseResponseBuilderError(err); ok {
+		builder := *rb
+		if builder.Language == "" {
+			builder.Language = language
+		}
+		builder.Protocol = ProtocolGraphQL
+
+		var response *Response
+		if response, buildErr = c.BuildResponse(&builder); buildErr == nil {
+			graphqlError.Message = response.Message
+			graphqlError.Extensions = c.graphqlExtensions(builder.MessageKey, response)
+			return graphqlError, nil
+		}
+		language = builder.Language
+	}
+
+	key := c.InternalErrorTemplate
+	if key == "" {
+		key = DefaultInternalErrorTemplate
+	}
+	fallback := NewResponseBuilder(key).SetLanguage(language).SetProtocol(ProtocolGraphQL).SetError(err)
+	if response, fallbackErr := c.BuildResponse(fallback); fallbackErr == nil {
+		graphqlError.Message = response.Message
+		graphqlError.Extensions = c.graphqlExtensions(key, response)
+	} else {
+		graphqlError.Message = "Internal Server Error"
+	}
+	return graphqlError, buildErr
+}
+
+// graphqlExtensions returns the extensions of a response built from the key template
+// key and code take precedence over Meta entries of the same name
+func (c *ResponseConfig) graphqlExtensions(key string, response *Response) map[string]any {
+	extensions := make(map[string]any, len(response.Meta)+3)
+	maps.Copy(extensions, response.Meta)
+	if len(response.FieldErrors) > 0 {
+		extensions["errors"] = fieldErrorObjects(response.FieldErrors)
+	}
+	extensions["key"] = key
+	if template, exists := c.GetMessageTemplate(key); exists {
+		if code, exists := template.CodeMappings[ProtocolGraphQL]; exists {
+			extensions["code"] = code
+		}
+	}
+	return extensions
+}
+
+// FormatGraphQLErrors converts errors into the "errors" array of a GraphQL response
+// Nil errors are skipped and errors joined with errors.Join are expanded into one entry each
+// The build errors of all entries are returned joined
+func (c *ResponseConfig) FormatGraphQLErrors(ctx context.Context, errs ...error) ([]GraphQLError, error) {
+	var graphqlErrors []GraphQLError
+	var buildErrs []error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			nested, buildErr := c.FormatGraphQLErrors(ctx, joined.Unwrap()...)
+			graphqlErrors = append(graphqlErrors, nested...)
+			buildErrs = append(buildErrs, buildErr)
+			continue
+		}
+		graphqlError, buildErr := c.FormatGraphQLError(ctx, err)
+		graphqlErrors = append(graphqlErrors, graphqlError)
+		buildErrs = append(buildErrs, buildErr)
+	}
+	return graphqlErrors, errors.Join(buildErrs...)
+}
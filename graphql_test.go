@@ -0,0 +1,179 @@
+package goresponse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestFormatGraphQLError tests converting errors to GraphQL errors
+func TestFormatGraphQLError(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"user_not_found": {
+				Key:          "user_not_found",
+				Template:     "User $id not found",
+				Translations: map[string]string{"id": "Pengguna $id tidak ditemukan"},
+				CodeMappings: map[string]int{"http": 404, "graphql": 40401},
+			},
+			"invalid_input": {
+				Key:          "invalid_input",
+				Template:     "Invalid input",
+				CodeMappings: map[string]int{"http": 400},
+			},
+			"required": {
+				Key:      "required",
+				Template: "$field is required",
+			},
+			"internal_error": {
+				Key:          "internal_error",
+				Template:     "Something went wrong",
+				Translations: map[string]string{"id": "Terjadi kesalahan"},
+			},
+		},
+		DefaultLanguage: "en",
+	}
+	ctx := WithLanguage(context.Background(), "id")
+	notFound := NewResponseBuilder("user_not_found").SetParam("id", 7).SetMeta("retryable", false).SetMeta("key", "ignored")
+
+	tests := []struct {
+		name          string
+		err           error
+		expected      string
+		expectErr     bool
+		errorContains string
+	}{
+		{
+			name:     "Builder with context language",
+			err:      notFound,
+			expected: `{"message":"Pengguna 7 tidak ditemukan","extensions":{"code":40401,"key":"user_not_found","retryable":false}}`,
+		},
+		{
+			name:     "Wrapped builder with path and locations",
+			err:      WithGraphQLPath(fmt.Errorf("resolver: %w", notFound), []any{"users", 0, "profile"}, GraphQLLocation{Line: 3, Column: 5}),
+			expected: `{"message":"Pengguna 7 tidak ditemukan","locations":[{"line":3,"column":5}],"path":["users",0,"profile"],"extensions":{"code":40401,"key":"user_not_found","retryable":false}}`,
+		},
+		{
+			name:     "Builder language kept",
+			err:      NewResponseBuilder("user_not_found").SetParam("id", 1).SetLanguage("en"),
+			expected: `{"message":"User 1 not found","extensions":{"code":40401,"key":"user_not_found"}}`,
+		},
+		{
+			name:     "Field errors without graphql code",
+			err:      NewResponseBuilder("invalid_input").AddFieldError("input.email", "required", map[string]any{"field": "email"}),
+			expected: `{"message":"Invalid input","extensions":{"errors":[{"field":"input.email","code":"required","message":"email is required"}],"key":"invalid_input"}}`,
+		},
+		{
+			name:     "Plain error uses internal error template",
+			err:      WithGraphQLPath(errors.New("database is down"), []any{"users"}),
+			expected: `{"message":"Terjadi kesalahan","path":["users"],"extensions":{"key":"internal_error"}}`,
+		},
+		{
+			name:          "Failed build uses internal error template",
+			err:           NewResponseBuilder("missing"),
+			expected:      `{"message":"Terjadi kesalahan","extensions":{"key":"internal_error"}}`,
+			expectErr:     true,
+			errorContains: "message template not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graphqlError, err := config.FormatGraphQLError(ctx, tt.err)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("Expected error %v, got %v", tt.expectErr, err)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing '%s', got %v", tt.errorContains, err)
+			}
+			data, err := json.Marshal(graphqlError)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+
+	t.Run("Builder is not modified", func(t *testing.T) {
+		builder := NewResponseBuilder("user_not_found").SetParam("id", 1)
+		if _, err := config.FormatGraphQLError(ctx, builder); err != nil {
+			t.Fatalf("FormatGraphQLError failed: %v", err)
+		}
+		if builder.Language != "" || builder.Protocol != "" {
+			t.Errorf("Expected builder to keep its language and protocol, got %q and %q", builder.Language, builder.Protocol)
+		}
+	})
+
+	t.Run("Without internal error template", func(t *testing.T) {
+		graphqlError, err := (&ResponseConfig{DefaultLanguage: "en"}).FormatGraphQLError(context.Background(), errors.New("boom"))
+		if err != nil {
+			t.Fatalf("FormatGraphQLError failed: %v", err)
+		}
+		if graphqlError.Message != "Internal Server Error" || graphqlError.Extensions != nil {
+			t.Errorf("Unexpected GraphQL error %+v", graphqlError)
+		}
+	})
+}
+
+// TestGraphQLPathError tests wrapping errors with GraphQL positions
+func TestGraphQLPathError(t *testing.T) {
+	inner := errors.New("inner")
+	err := WithGraphQLPath(inner, []any{"a"})
+	if err.Error() != "inner" || !errors.Is(err, inner) {
+		t.Errorf("Expected wrapped error, got %v", err)
+	}
+	if (&GraphQLPathError{}).Error() != "graphql error" {
+		t.Error("Expected default message for nil error")
+	}
+}
+
+// TestFormatGraphQLErrors tests aggregating errors into the errors array
+func TestFormatGraphQLErrors(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"user_not_found": {
+				Key:          "user_not_found",
+				Template:     "User $id not found",
+				CodeMappings: map[string]int{"graphql": 40401},
+			},
+			"internal_error": {
+				Key:      "internal_error",
+				Template: "Something went wrong",
+			},
+		},
+		DefaultLanguage: "en",
+	}
+
+	graphqlErrors, err := config.FormatGraphQLErrors(context.Background(),
+		WithGraphQLPath(NewResponseBuilder("user_not_found").SetParam("id", 1), []any{"a"}),
+		nil,
+		errors.Join(
+			WithGraphQLPath(NewResponseBuilder("user_not_found").SetParam("id", 2), []any{"b"}),
+			NewResponseBuilder("missing"),
+		),
+	)
+	if err == nil || !strings.Contains(err.Error(), "message template not found") {
+		t.Errorf("Expected joined build error, got %v", err)
+	}
+
+	data, _ := json.Marshal(map[string]any{"errors": graphqlErrors})
+	expected := `{"errors":[` +
+		`{"message":"User 1 not found","path":["a"],"extensions":{"code":40401,"key":"user_not_found"}},` +
+		`{"message":"User 2 not found","path":["b"],"extensions":{"code":40401,"key":"user_not_found"}},` +
+		`{"message":"Something went wrong","extensions":{"key":"internal_error"}}]}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	t.Run("No errors", func(t *testing.T) {
+		graphqlErrors, err := config.FormatGraphQLErrors(context.Background(), nil)
+		if graphqlErrors != nil || err != nil {
+			t.Errorf("Expected no errors, got %v and %v", graphqlErrors, err)
+		}
+	})
+}
@@ -0,0 +1,548 @@
+package goresponse
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// icuNode is a part of a parsed ICU message
+type icuNode interface{}
+
+// icuMessage is a parsed ICU message or sub-message
+type icuMessage []icuNode
+
+// icuText is literal text
+type icuText string
+
+// icuPound is the "#" placeholder inside plural and selectordinal sub-messages
+type icuPound struct{}
+
+// icuArgument is a simple argument ({name}, {name, number}, {name, date, short})
+type icuArgument struct {
+	Name  string
+	Type  string
+	Style string
+}
+
+// icuChoice is a plural, selectordinal or select argument
+type icuChoice struct {
+	Name    string
+	Type    string
+	Offset  float64
+	Options map[string]icuMessage
+}
+
+// icuCache caches parsed messages keyed by their source text
+var icuCache sync.Map
+
+// FormatMessage renders an ICU MessageFormat message with the given params
+// Supported arguments: {name}, number (integer, percent, currency and skeletons such as ::currency/EUR or ::.00),
+// date and time (short, medium, long, full), plural (with offset and =N), selectordinal and select
+// Arguments missing from params are rendered as "{name}"
+func (f *Formatter) FormatMessage(message string, params map[string]any) (string, error) {
+	parsed, err := parseICUCached(message)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	f.renderICU(&sb, parsed, params, nil)
+	return sb.String(), nil
+}
+
+// parseICUCached parses an ICU message, reusing a previously parsed result
+func parseICUCached(message string) (icuMessage, error) {
+	if cached, ok := icuCache.Load(message); ok {
+		return cached.(icuMessage), nil
+	}
+
+	parsed, err := parseICU(message)
+	if err != nil {
+		return nil, err
+	}
+	icuCache.Store(message, parsed)
+	return parsed, nil
+}
+
+// parseICU parses an ICU MessageFormat message
+func parseICU(message string) (icuMessage, error) {
+	p := &icuParser{input: []rune(message)}
+	parsed, err := p.parseMessage(0, false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.input) {
+		return nil, p.errorf("unmatched '}'")
+	}
+	return parsed, nil
+}
+
+// icuParser is a recursive descent parser for ICU MessageFormat
+type icuParser struct {
+	input []rune
+	pos   int
+}
+
+// errorf returns a parse error annotated with the current position
+func (p *icuParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid ICU message at position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// parseMessage parses text and arguments until the closing brace of a sub-message or the end of input
+func (p *icuParser) parseMessage(depth int, inPlural bool) (icuMessage, error) {
+	var message icuMessage
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() > 0 {
+			message = append(message, icuText(text.String()))
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.input) {
+		ch := p.input[p.pos]
+		switch {
+		case ch == '\'':
+			p.parseQuoted(&text, inPlural)
+		case ch == '{':
+			flush()
+			node, err := p.parseArgument(depth+1, inPlural)
+			if err != nil {
+				return nil, err
+			}
+			message = append(message, node)
+		case ch == '}':
+			if depth == 0 {
+				return nil, p.errorf("unmatched '}'")
+			}
+			flush()
+			return message, nil
+		case ch == '#' && inPlural:
+			flush()
+			message = append(message, icuPound{})
+			p.pos++
+		default:
+			text.WriteRune(ch)
+			p.pos++
+		}
+	}
+
+	if depth > 0 {
+		return nil, p.errorf("unclosed '{'")
+	}
+	flush()
+	return message, nil
+}
+
+// parseQuoted handles apostrophes: a doubled apostrophe is a literal apostrophe and an apostrophe
+// before a syntax character starts quoted literal text; any other apostrophe is literal
+func (p *icuParser) parseQuoted(text *strings.Builder, inPlural bool) {
+	p.pos++
+	if p.pos >= len(p.input) {
+		text.WriteRune('\'')
+		return
+	}
+
+	next := p.input[p.pos]
+	if next == '\'' {
+		text.WriteRune('\'')
+		p.pos++
+		return
+	}
+	if next != '{' && next != '}' && next != '|' && !(next == '#' && inPlural) {
+		text.WriteRune('\'')
+		return
+	}
+
+	for p.pos < len(p.input) {
+		ch := p.input[p.pos]
+		p.pos++
+		if ch != '\'' {
+			text.WriteRune(ch)
+			continue
+		}
+		if p.pos < len(p.input) && p.input[p.pos] == '\'' {
+			text.WriteRune('\'')
+			p.pos++
+			continue
+		}
+		return
+	}
+}
+
+// parseArgument parses an argument starting at an opening brace
+func (p *icuParser) parseArgument(depth int, inPlural bool) (icuNode, error) {
+	p.pos++ // skip '{'
+	name := p.parseWord()
+	if name == "" {
+		return nil, p.errorf("missing argument name")
+	}
+
+	if p.consume('}') {
+		return icuArgument{Name: name}, nil
+	}
+	if !p.consume(',') {
+		return nil, p.errorf("expected ',' or '}' after argument %s", name)
+	}
+
+	argType := p.parseWord()
+	switch argType {
+	case "plural", "selectordinal", "select":
+		if !p.consume(',') {
+			return nil, p.errorf("expected ',' after %s type of argument %s", argType, name)
+		}
+		return p.parseChoice(name, argType, depth, inPlural)
+	case "":
+		return nil, p.errorf("missing type for argument %s", name)
+	}
+
+	if p.consume('}') {
+		return icuArgument{Name: name, Type: argType}, nil
+	}
+	if !p.consume(',') {
+		return nil, p.errorf("expected ',' or '}' after type of argument %s", name)
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] != '}' {
+		p.pos++
+	}
+	if p.pos >= len(p.input) {
+		return nil, p.errorf("unclosed argument %s", name)
+	}
+	style := strings.TrimSpace(string(p.input[start:p.pos]))
+	p.pos++
+	return icuArgument{Name: name, Type: argType, Style: style}, nil
+}
+
+// parseChoice parses the options of a plural, selectordinal or select argument
+func (p *icuParser) parseChoice(name, argType string, depth int, inPlural bool) (icuNode, error) {
+	choice := icuChoice{Name: name, Type: argType, Options: make(map[string]icuMessage)}
+	subPlural := inPlural || argType != "select"
+
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.input) {
+			return nil, p.errorf("unclosed %s argument %s", argType, name)
+		}
+		if p.input[p.pos] == '}' {
+			p.pos++
+			break
+		}
+
+		selector := p.parseSelector()
+		if selector == "" {
+			return nil, p.errorf("missing selector in %s argument %s", argType, name)
+		}
+
+		if argType == "plural" && strings.HasPrefix(selector, "offset:") {
+			offset, err := strconv.ParseFloat(strings.TrimPrefix(selector, "offset:"), 64)
+			if err != nil || len(choice.Options) > 0 {
+				return nil, p.errorf("invalid offset in plural argument %s", name)
+			}
+			choice.Offset = offset
+			continue
+		}
+
+		if !p.consume('{') {
+			return nil, p.errorf("expected '{' after selector %s in argument %s", selector, name)
+		}
+		message, err := p.parseMessage(depth+1, subPlural)
+		if err != nil {
+			return nil, err
+		}
+		p.pos++ // skip '}'
+		choice.Options[selector] = message
+	}
+
+	if _, exists := choice.Options[PluralOther]; !exists {
+		return nil, p.errorf("missing 'other' option in %s argument %s", argType, name)
+	}
+	return choice, nil
+}
+
+// parseWord reads an argument name or type surrounded by optional whitespace
+func (p *icuParser) parseWord() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.input) {
+		ch := p.input[p.pos]
+		if unicode.IsSpace(ch) || ch == ',' || ch == '{' || ch == '}' {
+			break
+		}
+		p.pos++
+	}
+	word := string(p.input[start:p.pos])
+	p.skipSpace()
+	return word
+}
+
+// parseSelector reads a selector such as "one", "=0", "male" or "offset:1"
+func (p *icuParser) parseSelector() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		ch := p.input[p.pos]
+		if unicode.IsSpace(ch) || ch == '{' || ch == '}' {
+			break
+		}
+		p.pos++
+	}
+	selector := string(p.input[start:p.pos])
+	p.skipSpace()
+	return selector
+}
+
+// consume skips whitespace and the given character if it is next
+func (p *icuParser) consume(ch rune) bool {
+	p.skipSpace()
+	if p.pos < len(p.input) && p.input[p.pos] == ch {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// skipSpace skips whitespace
+func (p *icuParser) skipSpace() {
+	for p.pos < len(p.input) && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+// icuPoundValue is the number rendered for "#" inside a plural sub-message
+type icuPoundValue struct {
+	name  string
+	value any
+	ok    bool
+}
+
+// renderICU writes a parsed message to the builder
+func (f *Formatter) renderICU(sb *strings.Builder, message icuMessage, params map[string]any, pound *icuPoundValue) {
+	for _, node := range message {
+		switch n := node.(type) {
+		case icuText:
+			sb.WriteString(string(n))
+		case icuPound:
+			switch {
+			case pound == nil:
+				sb.WriteString("#")
+			case !pound.ok:
+				sb.WriteString("{" + pound.name + "}")
+			default:
+				sb.WriteString(f.formatGrouped(pound.value))
+			}
+		case icuArgument:
+			value, exists := params[n.Name]
+			if !exists {
+				sb.WriteString("{" + n.Name + "}")
+				continue
+			}
+			sb.WriteString(f.formatICUArgument(n, value))
+		case icuChoice:
+			option, nextPound := f.selectICUOption(n, params)
+			if nextPound == nil {
+				nextPound = pound
+			}
+			f.renderICU(sb, option, params, nextPound)
+		}
+	}
+}
+
+// selectICUOption picks the sub-message of a choice argument and the value its "#" renders
+func (f *Formatter) selectICUOption(choice icuChoice, params map[string]any) (icuMessage, *icuPoundValue) {
+	value, exists := params[choice.Name]
+	if choice.Type == "select" {
+		if exists {
+			if option, ok := choice.Options[fmt.Sprint(value)]; ok {
+				return option, nil
+			}
+		}
+		return choice.Options[PluralOther], nil
+	}
+
+	pound := &icuPoundValue{name: choice.Name}
+	n, isNumber := icuNumber(value)
+	if !exists || !isNumber {
+		return choice.Options[PluralOther], pound
+	}
+
+	// Exact matches compare the value before the offset is applied
+	for selector, option := range choice.Options {
+		if exact, ok := strings.CutPrefix(selector, "="); ok {
+			if target, err := strconv.ParseFloat(exact, 64); err == nil && target == n {
+				pound.value, pound.ok = icuPoundNumber(value, n, choice.Offset), true
+				return option, pound
+			}
+		}
+	}
+
+	pound.value, pound.ok = icuPoundNumber(value, n, choice.Offset), true
+	category := PluralCategory(f.language(), pound.value)
+	if choice.Type == "selectordinal" {
+		category = OrdinalCategory(f.language(), pound.value)
+	}
+	if option, ok := choice.Options[category]; ok {
+		return option, pound
+	}
+	return choice.Options[PluralOther], pound
+}
+
+// icuPoundNumber returns the value rendered for "#", keeping the original value when there is no offset
+func icuPoundNumber(value any, n, offset float64) any {
+	if offset == 0 {
+		return value
+	}
+	return n - offset
+}
+
+// formatICUArgument renders a simple argument with its type and style
+func (f *Formatter) formatICUArgument(arg icuArgument, value any) string {
+	switch arg.Type {
+	case "number":
+		return f.formatICUNumber(arg.Style, value)
+	case "date", "time":
+		t, ok := value.(time.Time)
+		if dt, isDateTime := value.(DateTime); isDateTime {
+			t, ok = dt.Time, true
+		}
+		if !ok {
+			return f.Format(value)
+		}
+		style := icuDateTimeStyle(arg.Style)
+		if arg.Type == "date" {
+			return f.FormatDateTime(t, style, StyleNone)
+		}
+		if style == StyleDefault {
+			style = StyleShort
+		}
+		return f.FormatDateTime(t, StyleNone, style)
+	}
+	return f.Format(value)
+}
+
+// formatICUNumber renders a number argument with an ICU style or "::" skeleton
+func (f *Formatter) formatICUNumber(style string, value any) string {
+	n, ok := icuNumber(value)
+	if !ok {
+		return f.Format(value)
+	}
+
+	switch style {
+	case "":
+		return f.formatGrouped(value)
+	case "integer":
+		return f.FormatInt(int64(math.Round(n)))
+	case "percent":
+		return f.FormatPercent(n)
+	case "currency":
+		if money, isMoney := value.(Money); isMoney {
+			return f.Format(money)
+		}
+		return f.Format(value)
+	}
+
+	skeleton, isSkeleton := strings.CutPrefix(style, "::")
+	if !isSkeleton {
+		return f.Format(value)
+	}
+
+	currency, percent := "", false
+	minDigits, maxDigits := -1, -1
+	for _, token := range strings.Fields(skeleton) {
+		switch {
+		case token == "percent" || token == "%":
+			percent = true
+		case strings.HasPrefix(token, "currency/"):
+			currency = strings.TrimPrefix(token, "currency/")
+		case strings.HasPrefix(token, "scale/"):
+			if scale, err := strconv.ParseFloat(strings.TrimPrefix(token, "scale/"), 64); err == nil {
+				n *= scale
+			}
+		case token == "precision-integer":
+			minDigits, maxDigits = 0, 0
+		case strings.HasPrefix(token, "."):
+			fraction := strings.TrimPrefix(token, ".")
+			minDigits = strings.Count(fraction, "0")
+			maxDigits = len(fraction)
+		}
+	}
+
+	switch {
+	case currency != "":
+		if money, isMoney := value.(Money); isMoney {
+			n = money.Amount
+		}
+		return f.FormatCurrency(n, currency)
+	case percent:
+		// Skeleton percent does not scale the value, "scale/100" does
+		if maxDigits < 0 {
+			return f.FormatPercent(n / 100)
+		}
+		number := f.formatFloat(n, minDigits, maxDigits)
+		if f == nil || f.Locale == nil {
+			return number + "%"
+		}
+		return strings.Replace(f.Locale.PercentPattern, "#", number, 1)
+	case maxDigits >= 0:
+		return f.formatFloat(n, minDigits, maxDigits)
+	}
+	return f.FormatNumber(n)
+}
+
+// icuDateTimeStyle converts an ICU date or time style name to a DateTimeStyle
+func icuDateTimeStyle(style string) DateTimeStyle {
+	switch style {
+	case "short":
+		return StyleShort
+	case "medium":
+		return StyleMedium
+	case "long":
+		return StyleLong
+	case "full":
+		return StyleFull
+	}
+	return StyleDefault
+}
+
+// icuNumber converts a numeric param value to a float
+func icuNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case Decimal:
+		return v.Value, true
+	case Money:
+		return v.Amount, true
+	case Percent:
+		return float64(v), true
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return n, err == nil
+	case nil:
+		return 0, false
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// language returns the formatter's language, empty for a nil Formatter
+func (f *Formatter) language() string {
+	if f == nil {
+		return ""
+	}
+	return f.Language
+}
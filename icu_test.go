@@ -0,0 +1,216 @@
+package goresponse
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFormatMessage tests ICU MessageFormat rendering
+func TestFormatMessage(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+	files := "{count, plural, =0 {No files} one {# file} other {# files}}"
+
+	tests := []struct {
+		name     string
+		language string
+		message  string
+		params   map[string]any
+		expected string
+	}{
+		{name: "Simple argument", language: "en", message: "Hello {name}", params: map[string]any{"name": "Budi"}, expected: "Hello Budi"},
+		{name: "Missing argument", language: "en", message: "Hello {name}", params: nil, expected: "Hello {name}"},
+		{name: "Plural exact", language: "en", message: files, params: map[string]any{"count": 0}, expected: "No files"},
+		{name: "Plural one", language: "en", message: files, params: map[string]any{"count": 1}, expected: "1 file"},
+		{name: "Plural other grouped", language: "en", message: files, params: map[string]any{"count": 1200}, expected: "1,200 files"},
+		{
+			name:     "Plural Russian",
+			language: "ru",
+			message:  "{n, plural, one {# файл} few {# файла} many {# файлов} other {# файла}}",
+			params:   map[string]any{"n": 22},
+			expected: "22 файла",
+		},
+		{
+			name:     "Plural offset",
+			language: "en",
+			message:  "{guests, plural, offset:1 =0 {Nobody} =1 {{host}} one {{host} and # other} other {{host} and # others}}",
+			params:   map[string]any{"guests": 3, "host": "Ana"},
+			expected: "Ana and 2 others",
+		},
+		{
+			name:     "Plural offset exact",
+			language: "en",
+			message:  "{guests, plural, offset:1 =0 {Nobody} =1 {{host}} one {{host} and # other} other {{host} and # others}}",
+			params:   map[string]any{"guests": 1, "host": "Ana"},
+			expected: "Ana",
+		},
+		{
+			name:     "Select",
+			language: "de",
+			message:  "{gender, select, male {Er hat} female {Sie hat} other {Sie haben}} geantwortet",
+			params:   map[string]any{"gender": "female"},
+			expected: "Sie hat geantwortet",
+		},
+		{
+			name:     "Select other",
+			language: "de",
+			message:  "{gender, select, male {Er} female {Sie} other {Die Person}}",
+			params:   map[string]any{"gender": "unknown"},
+			expected: "Die Person",
+		},
+		{
+			name:     "Select ordinal",
+			language: "en",
+			message:  "{place, selectordinal, one {#st} two {#nd} few {#rd} other {#th}} place",
+			params:   map[string]any{"place": 23},
+			expected: "23rd place",
+		},
+		{
+			name:     "Select ordinal teen",
+			language: "en",
+			message:  "{place, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}",
+			params:   map[string]any{"place": 12},
+			expected: "12th",
+		},
+		{
+			name:     "Nested select and plural",
+			language: "en",
+			message:  "{gender, select, female {{count, plural, one {She has # item} other {She has # items}}} other {{count, plural, one {They have # item} other {They have # items}}}}",
+			params:   map[string]any{"gender": "female", "count": 2},
+			expected: "She has 2 items",
+		},
+		{
+			name:     "Pound in nested select",
+			language: "en",
+			message:  "{count, plural, one {{kind, select, photo {# photo} other {# file}}} other {{kind, select, photo {# photos} other {# files}}}}",
+			params:   map[string]any{"count": 4, "kind": "photo"},
+			expected: "4 photos",
+		},
+		{name: "Number", language: "de", message: "{n, number}", params: map[string]any{"n": 1234.5}, expected: "1.234,5"},
+		{name: "Number integer", language: "en", message: "{n, number, integer}", params: map[string]any{"n": 2.6}, expected: "3"},
+		{name: "Number percent", language: "en", message: "{n, number, percent}", params: map[string]any{"n": 0.25}, expected: "25%"},
+		{name: "Number skeleton currency", language: "id", message: "{n, number, ::currency/IDR}", params: map[string]any{"n": 15000}, expected: "Rp15.000,00"},
+		{name: "Number skeleton precision", language: "en", message: "{n, number, ::.00}", params: map[string]any{"n": 3.5}, expected: "3.50"},
+		{name: "Number skeleton percent", language: "en", message: "{n, number, ::percent}", params: map[string]any{"n": 50}, expected: "50%"},
+		{name: "Number skeleton percent scale", language: "en", message: "{n, number, ::percent scale/100}", params: map[string]any{"n": 0.5}, expected: "50%"},
+		{name: "Date", language: "en", message: "Due {d, date}", params: map[string]any{"d": date}, expected: "Due Mar 5, 2024"},
+		{name: "Date long", language: "id", message: "{d, date, long}", params: map[string]any{"d": date}, expected: "5 Maret 2024"},
+		{name: "Time", language: "en", message: "at {d, time}", params: map[string]any{"d": date}, expected: "at 2:30 PM"},
+		{name: "Quoted braces", language: "en", message: "Use '{name}' literally, it''s {name}", params: map[string]any{"name": "x"}, expected: "Use {name} literally, it's x"},
+		{name: "Plain apostrophe", language: "en", message: "It's {name}", params: map[string]any{"name": "ok"}, expected: "It's ok"},
+		{name: "Quoted pound", language: "en", message: "{n, plural, other {'#' #}}", params: map[string]any{"n": 3}, expected: "# 3"},
+		{name: "Pound outside plural", language: "en", message: "Issue #{n}", params: map[string]any{"n": 7}, expected: "Issue #7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewFormatter(tt.language).FormatMessage(tt.message, tt.params)
+			if err != nil {
+				t.Fatalf("FormatMessage failed: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestFormatMessageErrors tests ICU MessageFormat parse errors
+func TestFormatMessageErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		message       string
+		errorContains string
+	}{
+		{name: "Unclosed argument", message: "Hello {name", errorContains: "expected ',' or '}'"},
+		{name: "Unmatched brace", message: "Hello }", errorContains: "unmatched '}'"},
+		{name: "Missing name", message: "Hello {}", errorContains: "missing argument name"},
+		{name: "Missing other", message: "{n, plural, one {# file}}", errorContains: "missing 'other' option"},
+		{name: "Unclosed sub-message", message: "{n, plural, other {# files}", errorContains: "unclosed"},
+		{name: "Missing sub-message", message: "{n, select, male}", errorContains: "expected '{' after selector male"},
+		{name: "Invalid offset", message: "{n, plural, offset:x other {#}}", errorContains: "invalid offset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFormatter("en").FormatMessage(tt.message, nil)
+			if err == nil {
+				t.Fatal("Expected error but got none")
+			}
+			if !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error to contain '%s', got: %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+// TestBuildResponseICU tests message templates with ICU syntax in BuildResponse
+func TestBuildResponseICU(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"files_deleted": {
+				Key:      "files_deleted",
+				Template: "{count, plural, one {# file deleted} other {# files deleted}}",
+				Syntax:   SyntaxICU,
+				Translations: map[string]string{
+					"id": "{count, plural, other {# berkas dihapus}}",
+				},
+				CodeMappings: map[string]int{"http": 200},
+			},
+			"legacy": {
+				Key:      "legacy",
+				Template: "Hello $name {name}",
+			},
+			"broken": {
+				Key:      "broken",
+				Template: "{count, plural, one {# file}}",
+				Syntax:   SyntaxICU,
+			},
+			"unknown": {
+				Key:      "unknown",
+				Template: "Hello",
+				Syntax:   "mustache",
+			},
+		},
+		DefaultLanguage: "en",
+	}
+	config.AddMessageTemplate(NewMessageTemplateBuilder("welcome").
+		WithTemplate("Welcome {name}").
+		WithSyntax(SyntaxICU).
+		Build())
+
+	tests := []struct {
+		name          string
+		key           string
+		language      string
+		params        map[string]any
+		expected      string
+		errorContains string
+	}{
+		{name: "ICU English", key: "files_deleted", language: "en", params: map[string]any{"count": 1}, expected: "1 file deleted"},
+		{name: "ICU Indonesian", key: "files_deleted", language: "id", params: map[string]any{"count": 1500}, expected: "1.500 berkas dihapus"},
+		{name: "Builder syntax", key: "welcome", language: "en", params: map[string]any{"name": "Budi"}, expected: "Welcome Budi"},
+		{name: "Default syntax unchanged", key: "legacy", language: "en", params: map[string]any{"name": "Budi"}, expected: "Hello Budi {name}"},
+		{name: "Parse error", key: "broken", language: "en", params: map[string]any{"count": 1}, errorContains: "failed to render message template broken"},
+		{name: "Unsupported syntax", key: "unknown", language: "en", errorContains: "unsupported template syntax"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewResponseBuilder(tt.key).SetLanguage(tt.language).SetParams(tt.params)
+			response, err := config.BuildResponse(builder)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("Expected error containing '%s', got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildResponse failed: %v", err)
+			}
+			if response.Message != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, response.Message)
+			}
+		})
+	}
+}
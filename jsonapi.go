@@ -0,0 +1,214 @@
+package goresponse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MediaTypeJSONAPI is the media type of JSON:API documents
+const MediaTypeJSONAPI = "application/vnd.api+json"
+
+// JSONAPIVersion is the JSON:API version written in the "jsonapi" member
+const JSONAPIVersion = "1.1"
+
+// JSONAPIDocument represents a JSON:API top-level document
+// A document holds either primary data or errors, along with meta and links
+type JSONAPIDocument struct {
+	Data     any            // Primary data (a resource object), written when there are no errors
+	Errors   []JSONAPIError // Error objects of an error response
+	Meta     map[string]any // Non-standard meta information
+	Links    map[string]any // Links related to the primary data
+	Status   int            // HTTP status of the document (not serialized)
+	Language string         // Language of the messages (not serialized)
+}
+
+// JSONAPIError represents a JSON:API error object
+type JSONAPIError struct {
+	ID     string              `json:"id,omitempty"`
+	Status string              `json:"status,omitempty"` // HTTP status code as a string
+	Code   string              `json:"code,omitempty"`   // Application-specific code (the message key)
+	Title  string              `json:"title,omitempty"`  // Short summary of the problem (the template title)
+	Detail string              `json:"detail,omitempty"` // Explanation of this occurrence (the rendered message)
+	Source *JSONAPIErrorSource `json:"source,omitempty"`
+	Meta   map[string]any      `json:"meta,omitempty"`
+}
+
+// JSONAPIErrorSource references the part of the request that caused an error
+type JSONAPIErrorSource struct {
+	Pointer   string `json:"pointer,omitempty"`   // JSON pointer into the request document ("/data/attributes/email")
+	Parameter string `json:"parameter,omitempty"` // Query parameter that caused the error
+	Header    string `json:"header,omitempty"`    // Request header that caused the error
+}
+
+// BuildJSONAPI builds the response and maps it to a JSON:API document
+// Successful responses with "type" and "id" in Data put a resource object in "data", with the other Data entries
+// as its attributes; without them "data" is null and Data is written in the top-level meta
+// Error responses become an "errors" array:
+// one error object per field error, with source.pointer under /data/attributes, or a single error object
+// with Data as its meta when the builder has no field errors
+// The "links" entry of Meta is written as the top-level links member
+func (c *ResponseConfig) BuildJSONAPI(rb *ResponseBuilder) (*JSONAPIDocument, error) {
+	response, err := c.BuildResponse(rb)
+	if err != nil {
+		return nil, err
+	}
+	return c.jsonapiDocument(rb.MessageKey, response, rb.IsBuiltError), nil
+}
+
+// jsonapiDocument maps a response built from the key template to a JSON:API document
+func (c *ResponseConfig) jsonapiDocument(key string, response *Response, isError bool) *JSONAPIDocument {
+	document := &JSONAPIDocument{Language: response.Language}
+	document.Meta, document.Links = jsonapiLinks(response.Meta)
+
+	if !isError {
+		if resource, ok := jsonapiResource(response.Data); ok {
+			document.Data = resource
+		} else if len(response.Data) > 0 {
+			meta := make(map[string]any, len(document.Meta)+len(response.Data))
+			maps.Copy(meta, document.Meta)
+			maps.Copy(meta, response.Data)
+			document.Meta = meta
+		}
+		document.Status = response.httpStatus()
+		return document
+	}
+
+	problem := c.problem(key, response)
+	document.Status = problem.Status
+	if len(response.FieldErrors) == 0 {
+		document.Errors = []JSONAPIError{{
+			Status: strconv.Itoa(problem.Status),
+			Code:   key,
+			Title:  problem.Title,
+			Detail: problem.Detail,
+			Meta:   response.Data,
+		}}
+		return document
+	}
+
+	titleLanguage := response.Language
+	if titleLanguage == "" {
+		titleLanguage = c.GetDefaultLanguage()
+	}
+	for _, fieldError := range response.FieldErrors {
+		template, _ := c.GetMessageTemplate(fieldError.MessageKey)
+
+		// Field templates without an HTTP status use the status of the response
+		status := template.CodeMappings["http"]
+		if status < 100 || status > 599 {
+			status = problem.Status
+		}
+		document.Errors = append(document.Errors, JSONAPIError{
+			Status: strconv.Itoa(status),
+			Code:   fieldError.MessageKey,
+			Title:  c.problemTitle(template, titleLanguage, status),
+			Detail: fieldError.Message,
+			Source: &JSONAPIErrorSource{Pointer: jsonapiPointer(fieldError.Field)},
+		})
+	}
+	return document
+}
+
+// jsonapiResource converts response data to a resource object when it has a "type" string and an "id"
+// The id is written as a string and the other entries become the attributes
+func jsonapiResource(data map[string]any) (Object, bool) {
+	resourceType, _ := data["type"].(string)
+	id, hasID := data["id"]
+	if resourceType == "" || !hasID || id == nil {
+		return nil, false
+	}
+
+	resource := Object{{Key: "type", Value: resourceType}, {Key: "id", Value: fmt.Sprint(id)}}
+	if len(data) > 2 {
+		attributes := make(map[string]any, len(data)-2)
+		for key, value := range data {
+			if key != "type" && key != "id" {
+				attributes[key] = value
+			}
+		}
+		resource = append(resource, Field{Key: "attributes", Value: attributes})
+	}
+	return resource, true
+}
+
+// jsonapiLinks separates the "links" object from meta, returning the remaining meta and the links
+func jsonapiLinks(meta map[string]any) (map[string]any, map[string]any) {
+	var links map[string]any
+	switch l := meta["links"].(type) {
+	case map[string]any:
+		links = l
+	case map[string]string:
+		links = make(map[string]any, len(l))
+		for key, value := range l {
+			links[key] = value
+		}
+	default:
+		return meta, nil
+	}
+
+	rest := make(map[string]any, len(meta)-1)
+	for key, value := range meta {
+		if key != "links" {
+			rest[key] = value
+		}
+	}
+	return rest, links
+}
+
+// jsonapiPointer returns the JSON pointer of a field in a JSON:API request document
+// Field names are placed under /data/attributes with dots separating nested members, pointers are kept as is
+func jsonapiPointer(field string) string {
+	if strings.HasPrefix(field, "/") {
+		return field
+	}
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+	var sb strings.Builder
+	sb.WriteString("/data/attributes")
+	for _, segment := range strings.Split(field, ".") {
+		sb.WriteString("/" + escaper.Replace(segment))
+	}
+	return sb.String()
+}
+
+// Members returns the document as it is encoded: jsonapi, data or errors, meta and links
+func (d *JSONAPIDocument) Members() Object {
+	members := Object{{Key: "jsonapi", Value: Object{{Key: "version", Value: JSONAPIVersion}}}}
+	if len(d.Errors) > 0 {
+		members = append(members, Field{Key: "errors", Value: d.Errors})
+	} else {
+		members = append(members, Field{Key: "data", Value: d.Data})
+	}
+	if len(d.Meta) > 0 {
+		members = append(members, Field{Key: "meta", Value: d.Meta})
+	}
+	if len(d.Links) > 0 {
+		members = append(members, Field{Key: "links", Value: d.Links})
+	}
+	return members
+}
+
+// MarshalJSON writes the document as a JSON object
+func (d JSONAPIDocument) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Members())
+}
+
+// WriteTo encodes the document as application/vnd.api+json to w
+// When w is an http.ResponseWriter the status is set from Status, along with Content-Type and Content-Language
+func (d *JSONAPIDocument) WriteTo(w io.Writer) (int64, error) {
+	var body bytes.Buffer
+	if err := (JSONEncoder{}).Encode(&body, d.Members()); err != nil {
+		return 0, err
+	}
+	status := d.Status
+	if status < 100 || status > 599 {
+		status = http.StatusInternalServerError
+	}
+	writeHTTPHeader(w, MediaTypeJSONAPI, d.Language, status)
+	return body.WriteTo(w)
+}
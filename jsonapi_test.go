@@ -0,0 +1,221 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestBuildJSONAPI tests mapping responses to JSON:API documents
+func TestBuildJSONAPI(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"user_found": {
+				Key:          "user_found",
+				Template:     "User found",
+				CodeMappings: map[string]int{"http": 200},
+			},
+			"validation_failed": {
+				Key:          "validation_failed",
+				Template:     "Validation failed",
+				CodeMappings: map[string]int{"http": 422},
+				Title:        "Invalid attributes",
+			},
+			"required": {
+				Key:               "required",
+				Template:          "$field is required",
+				Translations:      map[string]string{"id": "$field wajib diisi"},
+				Title:             "Missing attribute",
+				TitleTranslations: map[string]string{"id": "Atribut kosong"},
+			},
+			"conflict": {
+				Key:          "conflict",
+				Template:     "$field is already taken",
+				CodeMappings: map[string]int{"http": 409},
+			},
+		},
+		DefaultLanguage: "en",
+	}
+
+	tests := []struct {
+		name     string
+		builder  *ResponseBuilder
+		status   int
+		expected string
+	}{
+		{
+			name: "Primary data with links",
+			builder: NewResponseBuilder("user_found").SetProtocol("http").
+				SetData("type", "users").SetData("id", "1").
+				SetMeta("links", map[string]string{"self": "/users/1"}).SetMeta("total", 1),
+			status:   200,
+			expected: `{"jsonapi":{"version":"1.1"},"data":{"type":"users","id":"1"},"meta":{"total":1},"links":{"self":"/users/1"}}`,
+		},
+		{
+			name: "Resource attributes",
+			builder: NewResponseBuilder("user_found").SetProtocol("http").
+				SetData("type", "users").SetData("id", 7).SetData("name", "Budi").SetData("email", "budi@example.com"),
+			status:   200,
+			expected: `{"jsonapi":{"version":"1.1"},"data":{"type":"users","id":"7","attributes":{"email":"budi@example.com","name":"Budi"}}}`,
+		},
+		{
+			name:     "Data without type and id is meta",
+			builder:  NewResponseBuilder("user_found").SetProtocol("http").SetData("count", 2).SetMeta("total", 1).SetMeta("count", 0),
+			status:   200,
+			expected: `{"jsonapi":{"version":"1.1"},"data":null,"meta":{"count":2,"total":1}}`,
+		},
+		{
+			name:     "Empty primary data",
+			builder:  NewResponseBuilder("user_found").SetProtocol("http"),
+			status:   200,
+			expected: `{"jsonapi":{"version":"1.1"},"data":null}`,
+		},
+		{
+			name:     "Single error",
+			builder:  NewResponseBuilder("validation_failed").SetProtocol("http").SetError(errors.New("invalid")).SetData("attempt", 2),
+			status:   422,
+			expected: `{"jsonapi":{"version":"1.1"},"errors":[{"status":"422","code":"validation_failed","title":"Invalid attributes","detail":"Validation failed","meta":{"attempt":2}}]}`,
+		},
+		{
+			name: "Field errors",
+			builder: NewResponseBuilder("validation_failed").SetProtocol("http").
+				AddFieldError("email", "required", map[string]any{"field": "Email"}).
+				AddFieldError("profile.user/name", "conflict", map[string]any{"field": "Name"}),
+			status: 422,
+			expected: `{"jsonapi":{"version":"1.1"},"errors":[` +
+				`{"status":"422","code":"required","title":"Missing attribute","detail":"Email is required","source":{"pointer":"/data/attributes/email"}},` +
+				`{"status":"409","code":"conflict","title":"Conflict","detail":"Name is already taken","source":{"pointer":"/data/attributes/profile/user~1name"}}]}`,
+		},
+		{
+			name: "Localized field errors",
+			builder: NewResponseBuilder("validation_failed").SetProtocol("http").SetLanguage("id").
+				AddFieldError("/data/relationships/author", "required", map[string]any{"field": "Penulis"}),
+			status:   422,
+			expected: `{"jsonapi":{"version":"1.1"},"errors":[{"status":"422","code":"required","title":"Atribut kosong","detail":"Penulis wajib diisi","source":{"pointer":"/data/relationships/author"}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, err := config.BuildJSONAPI(tt.builder)
+			if err != nil {
+				t.Fatalf("BuildJSONAPI failed: %v", err)
+			}
+			if document.Status != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, document.Status)
+			}
+			data, err := json.Marshal(document)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+
+	t.Run("Missing field template", func(t *testing.T) {
+		_, err := config.BuildJSONAPI(NewResponseBuilder("validation_failed").AddFieldError("email", "missing", nil))
+		if err == nil || !strings.Contains(err.Error(), "field error email") {
+			t.Errorf("Expected field error, got %v", err)
+		}
+	})
+}
+
+// TestJSONAPIPointer tests JSON pointers of field names
+func TestJSONAPIPointer(t *testing.T) {
+	tests := []struct {
+		field    string
+		expected string
+	}{
+		{field: "title", expected: "/data/attributes/title"},
+		{field: "address.city", expected: "/data/attributes/address/city"},
+		{field: "a~b", expected: "/data/attributes/a~0b"},
+		{field: "/data/relationships/tags/0", expected: "/data/relationships/tags/0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			if pointer := jsonapiPointer(tt.field); pointer != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, pointer)
+			}
+		})
+	}
+}
+
+// TestWriteHTTPJSONAPI tests negotiation of JSON:API documents
+func TestWriteHTTPJSONAPI(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"user_found": {
+				Key:          "user_found",
+				Template:     "User found",
+				CodeMappings: map[string]int{"http": 200},
+			},
+			"validation_failed": {
+				Key:          "validation_failed",
+				Template:     "Validation failed",
+				CodeMappings: map[string]int{"http": 422},
+			},
+			"required": {
+				Key:      "required",
+				Template: "$field is required",
+				Title:    "Missing attribute",
+			},
+		},
+		DefaultLanguage: "en",
+	}
+
+	tests := []struct {
+		name        string
+		builder     *ResponseBuilder
+		status      int
+		body        string
+		expectError bool
+	}{
+		{
+			name:    "Document",
+			builder: NewResponseBuilder("user_found").SetData("type", "users").SetData("id", "1"),
+			status:  200,
+			body:    `{"jsonapi":{"version":"1.1"},"data":{"type":"users","id":"1"}}`,
+		},
+		{
+			name:    "Errors",
+			builder: NewResponseBuilder("validation_failed").AddFieldError("email", "required", map[string]any{"field": "Email"}),
+			status:  422,
+			body:    `{"jsonapi":{"version":"1.1"},"errors":[{"status":"422","code":"required","title":"Missing attribute","detail":"Email is required","source":{"pointer":"/data/attributes/email"}}]}`,
+		},
+		{
+			name:        "Failed build",
+			builder:     NewResponseBuilder("validation_failed").AddFieldError("email", "missing", nil),
+			status:      500,
+			body:        `{"jsonapi":{"version":"1.1"},"errors":[{"status":"500","title":"Internal Server Error","detail":"Internal Server Error"}]}`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users", nil)
+			req.Header.Set("Accept", MediaTypeJSONAPI)
+			rec := httptest.NewRecorder()
+
+			err := WriteHTTP(rec, req, config, tt.builder)
+			if (err != nil) != tt.expectError {
+				t.Errorf("Expected error %v, got %v", tt.expectError, err)
+			}
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if rec.Header().Get("Content-Type") != MediaTypeJSONAPI {
+				t.Errorf("Expected Content-Type %s, got %s", MediaTypeJSONAPI, rec.Header().Get("Content-Type"))
+			}
+			if body := strings.TrimSpace(rec.Body.String()); body != tt.body {
+				t.Errorf("Expected body %s, got %s", tt.body, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package goresponse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+)
+
+// ProtocolJSONRPC is the protocol of JSON-RPC 2.0 responses, used as the CodeMappings key of error codes
+const ProtocolJSONRPC = "jsonrpc"
+
+// JSONRPCVersion is the version written in the "jsonrpc" member
+const JSONRPCVersion = "2.0"
+
+// JSON-RPC 2.0 reserved error codes
+const (
+	JSONRPCParseError     = -32700 // Invalid JSON was received
+	JSONRPCInvalidRequest = -32600 // The JSON sent is not a valid request object
+	JSONRPCMethodNotFound = -32601 // The method does not exist or is not available
+	JSONRPCInvalidParams  = -32602 // Invalid method parameters
+	JSONRPCInternalError  = -32603 // Internal JSON-RPC error
+)
+
+// JSONRPCIDKey is the context key for storing the id of the JSON-RPC request
+const JSONRPCIDKey ResponseContextKey = "goresponse-jsonrpc-id"
+
+// WithJSONRPCID adds the id of the JSON-RPC request (string, number or nil) to the context
+func WithJSONRPCID(ctx context.Context, id any) context.Context {
+	return context.WithValue(ctx, JSONRPCIDKey, id)
+}
+
+// GetJSONRPCIDFromContext extracts the id of the JSON-RPC request from context
+// Returns the id and a boolean indicating if it was found
+func GetJSONRPCIDFromContext(ctx context.Context) (any, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	id := ctx.Value(JSONRPCIDKey)
+	return id, id != nil
+}
+
+// JSONRPCIDExtractor returns the id of the JSON-RPC request from a context
+type JSONRPCIDExtractor func(ctx context.Context) (any, bool)
+
+// JSONRPCOption configures BuildJSONRPC
+type JSONRPCOption func(*jsonrpcOptions)
+
+// jsonrpcOptions holds the settings of BuildJSONRPC
+type jsonrpcOptions struct {
+	idExtractor JSONRPCIDExtractor
+}
+
+// WithJSONRPCIDExtractor sets the function reading the request id from the builder context
+// GetJSONRPCIDFromContext is used by default
+func WithJSONRPCIDExtractor(extractor JSONRPCIDExtractor) JSONRPCOption {
+	return func(o *jsonrpcOptions) {
+		o.idExtractor = extractor
+	}
+}
+
+// JSONRPCResponse represents a JSON-RPC 2.0 response object
+type JSONRPCResponse struct {
+	ID       any           // Id of the request, null when unknown
+	Result   any           // Result of a successful call (the response data)
+	Error    *JSONRPCError // Error of a failed call
+	Language string        // Language of the message (not serialized)
+}
+
+// JSONRPCError represents a JSON-RPC 2.0 error object
+type JSONRPCError struct {
+	Code    int    `json:"code"`           // Error code
+	Message string `json:"message"`        // Rendered message
+	Data    any    `json:"data,omitempty"` // Additional information (the response data and field errors)
+}
+
+// BuildJSONRPC builds the response and maps it to a JSON-RPC 2.0 response object
+// Successful responses put Data in "result", error responses (SetError, AddFieldError) become an error object
+// whose code is the "jsonrpc" code mapping of the template, defaulting to the reserved codes:
+// invalid params for field errors and HTTP 400/422, method not found for HTTP 501 and internal error otherwise
+// The id is read from the builder context with the id extractor
+func (c *ResponseConfig) BuildJSONRPC(rb *ResponseBuilder, opts ...JSONRPCOption) (*JSONRPCResponse, error) {
+	options := jsonrpcOptions{idExtractor: GetJSONRPCIDFromContext}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// The protocol is set on a copy, so the builder can still be written with other adapters
+	if rb != nil && rb.Protocol == "" {
+		builder := *rb
+		builder.Protocol = ProtocolJSONRPC
+		rb = &builder
+	}
+	response, err := c.BuildResponse(rb)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonrpc := &JSONRPCResponse{Language: response.Language}
+	if options.idExtractor != nil && rb.Context != nil {
+		jsonrpc.ID, _ = options.idExtractor(rb.Context)
+	}
+	if !rb.IsBuiltError {
+		jsonrpc.Result = response.Data
+		return jsonrpc, nil
+	}
+
+	template, _ := c.GetMessageTemplate(rb.MessageKey)
+	jsonrpc.Error = &JSONRPCError{
+		Code:    jsonrpcErrorCode(template, response),
+		Message: response.Message,
+	}
+	if len(response.Data) > 0 || len(response.FieldErrors) > 0 {
+		data := make(map[string]any, len(response.Data)+1)
+		maps.Copy(data, response.Data)
+		if len(response.FieldErrors) > 0 {
+			data["errors"] = fieldErrorObjects(response.FieldErrors)
+		}
+		jsonrpc.Error.Data = data
+	}
+	return jsonrpc, nil
+}
+
+// jsonrpcErrorCode returns the "jsonrpc" code mapping of a template, or the reserved code matching the response
+func jsonrpcErrorCode(template *MessageTemplate, response *Response) int {
+	if code, exists := template.CodeMappings[ProtocolJSONRPC]; exists && code != 0 {
+		return code
+	}
+	if len(response.FieldErrors) > 0 {
+		return JSONRPCInvalidParams
+	}
+	switch template.CodeMappings["http"] {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return JSONRPCInvalidParams
+	case http.StatusNotImplemented:
+		return JSONRPCMethodNotFound
+	}
+	return JSONRPCInternalError
+}
+
+// Members returns the response object as it is encoded: jsonrpc, id and result or error
+func (r *JSONRPCResponse) Members() Object {
+	members := Object{{Key: "jsonrpc", Value: JSONRPCVersion}, {Key: "id", Value: r.ID}}
+	if r.Error != nil {
+		return append(members, Field{Key: "error", Value: r.Error})
+	}
+	return append(members, Field{Key: "result", Value: r.Result})
+}
+
+// MarshalJSON writes the response object as JSON
+func (r JSONRPCResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Members())
+}
+
+// WriteTo encodes the response object as JSON to w
+// When w is an http.ResponseWriter the status is 200, as JSON-RPC reports errors in the body,
+// along with Content-Type and Content-Language
+func (r *JSONRPCResponse) WriteTo(w io.Writer) (int64, error) {
+	var body bytes.Buffer
+	if err := (JSONEncoder{}).Encode(&body, r.Members()); err != nil {
+		return 0, err
+	}
+	writeHTTPHeader(w, ContentTypeJSON, r.Language, http.StatusOK)
+	return body.WriteTo(w)
+}
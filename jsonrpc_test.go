@@ -0,0 +1,184 @@
+package goresponse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestBuildJSONRPC tests mapping responses to JSON-RPC response objects
+func TestBuildJSONRPC(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"sum_ok": {
+				Key:      "sum_ok",
+				Template: "Sum calculated",
+			},
+			"insufficient_funds": {
+				Key:          "insufficient_funds",
+				Template:     "Insufficient funds",
+				Translations: map[string]string{"id": "Saldo tidak cukup"},
+				CodeMappings: map[string]int{"http": 402, "jsonrpc": -32001},
+			},
+			"bad_request": {
+				Key:          "bad_request",
+				Template:     "Bad request",
+				CodeMappings: map[string]int{"http": 400},
+			},
+			"not_implemented": {
+				Key:          "not_implemented",
+				Template:     "Method $method is not available",
+				CodeMappings: map[string]int{"http": 501},
+			},
+			"failure": {
+				Key:      "failure",
+				Template: "Something went wrong",
+			},
+			"required": {
+				Key:      "required",
+				Template: "$field is required",
+			},
+		},
+		DefaultLanguage: "en",
+	}
+	ctx := WithJSONRPCID(context.Background(), 7)
+
+	tests := []struct {
+		name     string
+		builder  *ResponseBuilder
+		expected string
+	}{
+		{
+			name:     "Result",
+			builder:  NewResponseBuilder("sum_ok").WithContext(ctx).SetData("sum", 19),
+			expected: `{"jsonrpc":"2.0","id":7,"result":{"sum":19}}`,
+		},
+		{
+			name:     "Empty result",
+			builder:  NewResponseBuilder("sum_ok").WithContext(WithJSONRPCID(context.Background(), "abc")),
+			expected: `{"jsonrpc":"2.0","id":"abc","result":null}`,
+		},
+		{
+			name:     "Mapped error code",
+			builder:  NewResponseBuilder("insufficient_funds").WithContext(WithLanguage(ctx, "id")).SetError(errors.New("balance")).SetData("balance", 5),
+			expected: `{"jsonrpc":"2.0","id":7,"error":{"code":-32001,"message":"Saldo tidak cukup","data":{"balance":5}}}`,
+		},
+		{
+			name:     "Invalid params from HTTP 400",
+			builder:  NewResponseBuilder("bad_request").WithContext(ctx).SetError(errors.New("bad")),
+			expected: `{"jsonrpc":"2.0","id":7,"error":{"code":-32602,"message":"Bad request"}}`,
+		},
+		{
+			name:     "Method not found from HTTP 501",
+			builder:  NewResponseBuilder("not_implemented").WithContext(ctx).SetParam("method", "divide").SetError(errors.New("missing")),
+			expected: `{"jsonrpc":"2.0","id":7,"error":{"code":-32601,"message":"Method divide is not available"}}`,
+		},
+		{
+			name:     "Internal error",
+			builder:  NewResponseBuilder("failure").SetError(errors.New("boom")),
+			expected: `{"jsonrpc":"2.0","id":null,"error":{"code":-32603,"message":"Something went wrong"}}`,
+		},
+		{
+			name:     "Field errors",
+			builder:  NewResponseBuilder("failure").WithContext(ctx).AddFieldError("a", "required", map[string]any{"field": "a"}),
+			expected: `{"jsonrpc":"2.0","id":7,"error":{"code":-32602,"message":"Something went wrong","data":{"errors":[{"field":"a","code":"required","message":"a is required"}]}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := config.BuildJSONRPC(tt.builder)
+			if err != nil {
+				t.Fatalf("BuildJSONRPC failed: %v", err)
+			}
+			data, err := json.Marshal(response)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+
+	t.Run("Builder is not changed", func(t *testing.T) {
+		builder := NewResponseBuilder("insufficient_funds").SetError(errors.New("balance"))
+		response, err := config.BuildJSONRPC(builder)
+		if err != nil {
+			t.Fatalf("BuildJSONRPC failed: %v", err)
+		}
+		if response.Error == nil || response.Error.Code != -32001 {
+			t.Errorf("Expected jsonrpc code -32001, got %+v", response.Error)
+		}
+		if builder.Protocol != "" {
+			t.Errorf("Expected builder protocol to stay empty, got %s", builder.Protocol)
+		}
+	})
+
+	t.Run("Custom id extractor", func(t *testing.T) {
+		type requestKey struct{}
+		extractor := func(ctx context.Context) (any, bool) {
+			id, ok := ctx.Value(requestKey{}).(string)
+			return id, ok
+		}
+		builder := NewResponseBuilder("sum_ok").WithContext(context.WithValue(context.Background(), requestKey{}, "req-9"))
+		response, err := config.BuildJSONRPC(builder, WithJSONRPCIDExtractor(extractor))
+		if err != nil {
+			t.Fatalf("BuildJSONRPC failed: %v", err)
+		}
+		if response.ID != "req-9" {
+			t.Errorf("Expected id req-9, got %v", response.ID)
+		}
+	})
+
+	t.Run("Missing template", func(t *testing.T) {
+		if _, err := config.BuildJSONRPC(NewResponseBuilder("missing")); err == nil {
+			t.Error("Expected error for missing template")
+		}
+	})
+}
+
+// TestGetJSONRPCIDFromContext tests reading the request id from context
+func TestGetJSONRPCIDFromContext(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		expectedID any
+		expectedOK bool
+	}{
+		{name: "Number id", ctx: WithJSONRPCID(context.Background(), 1), expectedID: 1, expectedOK: true},
+		{name: "String id", ctx: WithJSONRPCID(context.Background(), "a"), expectedID: "a", expectedOK: true},
+		{name: "Null id", ctx: WithJSONRPCID(context.Background(), nil), expectedID: nil, expectedOK: false},
+		{name: "Missing id", ctx: context.Background(), expectedID: nil, expectedOK: false},
+		{name: "Nil context", ctx: nil, expectedID: nil, expectedOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := GetJSONRPCIDFromContext(tt.ctx)
+			if id != tt.expectedID || ok != tt.expectedOK {
+				t.Errorf("Expected (%v, %v), got (%v, %v)", tt.expectedID, tt.expectedOK, id, ok)
+			}
+		})
+	}
+}
+
+// TestJSONRPCResponseWriteTo tests writing response objects over HTTP
+func TestJSONRPCResponseWriteTo(t *testing.T) {
+	rec := httptest.NewRecorder()
+	response := &JSONRPCResponse{ID: 1, Error: &JSONRPCError{Code: JSONRPCInternalError, Message: "Kesalahan"}, Language: "id"}
+	if _, err := response.WriteTo(rec); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if rec.Code != 200 {
+		t.Errorf("Expected status 200, got %d", rec.Code)
+	}
+	if rec.Header().Get("Content-Type") != ContentTypeJSON || rec.Header().Get("Content-Language") != "id" {
+		t.Errorf("Unexpected headers %v", rec.Header())
+	}
+	if rec.Body.String() != `{"jsonrpc":"2.0","id":1,"error":{"code":-32603,"message":"Kesalahan"}}`+"\n" {
+		t.Errorf("Unexpected body %q", rec.Body.String())
+	}
+}
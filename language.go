@@ -0,0 +1,222 @@
+package goresponse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LanguageTag is a parsed BCP 47 language tag such as zh-Hant-TW or de-CH-1996
+// Subtags are stored in their canonical case
+type LanguageTag struct {
+	Language   string   // Primary language with any extended language subtags, lowercase (zh, zh-yue)
+	Script     string   // Script subtag, title case (Hant)
+	Region     string   // Region subtag, uppercase letters or three digits (TW, 419)
+	Variants   []string // Variant subtags, lowercase (1996)
+	Extensions []string // Extension and private use sequences, lowercase (u-ca-buddhist, x-foo)
+}
+
+// scriptParents maps Chinese region tags to the script their translations are written in
+// zh-TW falls back to zh-Hant before zh, zh-SG falls back to zh-Hans
+var scriptParents = map[string]string{
+	"zh-tw": "zh-Hant",
+	"zh-hk": "zh-Hant",
+	"zh-mo": "zh-Hant",
+	"zh-cn": "zh-Hans",
+	"zh-sg": "zh-Hans",
+}
+
+// ParseLanguageTag parses a BCP 47 language tag
+// Underscores are accepted as separators (pt_BR) and subtags are normalized to their canonical case
+func ParseLanguageTag(tag string) (LanguageTag, error) {
+	var lt LanguageTag
+	subtags := strings.Split(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	for i, subtag := range subtags {
+		if subtag == "" || len(subtag) > 8 || !isAlphanumeric(subtag) {
+			return LanguageTag{}, fmt.Errorf("invalid language tag %q: malformed subtag %q", tag, subtag)
+		}
+		subtags[i] = strings.ToLower(subtag)
+	}
+
+	// Primary language: 2-3 letters (with up to three extended subtags) or 5-8 letters
+	i := 0
+	if n := len(subtags[0]); !isAlpha(subtags[0]) || n == 4 || n < 2 {
+		return LanguageTag{}, fmt.Errorf("invalid language tag %q: invalid language subtag %q", tag, subtags[0])
+	}
+	lt.Language = subtags[0]
+	i++
+	for extlang := 0; extlang < 3 && len(lt.Language) <= 3 && i < len(subtags) && len(subtags[i]) == 3 && isAlpha(subtags[i]); extlang++ {
+		lt.Language += "-" + subtags[i]
+		i++
+	}
+
+	if i < len(subtags) && len(subtags[i]) == 4 && isAlpha(subtags[i]) {
+		lt.Script = strings.ToUpper(subtags[i][:1]) + subtags[i][1:]
+		i++
+	}
+
+	if i < len(subtags) && ((len(subtags[i]) == 2 && isAlpha(subtags[i])) || (len(subtags[i]) == 3 && isDigits(subtags[i]))) {
+		lt.Region = strings.ToUpper(subtags[i])
+		i++
+	}
+
+	for i < len(subtags) && (len(subtags[i]) >= 5 || (len(subtags[i]) == 4 && isDigits(subtags[i][:1]))) {
+		lt.Variants = append(lt.Variants, subtags[i])
+		i++
+	}
+
+	// Extensions are a singleton followed by subtags of 2-8 characters, private use takes the rest of the tag
+	for i < len(subtags) {
+		singleton := subtags[i]
+		if len(singleton) != 1 {
+			return LanguageTag{}, fmt.Errorf("invalid language tag %q: unexpected subtag %q", tag, singleton)
+		}
+
+		end := i + 1
+		for end < len(subtags) && (singleton == "x" || len(subtags[end]) >= 2) {
+			end++
+		}
+		if end == i+1 {
+			return LanguageTag{}, fmt.Errorf("invalid language tag %q: empty extension %q", tag, singleton)
+		}
+		lt.Extensions = append(lt.Extensions, strings.Join(subtags[i:end], "-"))
+		i = end
+	}
+
+	return lt, nil
+}
+
+// String returns the tag in canonical form
+func (lt LanguageTag) String() string {
+	subtags := []string{lt.Language}
+	if lt.Script != "" {
+		subtags = append(subtags, lt.Script)
+	}
+	if lt.Region != "" {
+		subtags = append(subtags, lt.Region)
+	}
+	subtags = append(subtags, lt.Variants...)
+	subtags = append(subtags, lt.Extensions...)
+	return strings.Join(subtags, "-")
+}
+
+// Parents returns the tag followed by its less specific forms, as in RFC 4647 lookup
+// zh-Hant-TW returns zh-Hant-TW, zh-Hant, zh and zh-TW returns zh-TW, zh-Hant, zh
+func (lt LanguageTag) Parents() []string {
+	var parents []string
+	subtags := strings.Split(lt.String(), "-")
+	for n := len(subtags); n > 0; n-- {
+		// A truncated tag never ends with an extension singleton
+		if len(subtags[n-1]) == 1 {
+			continue
+		}
+		parent := strings.Join(subtags[:n], "-")
+		parents = append(parents, parent)
+
+		if script, exists := scriptParents[strings.ToLower(parent)]; exists {
+			parents = append(parents, script)
+		}
+	}
+	return parents
+}
+
+// LanguageChain returns the languages tried for a requested language, most specific first
+// The chain holds the tag's parents, the fallbacks configured in LanguageFallbacks for each of them,
+// and finally the default language with its parents (ms-MY -> ms -> id -> en)
+func (c *ResponseConfig) LanguageChain(lang string) []string {
+	return c.languageChain(lang, true)
+}
+
+// languageChain returns the language chain of a tag, optionally ending with the default language
+func (c *ResponseConfig) languageChain(lang string, withDefault bool) []string {
+	var chain []string
+	seen := make(map[string]bool)
+
+	var add func(tag string)
+	add = func(tag string) {
+		for _, candidate := range languageParents(tag) {
+			key := strings.ToLower(candidate)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			chain = append(chain, candidate)
+
+			if fallbacks, exists := lookupLanguage(c.LanguageFallbacks, candidate); exists {
+				for _, fallback := range fallbacks {
+					add(fallback)
+				}
+			}
+		}
+	}
+
+	add(lang)
+	if withDefault {
+		add(c.GetDefaultLanguage())
+	}
+	return chain
+}
+
+// languageParents returns the parents of a tag, or the tag alone if it is not valid BCP 47
+func languageParents(tag string) []string {
+	if tag == "" {
+		return nil
+	}
+	parsed, err := ParseLanguageTag(tag)
+	if err != nil {
+		return []string{tag}
+	}
+	return parsed.Parents()
+}
+
+// lookupLanguage finds the value stored for a language tag
+// Keys match case-insensitively and with either "-" or "_" separators (pt-BR, pt_br)
+// An exact key wins; among other matching keys the lowest in byte order is used, so the result is stable
+func lookupLanguage[V any](values map[string]V, tag string) (V, bool) {
+	if value, exists := values[tag]; exists {
+		return value, true
+	}
+
+	var match V
+	matchKey, found := "", false
+	for key, value := range values {
+		if sameLanguage(key, tag) && (!found || key < matchKey) {
+			match, matchKey, found = value, key, true
+		}
+	}
+	return match, found
+}
+
+// sameLanguage reports whether two language tags are equal ignoring case and separator style
+func sameLanguage(a, b string) bool {
+	return strings.EqualFold(strings.ReplaceAll(a, "_", "-"), strings.ReplaceAll(b, "_", "-"))
+}
+
+// isAlpha reports whether s consists of ASCII letters
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if (s[i] < 'a' || s[i] > 'z') && (s[i] < 'A' || s[i] > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// isDigits reports whether s consists of ASCII digits
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isAlphanumeric reports whether s consists of ASCII letters and digits
+func isAlphanumeric(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isAlpha(s[i:i+1]) && !isDigits(s[i:i+1]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,248 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParseLanguageTag tests BCP 47 parsing and canonical casing
+func TestParseLanguageTag(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected LanguageTag
+		str      string
+	}{
+		{tag: "en", expected: LanguageTag{Language: "en"}, str: "en"},
+		{tag: "pt_br", expected: LanguageTag{Language: "pt", Region: "BR"}, str: "pt-BR"},
+		{tag: "ZH-hant-tw", expected: LanguageTag{Language: "zh", Script: "Hant", Region: "TW"}, str: "zh-Hant-TW"},
+		{tag: "es-419", expected: LanguageTag{Language: "es", Region: "419"}, str: "es-419"},
+		{tag: "zh-yue-HK", expected: LanguageTag{Language: "zh-yue", Region: "HK"}, str: "zh-yue-HK"},
+		{tag: "de-CH-1996", expected: LanguageTag{Language: "de", Region: "CH", Variants: []string{"1996"}}, str: "de-CH-1996"},
+		{tag: "sl-rozaj-biske", expected: LanguageTag{Language: "sl", Variants: []string{"rozaj", "biske"}}, str: "sl-rozaj-biske"},
+		{tag: "th-TH-u-ca-buddhist", expected: LanguageTag{Language: "th", Region: "TH", Extensions: []string{"u-ca-buddhist"}}, str: "th-TH-u-ca-buddhist"},
+		{tag: "en-US-x-twain", expected: LanguageTag{Language: "en", Region: "US", Extensions: []string{"x-twain"}}, str: "en-US-x-twain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			parsed, err := ParseLanguageTag(tt.tag)
+			if err != nil {
+				t.Fatalf("ParseLanguageTag failed: %v", err)
+			}
+			if !reflect.DeepEqual(parsed, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, parsed)
+			}
+			if parsed.String() != tt.str {
+				t.Errorf("Expected %q, got %q", tt.str, parsed.String())
+			}
+		})
+	}
+}
+
+// TestParseLanguageTagErrors tests invalid BCP 47 tags
+func TestParseLanguageTagErrors(t *testing.T) {
+	tests := []struct {
+		tag           string
+		errorContains string
+	}{
+		{tag: "", errorContains: "malformed subtag"},
+		{tag: "en--US", errorContains: "malformed subtag"},
+		{tag: "en US", errorContains: "malformed subtag"},
+		{tag: "e", errorContains: "invalid language subtag"},
+		{tag: "12", errorContains: "invalid language subtag"},
+		{tag: "en-US-u", errorContains: "empty extension"},
+		{tag: "en-a-b", errorContains: "empty extension"},
+		{tag: "en-US-ab", errorContains: "unexpected subtag"},
+		{tag: "verylonglanguage", errorContains: "malformed subtag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			_, err := ParseLanguageTag(tt.tag)
+			if err == nil {
+				t.Fatal("Expected error but got none")
+			}
+			if !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error to contain '%s', got: %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+// TestLanguageTagParents tests RFC 4647 truncation of language tags
+func TestLanguageTagParents(t *testing.T) {
+	tests := map[string][]string{
+		"en":                 {"en"},
+		"zh-Hant-TW":         {"zh-Hant-TW", "zh-Hant", "zh"},
+		"zh-TW":              {"zh-TW", "zh-Hant", "zh"},
+		"zh-CN":              {"zh-CN", "zh-Hans", "zh"},
+		"de-CH-1996":         {"de-CH-1996", "de-CH", "de"},
+		"de-DE-u-co-phonebk": {"de-DE-u-co-phonebk", "de-DE-u-co", "de-DE", "de"},
+	}
+
+	for tag, expected := range tests {
+		parsed, err := ParseLanguageTag(tag)
+		if err != nil {
+			t.Fatalf("ParseLanguageTag(%q) failed: %v", tag, err)
+		}
+		if parents := parsed.Parents(); !reflect.DeepEqual(parents, expected) {
+			t.Errorf("%s: expected %v, got %v", tag, expected, parents)
+		}
+	}
+}
+
+// TestLanguageChain tests language chains with configured fallbacks
+func TestLanguageChain(t *testing.T) {
+	config := &ResponseConfig{
+		DefaultLanguage: "en",
+		LanguageFallbacks: map[string][]string{
+			"ms":    {"id"},
+			"pt_br": {"pt-PT"},
+			"a":     {"b"},
+			"id":    {"ms"},
+		},
+	}
+
+	tests := []struct {
+		lang     string
+		expected []string
+	}{
+		{lang: "zh-Hant-TW", expected: []string{"zh-Hant-TW", "zh-Hant", "zh", "en"}},
+		{lang: "ms-MY", expected: []string{"ms-MY", "ms", "id", "en"}},
+		{lang: "pt-BR", expected: []string{"pt-BR", "pt-PT", "pt", "en"}},
+		{lang: "en-GB", expected: []string{"en-GB", "en"}},
+		{lang: "", expected: []string{"en"}},
+		{lang: "not a tag", expected: []string{"not a tag", "en"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			if chain := config.LanguageChain(tt.lang); !reflect.DeepEqual(chain, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, chain)
+			}
+		})
+	}
+}
+
+// TestLanguageFallbackLookups tests that translation lookups follow the language chain
+func TestLanguageFallbackLookups(t *testing.T) {
+	var config ResponseConfig
+	data := `{
+		"default_language": "en",
+		"language_fallbacks": {"ms": ["id"]},
+		"message_templates": {
+			"welcome": {"key": "welcome", "template": "Welcome $name", "translations": {"en": "Hello $name", "zh-Hant": "歡迎 $name", "pt": "Bem-vindo $name"}},
+			"files": {"key": "files", "template": "$count files", "translations": {"id": {"one": "$count berkas", "other": "$count berkas"}}},
+			"saved": {"key": "saved", "template": "Saved"}
+		},
+		"translations": {
+			"id": {"saved": "Tersimpan", "bye": "Sampai jumpa"},
+			"EN": {"bye": "Bye"},
+			"zh_hant": {"saved": "已儲存"}
+		}
+	}`
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatalf("Failed to unmarshal config: %v", err)
+	}
+
+	t.Run("GetTranslation", func(t *testing.T) {
+		tests := []struct {
+			lang     string
+			key      string
+			expected string
+			exists   bool
+		}{
+			{lang: "id-ID", key: "bye", expected: "Sampai jumpa", exists: true},
+			{lang: "ms", key: "bye", expected: "Sampai jumpa", exists: true},
+			{lang: "zh-Hant-TW", key: "saved", expected: "已儲存", exists: true},
+			{lang: "zh-TW", key: "saved", expected: "已儲存", exists: true},
+			{lang: "en-US", key: "bye", expected: "Bye", exists: true},
+			{lang: "fr", key: "bye", exists: false},
+		}
+		for _, tt := range tests {
+			translation, exists := config.GetTranslation(tt.lang, tt.key)
+			if exists != tt.exists || translation != tt.expected {
+				t.Errorf("GetTranslation(%q, %q): expected %q (%v), got %q (%v)", tt.lang, tt.key, tt.expected, tt.exists, translation, exists)
+			}
+		}
+		if translation := config.GetTranslationWithFallback("fr-CA", "bye"); translation != "Bye" {
+			t.Errorf("Expected default language fallback, got %q", translation)
+		}
+	})
+
+	t.Run("GetMessageTemplateTranslation", func(t *testing.T) {
+		tests := map[string]string{
+			"zh-Hant-HK": "歡迎 $name",
+			"pt-BR":      "Bem-vindo $name",
+			"de":         "Hello $name",
+		}
+		for lang, expected := range tests {
+			if translation, _ := config.GetMessageTemplateTranslation("welcome", lang); translation != expected {
+				t.Errorf("%s: expected %q, got %q", lang, expected, translation)
+			}
+		}
+	})
+
+	t.Run("BuildResponse", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			key      string
+			language string
+			params   map[string]any
+			expected string
+		}{
+			{name: "Script parent", key: "welcome", language: "zh-Hant-TW", params: map[string]any{"name": "Li"}, expected: "歡迎 Li"},
+			{name: "Region parent", key: "welcome", language: "pt-BR", params: map[string]any{"name": "Ana"}, expected: "Bem-vindo Ana"},
+			{name: "Default language translation", key: "welcome", language: "fr", params: map[string]any{"name": "Luc"}, expected: "Hello Luc"},
+			{name: "Configured fallback variants", key: "files", language: "ms-MY", params: map[string]any{"count": 2}, expected: "2 berkas"},
+			{name: "Configured fallback config translation", key: "saved", language: "ms", expected: "Tersimpan"},
+			{name: "Case-insensitive key", key: "saved", language: "zh-Hant", expected: "已儲存"},
+			{name: "Template default", key: "saved", language: "fr", expected: "Saved"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				builder := NewResponseBuilder(tt.key).SetLanguage(tt.language).SetParams(tt.params)
+				response, err := config.BuildResponse(builder)
+				if err != nil {
+					t.Fatalf("BuildResponse failed: %v", err)
+				}
+				if response.Message != tt.expected {
+					t.Errorf("Expected %q, got %q", tt.expected, response.Message)
+				}
+				if response.Language != tt.language {
+					t.Errorf("Expected response language %q, got %q", tt.language, response.Language)
+				}
+			})
+		}
+	})
+}
+
+// TestLookupLanguage tests that keys differing only in case or separator resolve deterministically
+func TestLookupLanguage(t *testing.T) {
+	values := map[string]string{"pt_br": "underscore", "pt-BR": "canonical", "PT-br": "mixed", "id": "Indonesian"}
+
+	tests := []struct {
+		tag      string
+		expected string
+		exists   bool
+	}{
+		{tag: "pt_br", expected: "underscore", exists: true},
+		{tag: "PT-br", expected: "mixed", exists: true},
+		{tag: "pt-br", expected: "mixed", exists: true},
+		{tag: "ID", expected: "Indonesian", exists: true},
+		{tag: "pt", exists: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			for range 20 {
+				value, exists := lookupLanguage(values, tt.tag)
+				if exists != tt.exists || value != tt.expected {
+					t.Fatalf("Expected %q (%v), got %q (%v)", tt.expected, tt.exists, value, exists)
+				}
+			}
+		})
+	}
+}
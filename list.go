@@ -0,0 +1,211 @@
+package goresponse
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ListStyle selects how list items are joined
+type ListStyle int
+
+const (
+	// ListAnd joins items as a conjunction ("a, b, and c")
+	ListAnd ListStyle = iota
+	// ListOr joins items as a disjunction ("a, b, or c")
+	ListOr
+)
+
+// List is a slice parameter rendered as a natural-language list with an explicit style
+type List struct {
+	Items any       // Slice or array of items
+	Style ListStyle // Conjunction or disjunction
+}
+
+// AndList creates a List parameter joined as a conjunction
+func AndList(items any) List {
+	return List{Items: items, Style: ListAnd}
+}
+
+// OrList creates a List parameter joined as a disjunction
+func OrList(items any) List {
+	return List{Items: items, Style: ListOr}
+}
+
+// ListPatterns holds the CLDR patterns used to join list items
+// {0} is the text so far and {1} is the next item
+type ListPatterns struct {
+	Two    string // Pattern for a list of exactly two items
+	Start  string // Pattern joining the first two items of a longer list
+	Middle string // Pattern joining the items in the middle
+	End    string // Pattern joining the last item
+}
+
+// listPatterns creates list patterns where start and middle use the same separator
+func listPatterns(two, middle, end string) *ListPatterns {
+	return &ListPatterns{Two: two, Start: middle, Middle: middle, End: end}
+}
+
+var (
+	enLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0} and {1}", "{0}, {1}", "{0}, and {1}"),
+		ListOr:  listPatterns("{0} or {1}", "{0}, {1}", "{0}, or {1}"),
+	}
+	idLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0} dan {1}", "{0}, {1}", "{0}, dan {1}"),
+		ListOr:  listPatterns("{0} atau {1}", "{0}, {1}", "{0}, atau {1}"),
+	}
+	msLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0} dan {1}", "{0}, {1}", "{0} dan {1}"),
+		ListOr:  listPatterns("{0} atau {1}", "{0}, {1}", "{0} atau {1}"),
+	}
+	deLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0} und {1}", "{0}, {1}", "{0} und {1}"),
+		ListOr:  listPatterns("{0} oder {1}", "{0}, {1}", "{0} oder {1}"),
+	}
+	frLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0} et {1}", "{0}, {1}", "{0} et {1}"),
+		ListOr:  listPatterns("{0} ou {1}", "{0}, {1}", "{0} ou {1}"),
+	}
+	esLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0} y {1}", "{0}, {1}", "{0} y {1}"),
+		ListOr:  listPatterns("{0} o {1}", "{0}, {1}", "{0} o {1}"),
+	}
+	ptLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0} e {1}", "{0}, {1}", "{0} e {1}"),
+		ListOr:  listPatterns("{0} ou {1}", "{0}, {1}", "{0} ou {1}"),
+	}
+	itLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0} e {1}", "{0}, {1}", "{0} e {1}"),
+		ListOr:  listPatterns("{0} o {1}", "{0}, {1}", "{0} o {1}"),
+	}
+	nlLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0} en {1}", "{0}, {1}", "{0} en {1}"),
+		ListOr:  listPatterns("{0} of {1}", "{0}, {1}", "{0} of {1}"),
+	}
+	ruLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0} и {1}", "{0}, {1}", "{0} и {1}"),
+		ListOr:  listPatterns("{0} или {1}", "{0}, {1}", "{0} или {1}"),
+	}
+	trLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0} ve {1}", "{0}, {1}", "{0} ve {1}"),
+		ListOr:  listPatterns("{0} veya {1}", "{0}, {1}", "{0} veya {1}"),
+	}
+	viLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0} và {1}", "{0}, {1}", "{0} và {1}"),
+		ListOr:  listPatterns("{0} hoặc {1}", "{0}, {1}", "{0} hoặc {1}"),
+	}
+	thLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0}และ{1}", "{0} {1}", "{0} และ{1}"),
+		ListOr:  listPatterns("{0} หรือ {1}", "{0} {1}", "{0} หรือ {1}"),
+	}
+	jaLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0}、{1}", "{0}、{1}", "{0}、{1}"),
+		ListOr:  listPatterns("{0}または{1}", "{0}、{1}", "{0}、または{1}"),
+	}
+	zhLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0}和{1}", "{0}、{1}", "{0}和{1}"),
+		ListOr:  listPatterns("{0}或{1}", "{0}、{1}", "{0}或{1}"),
+	}
+	koLists = map[ListStyle]*ListPatterns{
+		ListAnd: listPatterns("{0} 및 {1}", "{0}, {1}", "{0} 및 {1}"),
+		ListOr:  listPatterns("{0} 또는 {1}", "{0}, {1}", "{0} 또는 {1}"),
+	}
+)
+
+// listPatternRules adjusts a joining pattern based on the next item, keyed by base language
+var listPatternRules = map[string]func(pattern, next string) string{
+	"es": spanishListPattern,
+}
+
+// FormatList renders items as a natural-language list in the given style
+// Items are rendered with Format, so numbers and times inside the list are localized too
+func (f *Formatter) FormatList(items []any, style ListStyle) string {
+	texts := make([]string, len(items))
+	for i, item := range items {
+		texts[i] = f.Format(item)
+	}
+
+	switch len(texts) {
+	case 0:
+		return ""
+	case 1:
+		return texts[0]
+	}
+
+	patterns, language := f.listPatterns(style)
+	if len(texts) == 2 {
+		return joinListPattern(patterns.Two, texts[0], texts[1], language)
+	}
+
+	result := joinListPattern(patterns.Start, texts[0], texts[1], language)
+	for i := 2; i < len(texts)-1; i++ {
+		result = joinListPattern(patterns.Middle, result, texts[i], language)
+	}
+	return joinListPattern(patterns.End, result, texts[len(texts)-1], language)
+}
+
+// listPatterns returns the list patterns of the formatter's locale and the language they are in
+// Languages without patterns use English
+func (f *Formatter) listPatterns(style ListStyle) (*ListPatterns, string) {
+	if f != nil && f.Locale != nil {
+		if patterns, exists := f.Locale.Lists[style]; exists {
+			return patterns, f.Language
+		}
+	}
+	if patterns, exists := enLists[style]; exists {
+		return patterns, "en"
+	}
+	return enLists[ListAnd], "en"
+}
+
+// joinListPattern joins two parts of a list using a pattern
+func joinListPattern(pattern, head, next, language string) string {
+	if rule, exists := listPatternRules[baseLanguage(language)]; exists {
+		pattern = rule(pattern, next)
+	}
+	return strings.Replace(strings.Replace(pattern, "{0}", head, 1), "{1}", next, 1)
+}
+
+// spanishListPattern applies the Spanish rules that turn "y" into "e" before an /i/ sound
+// and "o" into "u" before an /o/ sound ("padres e hijos", "siete u ocho", but "agua y hielo")
+func spanishListPattern(pattern, next string) string {
+	word := strings.ToLower(next)
+	switch {
+	case strings.Contains(pattern, " y {1}") &&
+		(strings.HasPrefix(word, "i") || strings.HasPrefix(word, "hi")) &&
+		!strings.HasPrefix(word, "hia") && !strings.HasPrefix(word, "hie") &&
+		!strings.HasPrefix(word, "hio") && !strings.HasPrefix(word, "hiu"):
+		return strings.Replace(pattern, " y {1}", " e {1}", 1)
+	case strings.Contains(pattern, " o {1}") &&
+		(strings.HasPrefix(word, "o") || strings.HasPrefix(word, "ho") ||
+			strings.HasPrefix(word, "8") || word == "11"):
+		return strings.Replace(pattern, " o {1}", " u {1}", 1)
+	}
+	return pattern
+}
+
+// listItems converts a slice or array value into a list of items
+// Byte slices and arrays (net.IP, json.RawMessage, UUIDs) and values implementing fmt.Stringer,
+// error or encoding.TextMarshaler are not treated as lists
+func listItems(value any) ([]any, bool) {
+	switch value.(type) {
+	case nil, fmt.Stringer, error, encoding.TextMarshaler:
+		return nil, false
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	if rv.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+
+	items := make([]any, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, true
+}
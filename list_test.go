@@ -0,0 +1,183 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+)
+
+// stringerPair is an array implementing fmt.Stringer
+type stringerPair [2]string
+
+// String joins the pair with a slash
+func (p stringerPair) String() string {
+	return p[0] + "/" + p[1]
+}
+
+// TestFormatList tests Formatter.FormatList and list rendering in Format
+func TestFormatList(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		value    any
+		expected string
+	}{
+		{
+			name:     "English conjunction",
+			language: "en",
+			value:    []string{"a", "b", "c"},
+			expected: "a, b, and c",
+		},
+		{
+			name:     "Indonesian conjunction",
+			language: "id",
+			value:    []string{"a", "b", "c"},
+			expected: "a, b, dan c",
+		},
+		{
+			name:     "English disjunction",
+			language: "en",
+			value:    OrList([]string{"email", "phone", "username"}),
+			expected: "email, phone, or username",
+		},
+		{
+			name:     "Two items",
+			language: "en",
+			value:    []string{"name", "email"},
+			expected: "name and email",
+		},
+		{
+			name:     "Single item",
+			language: "en",
+			value:    []string{"name"},
+			expected: "name",
+		},
+		{
+			name:     "Empty list",
+			language: "en",
+			value:    []string{},
+			expected: "",
+		},
+		{
+			name:     "German long list",
+			language: "de",
+			value:    AndList([]string{"Name", "E-Mail", "Telefon", "Adresse"}),
+			expected: "Name, E-Mail, Telefon und Adresse",
+		},
+		{
+			name:     "Japanese disjunction",
+			language: "ja",
+			value:    OrList([]string{"A", "B", "C"}),
+			expected: "A、B、またはC",
+		},
+		{
+			name:     "Spanish y becomes e",
+			language: "es",
+			value:    []string{"padres", "hijos"},
+			expected: "padres e hijos",
+		},
+		{
+			name:     "Spanish y kept before hie",
+			language: "es",
+			value:    []string{"agua", "hielo"},
+			expected: "agua y hielo",
+		},
+		{
+			name:     "Spanish o becomes u",
+			language: "es",
+			value:    OrList([]string{"siete", "ocho"}),
+			expected: "siete u ocho",
+		},
+		{
+			name:     "Numbers inside list are localized",
+			language: "id",
+			value:    []float64{1000.5, 2000},
+			expected: "1.000,50 dan 2.000",
+		},
+		{
+			name:     "Integers inside list are not grouped",
+			language: "id",
+			value:    []int{1000, 2000},
+			expected: "1000 dan 2000",
+		},
+		{
+			name:     "Array",
+			language: "en",
+			value:    [2]string{"x", "y"},
+			expected: "x and y",
+		},
+		{
+			name:     "Unknown language uses English",
+			language: "xx",
+			value:    []string{"a", "b", "c"},
+			expected: "a, b, and c",
+		},
+		{
+			name:     "Byte slice is not a list",
+			language: "en",
+			value:    []byte("ab"),
+			expected: "[97 98]",
+		},
+		{
+			name:     "Named byte slice is not a list",
+			language: "en",
+			value:    json.RawMessage(`"ab"`),
+			expected: `"ab"`,
+		},
+		{
+			name:     "Byte array is not a list",
+			language: "en",
+			value:    [4]byte{1, 2, 3, 4},
+			expected: "[1 2 3 4]",
+		},
+		{
+			name:     "Stringer slice is not a list",
+			language: "en",
+			value:    net.IPv4(10, 0, 0, 1),
+			expected: "10.0.0.1",
+		},
+		{
+			name:     "Stringer array is not a list",
+			language: "en",
+			value:    stringerPair{"a", "b"},
+			expected: "a/b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := NewFormatter(tt.language).Format(tt.value); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestBuildResponseListParam tests slice parameters in BuildResponse
+func TestBuildResponseListParam(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"validation": {
+				Key:      "validation",
+				Template: "Missing fields: $fields",
+				Translations: map[string]string{
+					"id": "Field yang belum diisi: $fields",
+				},
+			},
+		},
+		DefaultLanguage: "en",
+	}
+
+	builder := NewResponseBuilder("validation").
+		SetLanguage("id").
+		SetParam("fields", []string{"nama", "email", "telepon"})
+
+	response, err := config.BuildResponse(builder)
+	if err != nil {
+		t.Fatalf("BuildResponse failed: %v", err)
+	}
+	expected := "Field yang belum diisi: nama, email, dan telepon"
+	if response.Message != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, response.Message)
+	}
+}
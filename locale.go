@@ -0,0 +1,138 @@
+package goresponse
+
+import (
+	"strings"
+	"sync"
+)
+
+// Locale holds the formatting data used to render parameters for a language
+// Patterns use "#" as the placeholder for the formatted number and "¤" for the currency symbol
+type Locale struct {
+	Tag               string                      // Language tag the data applies to (en, id, pt-BR, etc.)
+	DecimalSeparator  string                      // Separator between integer and fraction digits
+	GroupSeparator    string                      // Separator between groups of thousands
+	PercentPattern    string                      // Placement of the percent sign, e.g. "#%" or "# %"
+	CurrencyPattern   string                      // Placement of the currency symbol, e.g. "¤#" or "# ¤"
+	MinFractionDigits int                         // Minimum fraction digits of numbers with a fraction part
+	Calendar          *Calendar                   // Month and day names and date/time patterns, nil when unknown
+	RelativeTime      *RelativeTimeData           // Relative time and duration phrases, nil when unknown
+	Lists             map[ListStyle]*ListPatterns // Patterns joining list items per style, nil when unknown
+}
+
+// localeRegistry stores locale data keyed by lowercase language tag
+var localeRegistry = struct {
+	mu      sync.RWMutex
+	locales map[string]*Locale
+}{
+	locales: map[string]*Locale{
+		"en":    {Tag: "en", DecimalSeparator: ".", GroupSeparator: ",", PercentPattern: "#%", CurrencyPattern: "¤#", Calendar: enCalendar, RelativeTime: enRelative, Lists: enLists},
+		"id":    {Tag: "id", DecimalSeparator: ",", GroupSeparator: ".", PercentPattern: "#%", CurrencyPattern: "¤#", MinFractionDigits: 2, Calendar: idCalendar, RelativeTime: idRelative, Lists: idLists},
+		"ms":    {Tag: "ms", DecimalSeparator: ".", GroupSeparator: ",", PercentPattern: "#%", CurrencyPattern: "¤#", Calendar: msCalendar, RelativeTime: msRelative, Lists: msLists},
+		"de":    {Tag: "de", DecimalSeparator: ",", GroupSeparator: ".", PercentPattern: "#\u00a0%", CurrencyPattern: "#\u00a0¤", Calendar: deCalendar, RelativeTime: deRelative, Lists: deLists},
+		"de-ch": {Tag: "de-CH", DecimalSeparator: ".", GroupSeparator: "’", PercentPattern: "#%", CurrencyPattern: "¤\u00a0#", Calendar: deCalendar, RelativeTime: deRelative, Lists: deLists},
+		"fr":    {Tag: "fr", DecimalSeparator: ",", GroupSeparator: "\u202f", PercentPattern: "#\u202f%", CurrencyPattern: "#\u00a0¤", Calendar: frCalendar, RelativeTime: frRelative, Lists: frLists},
+		"es":    {Tag: "es", DecimalSeparator: ",", GroupSeparator: ".", PercentPattern: "#\u00a0%", CurrencyPattern: "#\u00a0¤", Calendar: esCalendar, RelativeTime: esRelative, Lists: esLists},
+		"es-mx": {Tag: "es-MX", DecimalSeparator: ".", GroupSeparator: ",", PercentPattern: "#\u00a0%", CurrencyPattern: "¤#", Calendar: esCalendar, RelativeTime: esRelative, Lists: esLists},
+		"pt":    {Tag: "pt", DecimalSeparator: ",", GroupSeparator: ".", PercentPattern: "#%", CurrencyPattern: "¤\u00a0#", Calendar: ptCalendar, RelativeTime: ptRelative, Lists: ptLists},
+		"pt-pt": {Tag: "pt-PT", DecimalSeparator: ",", GroupSeparator: "\u00a0", PercentPattern: "#%", CurrencyPattern: "#\u00a0¤", Calendar: ptCalendar, RelativeTime: ptRelative, Lists: ptLists},
+		"it":    {Tag: "it", DecimalSeparator: ",", GroupSeparator: ".", PercentPattern: "#%", CurrencyPattern: "#\u00a0¤", Calendar: itCalendar, RelativeTime: itRelative, Lists: itLists},
+		"nl":    {Tag: "nl", DecimalSeparator: ",", GroupSeparator: ".", PercentPattern: "#%", CurrencyPattern: "¤\u00a0#", Calendar: nlCalendar, RelativeTime: nlRelative, Lists: nlLists},
+		"ru":    {Tag: "ru", DecimalSeparator: ",", GroupSeparator: "\u00a0", PercentPattern: "#\u00a0%", CurrencyPattern: "#\u00a0¤", Calendar: ruCalendar, RelativeTime: ruRelative, Lists: ruLists},
+		"tr":    {Tag: "tr", DecimalSeparator: ",", GroupSeparator: ".", PercentPattern: "%#", CurrencyPattern: "¤#", Calendar: trCalendar, RelativeTime: trRelative, Lists: trLists},
+		"vi":    {Tag: "vi", DecimalSeparator: ",", GroupSeparator: ".", PercentPattern: "#%", CurrencyPattern: "#\u00a0¤", Calendar: viCalendar, RelativeTime: viRelative, Lists: viLists},
+		"th":    {Tag: "th", DecimalSeparator: ".", GroupSeparator: ",", PercentPattern: "#%", CurrencyPattern: "¤#", Calendar: thCalendar, RelativeTime: thRelative, Lists: thLists},
+		"ja":    {Tag: "ja", DecimalSeparator: ".", GroupSeparator: ",", PercentPattern: "#%", CurrencyPattern: "¤#", Calendar: jaCalendar, RelativeTime: jaRelative, Lists: jaLists},
+		"zh":    {Tag: "zh", DecimalSeparator: ".", GroupSeparator: ",", PercentPattern: "#%", CurrencyPattern: "¤#", Calendar: zhCalendar, RelativeTime: zhRelative, Lists: zhLists},
+		"ko":    {Tag: "ko", DecimalSeparator: ".", GroupSeparator: ",", PercentPattern: "#%", CurrencyPattern: "¤#", Calendar: koCalendar, RelativeTime: koRelative, Lists: koLists},
+	},
+}
+
+// currencySymbols maps ISO 4217 currency codes to their display symbols
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"CNY": "¥",
+	"KRW": "₩",
+	"IDR": "Rp",
+	"MYR": "RM",
+	"SGD": "S$",
+	"THB": "฿",
+	"VND": "₫",
+	"INR": "₹",
+	"BRL": "R$",
+	"RUB": "₽",
+	"TRY": "₺",
+	"CHF": "CHF",
+	"MXN": "$",
+	"AUD": "A$",
+	"CAD": "CA$",
+}
+
+// currencyDigits maps ISO 4217 currency codes to their number of fraction digits
+// Currencies not listed use two fraction digits
+var currencyDigits = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"VND": 0,
+}
+
+// RegisterLocale adds or replaces locale data for the locale's tag
+// This allows languages without built-in data to get localized formatting
+// Missing calendar names and patterns are taken from the data the tag currently resolves to (pt-BR -> pt),
+// or from ISO 8601 style values
+func RegisterLocale(locale Locale) {
+	if locale.Calendar != nil {
+		base := isoCalendar
+		if current, exists := LookupLocale(locale.Tag); exists && current.Calendar != nil {
+			base = current.Calendar
+		}
+		locale.Calendar = locale.Calendar.complete(base)
+	}
+
+	localeRegistry.mu.Lock()
+	defer localeRegistry.mu.Unlock()
+	localeRegistry.locales[strings.ToLower(locale.Tag)] = &locale
+}
+
+// LookupLocale finds locale data for a language tag
+// Region-specific data is preferred, falling back to the base language (pt-BR -> pt)
+func LookupLocale(tag string) (*Locale, bool) {
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	if tag == "" {
+		return nil, false
+	}
+
+	localeRegistry.mu.RLock()
+	defer localeRegistry.mu.RUnlock()
+
+	for tag != "" {
+		if locale, exists := localeRegistry.locales[tag]; exists {
+			return locale, true
+		}
+		idx := strings.LastIndex(tag, "-")
+		if idx < 0 {
+			break
+		}
+		tag = tag[:idx]
+	}
+	return nil, false
+}
+
+// CurrencySymbol returns the display symbol for an ISO 4217 currency code
+// Unknown codes are returned unchanged
+func CurrencySymbol(code string) string {
+	if symbol, exists := currencySymbols[strings.ToUpper(code)]; exists {
+		return symbol
+	}
+	return code
+}
+
+// CurrencyDigits returns the number of fraction digits used for an ISO 4217 currency code
+func CurrencyDigits(code string) int {
+	if digits, exists := currencyDigits[strings.ToUpper(code)]; exists {
+		return digits
+	}
+	return 2
+}
@@ -0,0 +1,116 @@
+package goresponse
+
+import (
+	"testing"
+)
+
+// TestLookupLocale tests LookupLocale function
+func TestLookupLocale(t *testing.T) {
+	tests := []struct {
+		name          string
+		tag           string
+		expectedTag   string
+		expectedFound bool
+	}{
+		{
+			name:          "Base language",
+			tag:           "id",
+			expectedTag:   "id",
+			expectedFound: true,
+		},
+		{
+			name:          "Region falls back to base language",
+			tag:           "id-ID",
+			expectedTag:   "id",
+			expectedFound: true,
+		},
+		{
+			name:          "Region specific data",
+			tag:           "pt-PT",
+			expectedTag:   "pt-PT",
+			expectedFound: true,
+		},
+		{
+			name:          "Underscore separator",
+			tag:           "de_CH",
+			expectedTag:   "de-CH",
+			expectedFound: true,
+		},
+		{
+			name:          "Case insensitive",
+			tag:           "EN-us",
+			expectedTag:   "en",
+			expectedFound: true,
+		},
+		{
+			name:          "Unknown language",
+			tag:           "xx",
+			expectedFound: false,
+		},
+		{
+			name:          "Empty tag",
+			tag:           "",
+			expectedFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locale, found := LookupLocale(tt.tag)
+			if found != tt.expectedFound {
+				t.Fatalf("Expected found %v, got %v", tt.expectedFound, found)
+			}
+			if found && locale.Tag != tt.expectedTag {
+				t.Errorf("Expected tag %s, got %s", tt.expectedTag, locale.Tag)
+			}
+		})
+	}
+}
+
+// TestRegisterLocale tests RegisterLocale function
+func TestRegisterLocale(t *testing.T) {
+	RegisterLocale(Locale{
+		Tag:              "xx-Test",
+		DecimalSeparator: "'",
+		GroupSeparator:   "_",
+		PercentPattern:   "#pct",
+		CurrencyPattern:  "# ¤",
+	})
+
+	locale, found := LookupLocale("xx-test")
+	if !found {
+		t.Fatal("Expected registered locale to be found")
+	}
+	if locale.GroupSeparator != "_" {
+		t.Errorf("Expected group separator '_', got %s", locale.GroupSeparator)
+	}
+
+	if _, found := LookupLocale("xx"); found {
+		t.Error("Expected base language of registered locale not to be found")
+	}
+}
+
+// TestCurrencySymbolAndDigits tests CurrencySymbol and CurrencyDigits functions
+func TestCurrencySymbolAndDigits(t *testing.T) {
+	tests := []struct {
+		code           string
+		expectedSymbol string
+		expectedDigits int
+	}{
+		{code: "USD", expectedSymbol: "$", expectedDigits: 2},
+		{code: "idr", expectedSymbol: "Rp", expectedDigits: 2},
+		{code: "JPY", expectedSymbol: "¥", expectedDigits: 0},
+		{code: "XYZ", expectedSymbol: "XYZ", expectedDigits: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if symbol := CurrencySymbol(tt.code); symbol != tt.expectedSymbol {
+				t.Errorf("Expected symbol %s, got %s", tt.expectedSymbol, symbol)
+			}
+			if digits := CurrencyDigits(tt.code); digits != tt.expectedDigits {
+				t.Errorf("Expected digits %d, got %d", tt.expectedDigits, digits)
+			}
+		})
+	}
+}
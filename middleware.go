@@ -0,0 +1,220 @@
+package goresponse
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// Default names used by the HTTP middleware
+const (
+	DefaultLanguageQueryParam = "lang"
+	DefaultLanguageCookie     = "lang"
+	DefaultLanguageHeader     = "X-Language"
+	DefaultRequestIDHeader    = "X-Request-ID"
+)
+
+// maxRequestIDLength limits request IDs accepted from clients
+const maxRequestIDLength = 128
+
+// LanguageResolver finds the language requested by an HTTP request
+type LanguageResolver struct {
+	Resolve func(r *http.Request) string // Returns a language tag or Accept-Language value, empty when absent
+	Vary    string                       // Request header the result depends on, added to the Vary response header
+}
+
+// LanguageFromQuery resolves the language from a query param (?lang=id)
+func LanguageFromQuery(name string) LanguageResolver {
+	return LanguageResolver{
+		Resolve: func(r *http.Request) string {
+			return r.URL.Query().Get(name)
+		},
+	}
+}
+
+// LanguageFromCookie resolves the language from a cookie
+func LanguageFromCookie(name string) LanguageResolver {
+	return LanguageResolver{
+		Resolve: func(r *http.Request) string {
+			cookie, err := r.Cookie(name)
+			if err != nil {
+				return ""
+			}
+			return cookie.Value
+		},
+		Vary: "Cookie",
+	}
+}
+
+// LanguageFromHeader resolves the language from a request header such as X-Language
+func LanguageFromHeader(name string) LanguageResolver {
+	return LanguageResolver{
+		Resolve: func(r *http.Request) string {
+			return r.Header.Get(name)
+		},
+		Vary: http.CanonicalHeaderKey(name),
+	}
+}
+
+// LanguageFromAcceptLanguage resolves the language from the Accept-Language header
+func LanguageFromAcceptLanguage() LanguageResolver {
+	return LanguageFromHeader("Accept-Language")
+}
+
+// LanguageFromClaim resolves the language with an extractor, typically reading a user claim set by authentication middleware
+func LanguageFromClaim(extract func(r *http.Request) string) LanguageResolver {
+	return LanguageResolver{Resolve: extract}
+}
+
+// MiddlewareOption configures the HTTP middleware
+type MiddlewareOption func(*middlewareOptions)
+
+// middlewareOptions holds the HTTP middleware settings
+type middlewareOptions struct {
+	resolvers          []LanguageResolver
+	requestIDHeader    string
+	requestIDGenerator func() string
+}
+
+// WithLanguageResolvers replaces the language resolution chain
+// Resolvers are tried in order, the first value matching a configured language wins
+func WithLanguageResolvers(resolvers ...LanguageResolver) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.resolvers = resolvers
+	}
+}
+
+// WithRequestIDHeader sets the header the request ID is read from and written to
+// An empty name disables reading and writing the header, request IDs are still generated
+func WithRequestIDHeader(name string) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.requestIDHeader = name
+	}
+}
+
+// WithRequestIDGenerator sets the function generating request IDs for requests without one
+func WithRequestIDGenerator(generate func() string) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.requestIDGenerator = generate
+	}
+}
+
+// Middleware returns net/http middleware that populates the request context for response building
+// The language is resolved from the query param "lang", the "lang" cookie, the X-Language header
+// and Accept-Language (configurable with WithLanguageResolvers), falling back to the default language
+// The context gets the language, protocol "http" and a request ID, so handlers can use
+// NewResponseBuilder(key).WithContext(r.Context())
+// Content-Language, Vary and the request ID header are set on the response
+func Middleware(cfg Provider, opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	options := &middlewareOptions{
+		resolvers: []LanguageResolver{
+			LanguageFromQuery(DefaultLanguageQueryParam),
+			LanguageFromCookie(DefaultLanguageCookie),
+			LanguageFromHeader(DefaultLanguageHeader),
+			LanguageFromAcceptLanguage(),
+		},
+		requestIDHeader:    DefaultRequestIDHeader,
+		requestIDGenerator: newRequestID,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	var vary []string
+	for _, resolver := range options.resolvers {
+		if resolver.Vary != "" {
+			vary = append(vary, resolver.Vary)
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := WithProtocol(r.Context(), "http")
+
+			var config *ResponseConfig
+			if cfg != nil {
+				config = cfg.GetConfig()
+			}
+			if lang := resolveRequestLanguage(r, config, options.resolvers); lang != "" {
+				ctx = WithLanguage(ctx, lang)
+				w.Header().Set("Content-Language", lang)
+			}
+			addVary(w.Header(), vary...)
+
+			requestID := ""
+			if options.requestIDHeader != "" {
+				requestID = r.Header.Get(options.requestIDHeader)
+			}
+			if !validRequestID(requestID) {
+				requestID = options.requestIDGenerator()
+			}
+			ctx = WithRequestID(ctx, requestID)
+			if options.requestIDHeader != "" {
+				w.Header().Set(options.requestIDHeader, requestID)
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// resolveRequestLanguage returns the first resolved language matching a configured language
+// The default language is returned when no resolver matches
+func resolveRequestLanguage(r *http.Request, config *ResponseConfig, resolvers []LanguageResolver) string {
+	if config == nil {
+		return ""
+	}
+	for _, resolver := range resolvers {
+		if resolver.Resolve == nil {
+			continue
+		}
+		value := resolver.Resolve(r)
+		if value == "" {
+			continue
+		}
+		if lang, matched := negotiateLanguage(value, config); matched {
+			return lang
+		}
+	}
+	return config.GetDefaultLanguage()
+}
+
+// addVary adds values to the Vary header, skipping values already present
+func addVary(header http.Header, values ...string) {
+	for _, value := range values {
+		present := false
+		for _, existing := range header.Values("Vary") {
+			for _, field := range strings.Split(existing, ",") {
+				if strings.EqualFold(strings.TrimSpace(field), value) {
+					present = true
+				}
+			}
+		}
+		if !present {
+			header.Add("Vary", value)
+		}
+	}
+}
+
+// validRequestID reports whether a client supplied request ID is non-empty, short and printable ASCII
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < 0x21 || requestID[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID generates a random 128-bit request ID in hex
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,182 @@
+package goresponse
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// TestMiddleware tests language resolution, context values and response headers of the HTTP middleware
+func TestMiddleware(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"welcome": {Key: "welcome", Template: "Welcome", CodeMappings: map[string]int{"http": 200}, Translations: map[string]string{"id": "Selamat datang"}},
+		},
+		DefaultLanguage: "en",
+		Languages:       []string{"en", "id", "pt-BR"},
+	}
+
+	tests := []struct {
+		name     string
+		target   string
+		headers  map[string]string
+		cookie   *http.Cookie
+		expected string
+	}{
+		{name: "Default language", target: "/", expected: "en"},
+		{name: "Accept-Language", target: "/", headers: map[string]string{"Accept-Language": "fr, id-ID;q=0.8"}, expected: "id"},
+		{name: "Header over Accept-Language", target: "/", headers: map[string]string{"X-Language": "pt", "Accept-Language": "id"}, expected: "pt-BR"},
+		{name: "Cookie over header", target: "/", headers: map[string]string{"X-Language": "pt"}, cookie: &http.Cookie{Name: "lang", Value: "id"}, expected: "id"},
+		{name: "Query over cookie", target: "/?lang=pt-BR", cookie: &http.Cookie{Name: "lang", Value: "id"}, expected: "pt-BR"},
+		{name: "Unsupported query falls through", target: "/?lang=xx", headers: map[string]string{"Accept-Language": "id"}, expected: "id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var language, protocol, requestID string
+			handler := Middleware(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				language = GetLanguage(r.Context())
+				protocol = GetProtocol(r.Context())
+				requestID = GetRequestID(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			if tt.cookie != nil {
+				req.AddCookie(tt.cookie)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if language != tt.expected {
+				t.Errorf("Expected language %q, got %q", tt.expected, language)
+			}
+			if protocol != "http" {
+				t.Errorf("Expected protocol http, got %q", protocol)
+			}
+			if len(requestID) != 32 || rec.Header().Get("X-Request-ID") != requestID {
+				t.Errorf("Expected generated request ID in context and header, got %q and %q", requestID, rec.Header().Get("X-Request-ID"))
+			}
+			if rec.Header().Get("Content-Language") != tt.expected {
+				t.Errorf("Expected Content-Language %q, got %q", tt.expected, rec.Header().Get("Content-Language"))
+			}
+			if vary := rec.Header().Values("Vary"); !reflect.DeepEqual(vary, []string{"Cookie", "X-Language", "Accept-Language"}) {
+				t.Errorf("Unexpected Vary header %v", vary)
+			}
+		})
+	}
+
+	t.Run("Handler builds localized response", func(t *testing.T) {
+		handler := Middleware(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			response, err := config.BuildResponse(NewResponseBuilder("welcome").WithContext(r.Context()))
+			if err != nil {
+				t.Fatalf("BuildResponse failed: %v", err)
+			}
+			w.WriteHeader(response.Code)
+			w.Write([]byte(response.Message))
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", "id")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != 200 || rec.Body.String() != "Selamat datang" {
+			t.Errorf("Expected 200 Selamat datang, got %d %q", rec.Code, rec.Body.String())
+		}
+	})
+}
+
+// TestMiddlewareOptions tests custom resolvers and request ID options
+func TestMiddlewareOptions(t *testing.T) {
+	config := &ResponseConfig{DefaultLanguage: "en", Languages: []string{"en", "id", "ms"}}
+
+	t.Run("Custom resolver chain with claim", func(t *testing.T) {
+		var language string
+		handler := Middleware(config, WithLanguageResolvers(
+			LanguageFromClaim(func(r *http.Request) string { return r.Header.Get("X-Test-Claim") }),
+			LanguageFromAcceptLanguage(),
+		))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			language = GetLanguage(r.Context())
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/?lang=id", nil)
+		req.Header.Set("X-Test-Claim", "ms")
+		req.Header.Set("Accept-Language", "id")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if language != "ms" {
+			t.Errorf("Expected claim language ms, got %q", language)
+		}
+		if vary := rec.Header().Values("Vary"); !reflect.DeepEqual(vary, []string{"Accept-Language"}) {
+			t.Errorf("Unexpected Vary header %v", vary)
+		}
+	})
+
+	t.Run("Existing Vary is not duplicated", func(t *testing.T) {
+		handler := Middleware(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Vary", "Origin, accept-language")
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if vary := rec.Header().Values("Vary"); !reflect.DeepEqual(vary, []string{"Origin, accept-language", "Cookie", "X-Language"}) {
+			t.Errorf("Unexpected Vary header %v", vary)
+		}
+	})
+
+	t.Run("Request ID", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			incoming string
+			expected string
+		}{
+			{name: "Incoming ID is kept", incoming: "abc-123", expected: "abc-123"},
+			{name: "Invalid ID is replaced", incoming: "bad id\n", expected: "generated"},
+			{name: "Missing ID is generated", incoming: "", expected: "generated"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var requestID string
+				handler := Middleware(config,
+					WithRequestIDHeader("X-Correlation-ID"),
+					WithRequestIDGenerator(func() string { return "generated" }),
+				)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					requestID = GetRequestID(r.Context())
+				}))
+
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				if tt.incoming != "" {
+					req.Header["X-Correlation-Id"] = []string{tt.incoming}
+				}
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+
+				if requestID != tt.expected || rec.Header().Get("X-Correlation-ID") != tt.expected {
+					t.Errorf("Expected request ID %q, got %q (header %q)", tt.expected, requestID, rec.Header().Get("X-Correlation-ID"))
+				}
+			})
+		}
+	})
+
+	t.Run("Provider without config", func(t *testing.T) {
+		var language, protocol string
+		manager := NewConfigManager(ConfigSource{Method: "file", Path: "missing.json"})
+		handler := Middleware(manager)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			language = GetLanguage(r.Context())
+			protocol = GetProtocol(r.Context())
+		}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if language != "" || protocol != "http" {
+			t.Errorf("Expected no language and protocol http, got %q and %q", language, protocol)
+		}
+		if rec.Header().Get("Content-Language") != "" {
+			t.Errorf("Expected no Content-Language, got %q", rec.Header().Get("Content-Language"))
+		}
+	})
+}
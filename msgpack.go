@@ -0,0 +1,293 @@
+package goresponse
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// MsgPackEncoder encodes values as MessagePack
+// Integers use their smallest encoding, floats are float64 and map keys are strings
+type MsgPackEncoder struct{}
+
+// ContentType returns the MessagePack Content-Type
+func (MsgPackEncoder) ContentType() string {
+	return MediaTypeMsgPack
+}
+
+// Encode writes v as MessagePack
+func (MsgPackEncoder) Encode(w io.Writer, v any) error {
+	value, err := normalizeValue(v)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	if err := writeMsgPack(bw, value); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// writeMsgPack writes a normalized value
+func writeMsgPack(w *bufio.Writer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		w.WriteByte(0xc0)
+	case bool:
+		if v {
+			w.WriteByte(0xc3)
+		} else {
+			w.WriteByte(0xc2)
+		}
+	case int64:
+		writeMsgPackInt(w, v)
+	case uint64:
+		w.WriteByte(0xcf)
+		binary.Write(w, binary.BigEndian, v)
+	case float64:
+		w.WriteByte(0xcb)
+		binary.Write(w, binary.BigEndian, math.Float64bits(v))
+	case string:
+		writeMsgPackLength(w, len(v), 0xa0, 32, 0xd9, 0xda, 0xdb)
+		w.WriteString(v)
+	case []any:
+		writeMsgPackLength(w, len(v), 0x90, 16, 0, 0xdc, 0xdd)
+		for _, item := range v {
+			if err := writeMsgPack(w, item); err != nil {
+				return err
+			}
+		}
+	case Object:
+		writeMsgPackLength(w, len(v), 0x80, 16, 0, 0xde, 0xdf)
+		for _, field := range v {
+			writeMsgPack(w, field.Key)
+			if err := writeMsgPack(w, field.Value); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		writeMsgPackLength(w, len(v), 0x80, 16, 0, 0xde, 0xdf)
+		for _, key := range sortedKeys(v) {
+			writeMsgPack(w, key)
+			if err := writeMsgPack(w, v[key]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("msgpack: %w %T", errUnsupportedValue, value)
+	}
+	return nil
+}
+
+// writeMsgPackInt writes an integer in its smallest encoding
+func writeMsgPackInt(w *bufio.Writer, v int64) {
+	switch {
+	case v >= 0 && v <= 0x7f:
+		w.WriteByte(byte(v))
+	case v >= -32 && v < 0:
+		w.WriteByte(byte(int8(v)))
+	case v >= 0 && v <= math.MaxUint8:
+		w.Write([]byte{0xcc, byte(v)})
+	case v >= 0 && v <= math.MaxUint16:
+		w.WriteByte(0xcd)
+		binary.Write(w, binary.BigEndian, uint16(v))
+	case v >= 0 && v <= math.MaxUint32:
+		w.WriteByte(0xce)
+		binary.Write(w, binary.BigEndian, uint32(v))
+	case v >= 0:
+		w.WriteByte(0xcf)
+		binary.Write(w, binary.BigEndian, uint64(v))
+	case v >= math.MinInt8:
+		w.Write([]byte{0xd0, byte(int8(v))})
+	case v >= math.MinInt16:
+		w.WriteByte(0xd1)
+		binary.Write(w, binary.BigEndian, int16(v))
+	case v >= math.MinInt32:
+		w.WriteByte(0xd2)
+		binary.Write(w, binary.BigEndian, int32(v))
+	default:
+		w.WriteByte(0xd3)
+		binary.Write(w, binary.BigEndian, v)
+	}
+}
+
+// writeMsgPackLength writes the header of a string, array or map
+// fix is the fixed-size prefix used below fixLimit, len8 (0 when unavailable), len16 and len32 the sized prefixes
+func writeMsgPackLength(w *bufio.Writer, n int, fix byte, fixLimit int, len8, len16, len32 byte) {
+	switch {
+	case n < fixLimit:
+		w.WriteByte(fix | byte(n))
+	case len8 != 0 && n <= math.MaxUint8:
+		w.Write([]byte{len8, byte(n)})
+	case n <= math.MaxUint16:
+		w.WriteByte(len16)
+		binary.Write(w, binary.BigEndian, uint16(n))
+	default:
+		w.WriteByte(len32)
+		binary.Write(w, binary.BigEndian, uint32(n))
+	}
+}
+
+// Decode reads one MessagePack value
+// Binary values are returned as strings, extension types are not supported
+func (MsgPackEncoder) Decode(r io.Reader) (any, error) {
+	return readMsgPack(bufio.NewReader(r), 0)
+}
+
+// maxDecodeDepth limits nesting when decoding binary formats
+const maxDecodeDepth = 1000
+
+// readMsgPack reads one value
+func readMsgPack(r *bufio.Reader, depth int) (any, error) {
+	if depth > maxDecodeDepth {
+		return nil, errors.New("msgpack: maximum nesting depth exceeded")
+	}
+	b, err := r.ReadByte()
+	if err != nil {
+		if depth > 0 {
+			return nil, unexpectedEOF(err)
+		}
+		return nil, err
+	}
+
+	switch {
+	case b <= 0x7f:
+		return int64(b), nil
+	case b >= 0xe0:
+		return int64(int8(b)), nil
+	case b&0xe0 == 0xa0:
+		return readMsgPackString(r, uint64(b&0x1f))
+	case b&0xf0 == 0x90:
+		return readMsgPackArray(r, uint64(b&0x0f), depth)
+	case b&0xf0 == 0x80:
+		return readMsgPackMap(r, uint64(b&0x0f), depth)
+	}
+
+	switch b {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n, err := readBigEndian(r, 1<<(b-0xcc))
+		if err != nil {
+			return nil, err
+		}
+		return normalizeUint(n), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (b - 0xd0)
+		n, err := readBigEndian(r, size)
+		if err != nil {
+			return nil, err
+		}
+		shift := 64 - 8*size
+		return int64(n<<shift) >> shift, nil
+	case 0xca:
+		n, err := readBigEndian(r, 4)
+		if err != nil {
+			return nil, err
+		}
+		return normalizeFloat(float64(math.Float32frombits(uint32(n)))), nil
+	case 0xcb:
+		n, err := readBigEndian(r, 8)
+		if err != nil {
+			return nil, err
+		}
+		return normalizeFloat(math.Float64frombits(n)), nil
+	case 0xd9, 0xda, 0xdb:
+		n, err := readBigEndian(r, 1<<(b-0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgPackString(r, n)
+	case 0xc4, 0xc5, 0xc6:
+		n, err := readBigEndian(r, 1<<(b-0xc4))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgPackString(r, n)
+	case 0xdc, 0xdd:
+		n, err := readBigEndian(r, 2<<(b-0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgPackArray(r, n, depth)
+	case 0xde, 0xdf:
+		n, err := readBigEndian(r, 2<<(b-0xde))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgPackMap(r, n, depth)
+	}
+	return nil, fmt.Errorf("msgpack: unsupported type byte 0x%02x", b)
+}
+
+// readMsgPackString reads n bytes as a string
+func readMsgPackString(r *bufio.Reader, n uint64) (string, error) {
+	data, err := readBytes(r, n)
+	return string(data), err
+}
+
+// readMsgPackArray reads n array items
+func readMsgPackArray(r *bufio.Reader, n uint64, depth int) ([]any, error) {
+	items := make([]any, 0, min(n, 1024))
+	for i := uint64(0); i < n; i++ {
+		item, err := readMsgPack(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// readMsgPackMap reads n map entries with string keys
+func readMsgPackMap(r *bufio.Reader, n uint64, depth int) (map[string]any, error) {
+	object := make(map[string]any, min(n, 1024))
+	for i := uint64(0); i < n; i++ {
+		key, err := readMsgPack(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		name, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("msgpack: map key must be a string, got %T", key)
+		}
+		if object[name], err = readMsgPack(r, depth+1); err != nil {
+			return nil, err
+		}
+	}
+	return object, nil
+}
+
+// readBigEndian reads an unsigned big-endian integer of size bytes
+func readBigEndian(r io.Reader, size int) (uint64, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(r, buf[8-size:]); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	return binary.BigEndian.Uint64(buf[:]), nil
+}
+
+// readBytes reads n bytes without allocating more than the input holds
+func readBytes(r io.Reader, n uint64) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, r, int64(min(n, math.MaxInt64))); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// unexpectedEOF reports a truncated value as io.ErrUnexpectedEOF
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+package goresponse
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestMsgPackEncode tests the smallest encoding of values
+func TestMsgPackEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{name: "Positive fixint", value: 127, expected: "7f"},
+		{name: "Negative fixint", value: -32, expected: "e0"},
+		{name: "Uint8", value: 200, expected: "ccc8"},
+		{name: "Uint16", value: 60000, expected: "cdea60"},
+		{name: "Uint32", value: 4000000000, expected: "ceee6b2800"},
+		{name: "Uint64", value: uint64(math.MaxUint64), expected: "cfffffffffffffffff"},
+		{name: "Int8", value: -100, expected: "d09c"},
+		{name: "Int16", value: -1000, expected: "d1fc18"},
+		{name: "Int32", value: -100000, expected: "d2fffe7960"},
+		{name: "Int64", value: int64(math.MinInt64), expected: "d38000000000000000"},
+		{name: "Float", value: 1.5, expected: "cb3ff8000000000000"},
+		{name: "Simple values", value: []any{nil, false, true}, expected: "93c0c2c3"},
+		{name: "Str8", value: strings.Repeat("a", 32), expected: "d920" + strings.Repeat("61", 32)},
+		{name: "Map keys sorted", value: map[string]any{"b": 1, "a": 2}, expected: "82a16102a16201"},
+		{name: "Array16", value: make([]any, 16), expected: "dc0010" + strings.Repeat("c0", 16)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := (MsgPackEncoder{}).Encode(&buf, tt.value); err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if hex.EncodeToString(buf.Bytes()) != tt.expected {
+				t.Errorf("Expected %s, got %x", tt.expected, buf.Bytes())
+			}
+		})
+	}
+}
+
+// TestMsgPackDecode tests decoding of types the encoder does not write
+func TestMsgPackDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{name: "Float32 integral", input: "ca40000000", expected: int64(2)},
+		{name: "Float32 fraction", input: "ca3fc00000", expected: 1.5},
+		{name: "Bin8", input: "c4020102", expected: "\x01\x02"},
+		{name: "Str16", input: "da000161", expected: "a"},
+		{name: "Map16", input: "de0001a1610a", expected: map[string]any{"a": int64(10)}},
+		{name: "Array32", input: "dd00000001c3", expected: []any{true}},
+		{name: "Signed int16", input: "d10064", expected: int64(100)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _ := hex.DecodeString(tt.input)
+			value, err := (MsgPackEncoder{}).Decode(bytes.NewReader(input))
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if !reflect.DeepEqual(value, tt.expected) {
+				t.Errorf("Expected %#v, got %#v", tt.expected, value)
+			}
+		})
+	}
+}
+
+// TestMsgPackDecodeErrors tests malformed MessagePack input
+func TestMsgPackDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		errorContains string
+	}{
+		{name: "Truncated map", input: "81a161", errorContains: io.ErrUnexpectedEOF.Error()},
+		{name: "Truncated uint", input: "cd00", errorContains: io.ErrUnexpectedEOF.Error()},
+		{name: "Truncated string", input: "a36162", errorContains: io.ErrUnexpectedEOF.Error()},
+		{name: "Integer key", input: "810101", errorContains: "map key must be a string"},
+		{name: "Extension", input: "d40100", errorContains: "unsupported type byte 0xd4"},
+		{name: "Nesting", input: strings.Repeat("91", maxDecodeDepth+2), errorContains: "maximum nesting depth"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _ := hex.DecodeString(tt.input)
+			_, err := (MsgPackEncoder{}).Decode(bytes.NewReader(input))
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing '%s', got %v", tt.errorContains, err)
+			}
+		})
+	}
+
+	t.Run("Empty input", func(t *testing.T) {
+		if _, err := (MsgPackEncoder{}).Decode(bytes.NewReader(nil)); !errors.Is(err, io.EOF) {
+			t.Errorf("Expected io.EOF, got %v", err)
+		}
+	})
+}
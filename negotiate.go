@@ -0,0 +1,169 @@
+package goresponse
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LanguagePreference is a language range of an Accept-Language header with its quality value
+type LanguagePreference struct {
+	Range   string  // Language range such as "pt-BR" or "*"
+	Quality float64 // Quality value between 0 and 1, 1 when the header does not specify one
+}
+
+// ParseAcceptLanguage parses an RFC 9110 Accept-Language header
+// Preferences are sorted by quality, keeping header order for equal qualities
+// Malformed ranges and quality values are skipped
+func ParseAcceptLanguage(header string) []LanguagePreference {
+	var preferences []LanguagePreference
+	for _, element := range strings.Split(header, ",") {
+		parts := strings.Split(element, ";")
+		languageRange := strings.TrimSpace(parts[0])
+		if !isLanguageRange(languageRange) {
+			continue
+		}
+
+		preference := LanguagePreference{Range: languageRange, Quality: 1}
+		valid := true
+		for _, param := range parts[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if !strings.EqualFold(strings.TrimSpace(name), "q") {
+				continue
+			}
+			quality, ok := parseQuality(strings.TrimSpace(value))
+			if !ok {
+				valid = false
+				break
+			}
+			preference.Quality = quality
+		}
+		if valid {
+			preferences = append(preferences, preference)
+		}
+	}
+
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].Quality > preferences[j].Quality
+	})
+	return preferences
+}
+
+// NegotiateLanguage picks the configured language that best matches an Accept-Language header
+// Ranges are tried by quality and matched along their language chain (zh-Hant-TW -> zh-Hant -> zh, plus LanguageFallbacks),
+// then against more specific configured languages ("en" matches "en-US")
+// Languages excluded with q=0 are never picked, "*" prefers the default language
+// The default language is returned when nothing matches
+func NegotiateLanguage(header string, cfg *ResponseConfig) string {
+	if cfg == nil {
+		return ""
+	}
+	if lang, matched := negotiateLanguage(header, cfg); matched {
+		return lang
+	}
+	return cfg.GetDefaultLanguage()
+}
+
+// negotiateLanguage matches an Accept-Language value against the configured languages
+// The second return value is false when no configured language matches
+func negotiateLanguage(header string, cfg *ResponseConfig) (string, bool) {
+	supported := cfg.GetSupportedLanguages()
+	if len(supported) == 0 && cfg.GetDefaultLanguage() != "" {
+		supported = []string{cfg.GetDefaultLanguage()}
+	}
+
+	preferences := ParseAcceptLanguage(header)
+	var excluded []string
+	for _, preference := range preferences {
+		if preference.Quality == 0 && preference.Range != "*" {
+			excluded = append(excluded, preference.Range)
+		}
+	}
+	acceptable := func(lang string) bool {
+		for _, languageRange := range excluded {
+			if matchesLanguageRange(languageRange, lang) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, preference := range preferences {
+		if preference.Quality == 0 {
+			break
+		}
+
+		if preference.Range == "*" {
+			candidates := append([]string{cfg.GetDefaultLanguage()}, supported...)
+			for _, lang := range candidates {
+				if lang != "" && containsLanguage(supported, lang) && acceptable(lang) {
+					return lang, true
+				}
+			}
+			continue
+		}
+
+		for _, tag := range cfg.languageChain(preference.Range, false) {
+			for _, lang := range supported {
+				if sameLanguage(lang, tag) && acceptable(lang) {
+					return lang, true
+				}
+			}
+		}
+		for _, lang := range supported {
+			if matchesLanguageRange(preference.Range, lang) && acceptable(lang) {
+				return lang, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// matchesLanguageRange reports whether a tag matches a language range using RFC 4647 basic filtering
+// "de" matches "de", "de-DE" and "de-CH-1996" but not "den"
+func matchesLanguageRange(languageRange, tag string) bool {
+	if languageRange == "*" || sameLanguage(languageRange, tag) {
+		return true
+	}
+	prefix := strings.ToLower(strings.ReplaceAll(languageRange, "_", "-")) + "-"
+	return strings.HasPrefix(strings.ToLower(strings.ReplaceAll(tag, "_", "-")), prefix)
+}
+
+// containsLanguage reports whether a language is in a list of languages
+func containsLanguage(languages []string, lang string) bool {
+	for _, candidate := range languages {
+		if sameLanguage(candidate, lang) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLanguageRange reports whether s is "*" or subtags of 1-8 letters and digits, the first made of letters
+func isLanguageRange(s string) bool {
+	if s == "*" {
+		return true
+	}
+	for i, subtag := range strings.Split(s, "-") {
+		if subtag == "" || len(subtag) > 8 || !isAlphanumeric(subtag) || (i == 0 && !isAlpha(subtag)) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseQuality parses an RFC 9110 quality value: 0 or 1 with up to three decimals
+func parseQuality(value string) (float64, bool) {
+	if value == "" || len(value) > 5 || (value[0] != '0' && value[0] != '1') {
+		return 0, false
+	}
+	if len(value) > 1 && (value[1] != '.' || !isDigits(value[2:])) {
+		return 0, false
+	}
+	quality, err := strconv.ParseFloat(value, 64)
+	if err != nil || quality > 1 {
+		return 0, false
+	}
+	return quality, true
+}
@@ -0,0 +1,98 @@
+package goresponse
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseAcceptLanguage tests Accept-Language parsing and ordering
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected []LanguagePreference
+	}{
+		{name: "Empty", header: "", expected: nil},
+		{name: "Single", header: "id-ID", expected: []LanguagePreference{{Range: "id-ID", Quality: 1}}},
+		{
+			name:   "Sorted by quality",
+			header: "en;q=0.5, id-ID, id;q=0.9, *;q=0.1",
+			expected: []LanguagePreference{
+				{Range: "id-ID", Quality: 1},
+				{Range: "id", Quality: 0.9},
+				{Range: "en", Quality: 0.5},
+				{Range: "*", Quality: 0.1},
+			},
+		},
+		{
+			name:     "Equal quality keeps order",
+			header:   "fr , de ;Q=1",
+			expected: []LanguagePreference{{Range: "fr", Quality: 1}, {Range: "de", Quality: 1}},
+		},
+		{
+			name:     "Malformed entries skipped",
+			header:   "en;q=2, de;q=abc, fr;q=0.1234, 123, es_ES, , it;q=1.000, pt;q=0",
+			expected: []LanguagePreference{{Range: "it", Quality: 1}, {Range: "pt", Quality: 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ParseAcceptLanguage(tt.header); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestNegotiateLanguage tests matching Accept-Language headers against configured languages
+func TestNegotiateLanguage(t *testing.T) {
+	config := &ResponseConfig{
+		DefaultLanguage:   "en",
+		Languages:         []string{"en", "id", "pt-BR", "zh-Hant", "zh-Hans", "de-CH"},
+		LanguageFallbacks: map[string][]string{"ms": {"id"}},
+	}
+
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "Empty header", header: "", expected: "en"},
+		{name: "Exact match", header: "id", expected: "id"},
+		{name: "Case-insensitive match", header: "PT-br", expected: "pt-BR"},
+		{name: "Region truncated", header: "id-ID", expected: "id"},
+		{name: "Script truncated", header: "zh-Hant-TW", expected: "zh-Hant"},
+		{name: "Region implies script", header: "zh-CN", expected: "zh-Hans"},
+		{name: "Range matches more specific language", header: "pt", expected: "pt-BR"},
+		{name: "Configured fallback", header: "ms-MY", expected: "id"},
+		{name: "Quality order", header: "fr;q=0.9, de;q=0.8, id;q=0.7", expected: "de-CH"},
+		{name: "Higher quality wins over order", header: "en;q=0.3, id", expected: "id"},
+		{name: "Wildcard prefers default", header: "fr, *;q=0.5", expected: "en"},
+		{name: "Wildcard with default excluded", header: "*, en;q=0", expected: "id"},
+		{name: "Exclusion", header: "pt, pt-BR;q=0, id;q=0.5", expected: "id"},
+		{name: "No match uses default", header: "fr, ja;q=0.8", expected: "en"},
+		{name: "Malformed header uses default", header: ";;,=", expected: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := NegotiateLanguage(tt.header, config); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+
+	t.Run("Without configured languages", func(t *testing.T) {
+		config := &ResponseConfig{DefaultLanguage: "id"}
+		if result := NegotiateLanguage("id-ID, en", config); result != "id" {
+			t.Errorf("Expected the default language to be matched, got %q", result)
+		}
+	})
+
+	t.Run("Nil config", func(t *testing.T) {
+		if result := NegotiateLanguage("en", nil); result != "" {
+			t.Errorf("Expected empty language, got %q", result)
+		}
+	})
+}
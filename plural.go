@@ -0,0 +1,319 @@
+package goresponse
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CLDR plural categories
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// pluralOperands holds the CLDR plural operands of a number
+type pluralOperands struct {
+	n float64 // Absolute value
+	i int64   // Integer digits
+	v int     // Number of visible fraction digits, with trailing zeros
+}
+
+// pluralRule returns the plural category for the operands of a number
+type pluralRule func(op pluralOperands) string
+
+// pluralRules maps base languages to their CLDR cardinal plural rules
+// Languages not listed (id, ms, ja, zh, ko, th, vi, etc.) only use the "other" category
+var pluralRules = map[string]pluralRule{
+	"en": ruleOneInteger,
+	"de": ruleOneInteger,
+	"nl": ruleOneInteger,
+	"it": ruleOneInteger,
+	"sv": ruleOneInteger,
+	"da": ruleOneInteger,
+	"fi": ruleOneInteger,
+	"no": ruleOneInteger,
+	"nb": ruleOneInteger,
+	"es": ruleOne,
+	"tr": ruleOne,
+	"el": ruleOne,
+	"hu": ruleOne,
+	"fr": ruleZeroOne,
+	"pt": ruleZeroOne,
+	"hi": ruleZeroOne,
+	"bn": ruleZeroOne,
+	"ru": ruleEastSlavic,
+	"uk": ruleEastSlavic,
+	"pl": rulePolish,
+	"cs": ruleCzech,
+	"sk": ruleCzech,
+	"he": ruleHebrew,
+	"ar": ruleArabic,
+	"cy": ruleWelsh,
+}
+
+// ordinalRules maps base languages to their CLDR ordinal plural rules (1st, 2nd, 3rd)
+// Languages not listed only use the "other" category
+var ordinalRules = map[string]pluralRule{
+	"en": ordinalEnglish,
+	"fr": ordinalOne,
+	"ms": ordinalOne,
+	"vi": ordinalOne,
+	"it": ordinalItalian,
+	"sv": ordinalSwedish,
+}
+
+// PluralCategory returns the CLDR cardinal plural category of count for a language
+// count may be any integer or float type, Decimal, or a numeric string ("1.50" keeps its fraction digits)
+// Values that are not numbers return "other"
+func PluralCategory(lang string, count any) string {
+	op, ok := newPluralOperands(count)
+	if !ok {
+		return PluralOther
+	}
+
+	rule, exists := pluralRules[baseLanguage(lang)]
+	if !exists {
+		return PluralOther
+	}
+	return rule(op)
+}
+
+// OrdinalCategory returns the CLDR ordinal plural category of count for a language
+// count accepts the same values as PluralCategory
+func OrdinalCategory(lang string, count any) string {
+	op, ok := newPluralOperands(count)
+	if !ok {
+		return PluralOther
+	}
+
+	rule, exists := ordinalRules[baseLanguage(lang)]
+	if !exists {
+		return PluralOther
+	}
+	return rule(op)
+}
+
+// baseLanguage returns the lowercase primary language subtag of a language tag
+func baseLanguage(tag string) string {
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	if idx := strings.Index(tag, "-"); idx >= 0 {
+		return tag[:idx]
+	}
+	return tag
+}
+
+// newPluralOperands computes the plural operands of a numeric value
+func newPluralOperands(count any) (pluralOperands, bool) {
+	var s string
+	switch v := count.(type) {
+	case int:
+		s = strconv.FormatInt(int64(v), 10)
+	case int8:
+		s = strconv.FormatInt(int64(v), 10)
+	case int16:
+		s = strconv.FormatInt(int64(v), 10)
+	case int32:
+		s = strconv.FormatInt(int64(v), 10)
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case uint:
+		s = strconv.FormatUint(uint64(v), 10)
+	case uint8:
+		s = strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		s = strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		s = strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		s = strconv.FormatUint(v, 10)
+	case float32:
+		s = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case Decimal:
+		digits := max(v.Digits, 0)
+		s = strconv.FormatFloat(v.Value, 'f', digits, 64)
+	case string:
+		s = strings.TrimSpace(v)
+	default:
+		return pluralOperands{}, false
+	}
+
+	s = strings.TrimPrefix(s, "-")
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+		return pluralOperands{}, false
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	op := pluralOperands{n: n, v: len(fracPart)}
+	op.i, _ = strconv.ParseInt(intPart, 10, 64)
+	return op, true
+}
+
+// ruleOneInteger: one → i = 1 and v = 0 (en, de, nl, it, ...)
+func ruleOneInteger(op pluralOperands) string {
+	if op.i == 1 && op.v == 0 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// ruleOne: one → n = 1 (es, tr, el, hu)
+func ruleOne(op pluralOperands) string {
+	if op.n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// ruleZeroOne: one → i = 0,1 (fr, pt, hi, bn)
+func ruleZeroOne(op pluralOperands) string {
+	if op.i == 0 || op.i == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// ruleEastSlavic: one/few/many by the last digits of integers (ru, uk)
+func ruleEastSlavic(op pluralOperands) string {
+	if op.v != 0 {
+		return PluralOther
+	}
+	mod10, mod100 := op.i%10, op.i%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// rulePolish: one → 1, few → 2-4 except 12-14, many → other integers (pl)
+func rulePolish(op pluralOperands) string {
+	if op.v != 0 {
+		return PluralOther
+	}
+	mod10, mod100 := op.i%10, op.i%100
+	switch {
+	case op.i == 1:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// ruleCzech: one → 1, few → 2-4, many → fractions (cs, sk)
+func ruleCzech(op pluralOperands) string {
+	switch {
+	case op.v != 0:
+		return PluralMany
+	case op.i == 1:
+		return PluralOne
+	case op.i >= 2 && op.i <= 4:
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+// ruleHebrew: one → 1 (or 0.x), two → 2 (he)
+func ruleHebrew(op pluralOperands) string {
+	switch {
+	case (op.i == 1 && op.v == 0) || (op.i == 0 && op.v != 0):
+		return PluralOne
+	case op.i == 2 && op.v == 0:
+		return PluralTwo
+	default:
+		return PluralOther
+	}
+}
+
+// ruleArabic: zero, one, two, few → n%100 = 3..10, many → n%100 = 11..99 (ar)
+func ruleArabic(op pluralOperands) string {
+	mod100 := math.Mod(op.n, 100)
+	isInt := op.n == math.Trunc(op.n)
+	switch {
+	case op.n == 0:
+		return PluralZero
+	case op.n == 1:
+		return PluralOne
+	case op.n == 2:
+		return PluralTwo
+	case isInt && mod100 >= 3 && mod100 <= 10:
+		return PluralFew
+	case isInt && mod100 >= 11 && mod100 <= 99:
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
+
+// ruleWelsh: zero → 0, one → 1, two → 2, few → 3, many → 6 (cy)
+func ruleWelsh(op pluralOperands) string {
+	switch op.n {
+	case 0:
+		return PluralZero
+	case 1:
+		return PluralOne
+	case 2:
+		return PluralTwo
+	case 3:
+		return PluralFew
+	case 6:
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
+
+// ordinalEnglish: one → 1st, two → 2nd, few → 3rd, except 11th-13th (en)
+func ordinalEnglish(op pluralOperands) string {
+	mod10, mod100 := math.Mod(op.n, 10), math.Mod(op.n, 100)
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 == 2 && mod100 != 12:
+		return PluralTwo
+	case mod10 == 3 && mod100 != 13:
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+// ordinalOne: one → n = 1 (fr "1er", ms "pertama", vi "thứ nhất")
+func ordinalOne(op pluralOperands) string {
+	if op.n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// ordinalItalian: many → 8, 11, 80, 800 (it "l'11°")
+func ordinalItalian(op pluralOperands) string {
+	switch op.n {
+	case 8, 11, 80, 800:
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
+
+// ordinalSwedish: one → n%10 = 1,2 except 11, 12 (sv "1:a", "2:a")
+func ordinalSwedish(op pluralOperands) string {
+	mod10, mod100 := math.Mod(op.n, 10), math.Mod(op.n, 100)
+	if (mod10 == 1 || mod10 == 2) && mod100 != 11 && mod100 != 12 {
+		return PluralOne
+	}
+	return PluralOther
+}
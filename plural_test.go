@@ -0,0 +1,176 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// TestPluralCategory tests PluralCategory function
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		lang     string
+		count    any
+		expected string
+	}{
+		{lang: "en", count: 1, expected: PluralOne},
+		{lang: "en", count: 0, expected: PluralOther},
+		{lang: "en", count: 2, expected: PluralOther},
+		{lang: "en", count: 1.5, expected: PluralOther},
+		{lang: "en", count: "1.0", expected: PluralOther},
+		{lang: "en", count: Decimal{Value: 1, Digits: 2}, expected: PluralOther},
+		{lang: "en-US", count: int64(1), expected: PluralOne},
+		{lang: "en", count: -1, expected: PluralOne},
+		{lang: "id", count: 1, expected: PluralOther},
+		{lang: "fr", count: 0, expected: PluralOne},
+		{lang: "fr", count: 1.5, expected: PluralOne},
+		{lang: "fr", count: 2, expected: PluralOther},
+		{lang: "pt-BR", count: 0, expected: PluralOne},
+		{lang: "es", count: 1, expected: PluralOne},
+		{lang: "ru", count: 1, expected: PluralOne},
+		{lang: "ru", count: 21, expected: PluralOne},
+		{lang: "ru", count: 11, expected: PluralMany},
+		{lang: "ru", count: 3, expected: PluralFew},
+		{lang: "ru", count: 13, expected: PluralMany},
+		{lang: "ru", count: 5, expected: PluralMany},
+		{lang: "ru", count: 2.5, expected: PluralOther},
+		{lang: "pl", count: 22, expected: PluralFew},
+		{lang: "pl", count: 21, expected: PluralMany},
+		{lang: "cs", count: 3, expected: PluralFew},
+		{lang: "cs", count: 1.5, expected: PluralMany},
+		{lang: "he", count: 2, expected: PluralTwo},
+		{lang: "ar", count: 0, expected: PluralZero},
+		{lang: "ar", count: 2, expected: PluralTwo},
+		{lang: "ar", count: 103, expected: PluralFew},
+		{lang: "ar", count: 111, expected: PluralMany},
+		{lang: "ar", count: 100, expected: PluralOther},
+		{lang: "cy", count: 6, expected: PluralMany},
+		{lang: "en", count: "abc", expected: PluralOther},
+		{lang: "en", count: true, expected: PluralOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %v", tt.lang, tt.count), func(t *testing.T) {
+			if result := PluralCategory(tt.lang, tt.count); result != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestOrdinalCategory tests OrdinalCategory function
+func TestOrdinalCategory(t *testing.T) {
+	tests := []struct {
+		lang     string
+		count    any
+		expected string
+	}{
+		{lang: "en", count: 1, expected: PluralOne},
+		{lang: "en", count: 2, expected: PluralTwo},
+		{lang: "en", count: 3, expected: PluralFew},
+		{lang: "en", count: 4, expected: PluralOther},
+		{lang: "en", count: 11, expected: PluralOther},
+		{lang: "en", count: 12, expected: PluralOther},
+		{lang: "en", count: 13, expected: PluralOther},
+		{lang: "en", count: 101, expected: PluralOne},
+		{lang: "en", count: 112, expected: PluralOther},
+		{lang: "fr", count: 1, expected: PluralOne},
+		{lang: "fr", count: 2, expected: PluralOther},
+		{lang: "it", count: 11, expected: PluralMany},
+		{lang: "sv", count: 22, expected: PluralOne},
+		{lang: "id", count: 1, expected: PluralOther},
+		{lang: "en", count: "x", expected: PluralOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %v", tt.lang, tt.count), func(t *testing.T) {
+			if result := OrdinalCategory(tt.lang, tt.count); result != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestBaseLanguage tests baseLanguage function
+func TestBaseLanguage(t *testing.T) {
+	tests := map[string]string{
+		"en":         "en",
+		"en-US":      "en",
+		"zh_Hant_TW": "zh",
+		"":           "",
+	}
+
+	for tag, expected := range tests {
+		if result := baseLanguage(tag); result != expected {
+			t.Errorf("baseLanguage(%q): expected %q, got %q", tag, expected, result)
+		}
+	}
+}
+
+// TestBuildResponsePluralVariants tests plural variant selection in BuildResponse
+func TestBuildResponsePluralVariants(t *testing.T) {
+	var config ResponseConfig
+	data := `{
+		"default_language": "en",
+		"message_templates": {
+			"items_found": {
+				"key": "items_found",
+				"template": "Found $count items",
+				"translations": {
+					"en": {"=0": "No items found", "one": "Found $count item", "other": "Found $count items"},
+					"ru": {"one": "Найден $count файл", "few": "Найдено $count файла", "many": "Найдено $count файлов"}
+				}
+			},
+			"cart": {
+				"key": "cart",
+				"template": "$n items in cart",
+				"count_param": "n"
+			}
+		},
+		"translations": {
+			"en": {"cart": {"one": "$n item in cart", "other": "$n items in cart"}},
+			"id": {"cart": "$n barang di keranjang"}
+		}
+	}`
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatalf("Failed to unmarshal config: %v", err)
+	}
+	config.AddMessageTemplate(NewMessageTemplateBuilder("messages").
+		WithTemplate("$count messages").
+		WithPluralTranslation("en", map[string]string{"one": "$count message", "other": "$count messages"}).
+		Build())
+
+	tests := []struct {
+		name     string
+		key      string
+		language string
+		params   map[string]any
+		expected string
+	}{
+		{name: "English one", key: "items_found", language: "en", params: map[string]any{"count": 1}, expected: "Found 1 item"},
+		{name: "English other", key: "items_found", language: "en", params: map[string]any{"count": 5}, expected: "Found 5 items"},
+		{name: "English exact zero", key: "items_found", language: "en", params: map[string]any{"count": 0}, expected: "No items found"},
+		{name: "English fraction", key: "items_found", language: "en", params: map[string]any{"count": 1.5}, expected: "Found 1.5 items"},
+		{name: "Russian few", key: "items_found", language: "ru", params: map[string]any{"count": 3}, expected: "Найдено 3 файла"},
+		{name: "Russian many", key: "items_found", language: "ru", params: map[string]any{"count": 11}, expected: "Найдено 11 файлов"},
+		{name: "Russian one", key: "items_found", language: "ru", params: map[string]any{"count": 21}, expected: "Найден 21 файл"},
+		{name: "Missing variant falls back to template", key: "items_found", language: "ru", params: map[string]any{"count": 2.5}, expected: "Found 2,5 items"},
+		{name: "Missing count uses other", key: "items_found", language: "en", params: map[string]any{}, expected: "Found $count items"},
+		{name: "Config variants with count param", key: "cart", language: "en", params: map[string]any{"n": 1}, expected: "1 item in cart"},
+		{name: "Config string translation", key: "cart", language: "id", params: map[string]any{"n": 1}, expected: "1 barang di keranjang"},
+		{name: "Builder plural translation", key: "messages", language: "en", params: map[string]any{"count": 1}, expected: "1 message"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewResponseBuilder(tt.key).SetLanguage(tt.language).SetParams(tt.params)
+			response, err := config.BuildResponse(builder)
+			if err != nil {
+				t.Fatalf("BuildResponse failed: %v", err)
+			}
+			if response.Message != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, response.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package goresponse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+)
+
+// MediaTypeProblemJSON is the media type of RFC 9457 problem details
+const MediaTypeProblemJSON = "application/problem+json"
+
+// ProblemTypeBlank is the default problem type, meaning the problem has no semantics beyond its HTTP status
+const ProblemTypeBlank = "about:blank"
+
+// problemMembers are the standard members of problem details, which extensions cannot redefine
+var problemMembers = []string{"type", "title", "status", "detail", "instance"}
+
+// Problem represents RFC 9457 problem details
+// Extensions are written as additional top-level members, members named like a standard member are ignored
+type Problem struct {
+	Type       string         // URI reference identifying the problem type ("about:blank" when empty)
+	Title      string         // Short summary of the problem type
+	Status     int            // HTTP status code
+	Detail     string         // Explanation specific to this occurrence (the rendered message)
+	Instance   string         // URI reference identifying this occurrence
+	Extensions map[string]any // Extension members (data and meta of the response)
+	Language   string         // Language of the title and detail (not serialized)
+}
+
+// BuildProblem builds the response and maps it to problem details
+// The type and title come from the template (ProblemType, Title, TitleTranslations),
+// the detail is the rendered message and Data and Meta become extension members (Data wins on conflicts)
+// Field errors are written as the "errors" extension
+// The status is the "http" code mapping of the template, 500 when it is not an HTTP status
+func (c *ResponseConfig) BuildProblem(rb *ResponseBuilder) (*Problem, error) {
+	response, err := c.BuildResponse(rb)
+	if err != nil {
+		return nil, err
+	}
+	return c.problem(rb.MessageKey, response), nil
+}
+
+// problem maps a response built from a template to problem details
+// A nil config or a missing template yields an "about:blank" problem titled after the status
+func (c *ResponseConfig) problem(key string, response *Response) *Problem {
+	var template *MessageTemplate
+	if c != nil {
+		template, _ = c.GetMessageTemplate(key)
+	}
+	if template == nil {
+		template = &MessageTemplate{Key: key}
+	}
+
+	status := response.Code
+	if response.Protocol != "http" {
+		status = template.CodeMappings["http"]
+	}
+	if status < 100 || status > 599 {
+		status = http.StatusInternalServerError
+	}
+
+	titleLanguage := response.Language
+	if titleLanguage == "" && c != nil {
+		titleLanguage = c.GetDefaultLanguage()
+	}
+
+	problem := &Problem{
+		Type:     template.ProblemType,
+		Title:    c.problemTitle(template, titleLanguage, status),
+		Status:   status,
+		Detail:   response.Message,
+		Language: response.Language,
+	}
+	if len(response.Data)+len(response.Meta)+len(response.FieldErrors) > 0 {
+		problem.Extensions = make(map[string]any, len(response.Data)+len(response.Meta)+1)
+		maps.Copy(problem.Extensions, response.Meta)
+		maps.Copy(problem.Extensions, response.Data)
+	}
+	if len(response.FieldErrors) > 0 {
+		problem.Extensions["errors"] = fieldErrorObjects(response.FieldErrors)
+	}
+	return problem
+}
+
+// problemTitle returns the title of a template in the language chain (including the default language),
+// falling back to Title and then to the HTTP status text
+func (c *ResponseConfig) problemTitle(template *MessageTemplate, lang string, status int) string {
+	if c != nil {
+		for _, language := range c.languageChain(lang, true) {
+			if title, exists := lookupLanguage(template.TitleTranslations, language); exists {
+				return title
+			}
+		}
+	}
+	if template.Title != "" {
+		return template.Title
+	}
+	return http.StatusText(status)
+}
+
+// Members returns the problem as it is encoded: the standard members followed by extensions in key order
+func (p *Problem) Members() Object {
+	problemType := p.Type
+	if problemType == "" {
+		problemType = ProblemTypeBlank
+	}
+
+	members := Object{{Key: "type", Value: problemType}}
+	if p.Title != "" {
+		members = append(members, Field{Key: "title", Value: p.Title})
+	}
+	if p.Status != 0 {
+		members = append(members, Field{Key: "status", Value: p.Status})
+	}
+	if p.Detail != "" {
+		members = append(members, Field{Key: "detail", Value: p.Detail})
+	}
+	if p.Instance != "" {
+		members = append(members, Field{Key: "instance", Value: p.Instance})
+	}
+	for _, key := range sortedKeys(p.Extensions) {
+		if !slices.Contains(problemMembers, key) {
+			members = append(members, Field{Key: key, Value: p.Extensions[key]})
+		}
+	}
+	return members
+}
+
+// MarshalJSON writes the problem as a JSON object
+func (p Problem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.Members())
+}
+
+// UnmarshalJSON reads a problem, members other than the standard ones are stored in Extensions
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	var members map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&members); err != nil {
+		return err
+	}
+
+	*p = Problem{}
+	for key, raw := range members {
+		value, err := normalizeValue(raw)
+		if err != nil {
+			return err
+		}
+
+		var ok bool
+		switch key {
+		case "type":
+			p.Type, ok = value.(string)
+		case "title":
+			p.Title, ok = value.(string)
+		case "detail":
+			p.Detail, ok = value.(string)
+		case "instance":
+			p.Instance, ok = value.(string)
+		case "status":
+			var status int64
+			status, ok = value.(int64)
+			p.Status = int(status)
+		default:
+			if p.Extensions == nil {
+				p.Extensions = make(map[string]any)
+			}
+			p.Extensions[key], ok = value, true
+		}
+		if !ok {
+			return fmt.Errorf("invalid problem details: %s has an invalid type %T", key, value)
+		}
+	}
+	return nil
+}
+
+// WriteTo encodes the problem as application/problem+json to w
+// When w is an http.ResponseWriter the status is set from Status, along with Content-Type and Content-Language
+func (p *Problem) WriteTo(w io.Writer) (int64, error) {
+	var body bytes.Buffer
+	if err := (JSONEncoder{}).Encode(&body, p.Members()); err != nil {
+		return 0, err
+	}
+	status := p.Status
+	if status < 100 || status > 599 {
+		status = http.StatusInternalServerError
+	}
+	writeHTTPHeader(w, MediaTypeProblemJSON, p.Language, status)
+	return body.WriteTo(w)
+}
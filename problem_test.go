@@ -0,0 +1,281 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestBuildProblem tests mapping templates and responses to problem details
+func TestBuildProblem(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"out_of_credit": *NewMessageTemplateBuilder("out_of_credit").
+				WithTemplate("Your balance is $balance, but that costs $price").
+				WithTranslation("id", "Saldo Anda $balance, tetapi harganya $price").
+				WithProblemType("https://example.com/probs/out-of-credit").
+				WithTitle("You do not have enough credit").
+				WithTitleTranslation("id", "Kredit Anda tidak cukup").
+				WithCodeMapping("http", 403).
+				WithCodeMapping("grpc", 9).
+				Build(),
+			"not_found": {
+				Key:          "not_found",
+				Template:     "User not found",
+				CodeMappings: map[string]int{"http": 404},
+			},
+			"unmapped": {
+				Key:      "unmapped",
+				Template: "Something odd",
+			},
+		},
+		DefaultLanguage:   "en",
+		LanguageFallbacks: map[string][]string{"ms": {"id"}},
+	}
+
+	tests := []struct {
+		name     string
+		builder  *ResponseBuilder
+		expected *Problem
+	}{
+		{
+			name: "Type, title and extensions",
+			builder: NewResponseBuilder("out_of_credit").SetProtocol("http").
+				SetParams(map[string]any{"balance": 30, "price": 50}).
+				SetData("balance", 30).SetMeta("balance", 0).SetMeta("accounts", []string{"/account/1"}),
+			expected: &Problem{
+				Type:       "https://example.com/probs/out-of-credit",
+				Title:      "You do not have enough credit",
+				Status:     403,
+				Detail:     "Your balance is 30, but that costs 50",
+				Extensions: map[string]any{"balance": 30, "accounts": []string{"/account/1"}},
+			},
+		},
+		{
+			name:    "Title through language fallback",
+			builder: NewResponseBuilder("out_of_credit").SetLanguage("ms").SetProtocol("http").SetParams(map[string]any{"balance": 1, "price": 2}),
+			expected: &Problem{
+				Type:     "https://example.com/probs/out-of-credit",
+				Title:    "Kredit Anda tidak cukup",
+				Status:   403,
+				Detail:   "Saldo Anda 1, tetapi harganya 2",
+				Language: "ms",
+			},
+		},
+		{
+			name:    "HTTP status from code mappings for other protocols",
+			builder: NewResponseBuilder("not_found").SetProtocol("grpc"),
+			expected: &Problem{
+				Title:  "Not Found",
+				Status: 404,
+				Detail: "User not found",
+			},
+		},
+		{
+			name:    "Unmapped status",
+			builder: NewResponseBuilder("unmapped").SetProtocol("http"),
+			expected: &Problem{
+				Title:  "Internal Server Error",
+				Status: 500,
+				Detail: "Something odd",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem, err := config.BuildProblem(tt.builder)
+			if err != nil {
+				t.Fatalf("BuildProblem failed: %v", err)
+			}
+			if !reflect.DeepEqual(problem, tt.expected) {
+				t.Errorf("Expected %#v, got %#v", tt.expected, problem)
+			}
+		})
+	}
+
+	t.Run("Missing template", func(t *testing.T) {
+		if _, err := config.BuildProblem(NewResponseBuilder("missing")); err == nil {
+			t.Error("Expected error for missing template")
+		}
+	})
+}
+
+// TestProblemJSON tests encoding and decoding problem details
+func TestProblemJSON(t *testing.T) {
+	problem := &Problem{
+		Title:      "Bad input",
+		Status:     400,
+		Detail:     "Name is required",
+		Instance:   "/users",
+		Extensions: map[string]any{"errors": []any{map[string]any{"field": "name"}}, "status": "ignored", "code": "E1"},
+		Language:   "en",
+	}
+
+	data, err := json.Marshal(problem)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	expected := `{"type":"about:blank","title":"Bad input","status":400,"detail":"Name is required","instance":"/users","code":"E1","errors":[{"field":"name"}]}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	var decoded Problem
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	want := Problem{
+		Type:       ProblemTypeBlank,
+		Title:      "Bad input",
+		Status:     400,
+		Detail:     "Name is required",
+		Instance:   "/users",
+		Extensions: map[string]any{"errors": []any{map[string]any{"field": "name"}}, "code": "E1"},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Errorf("Expected %#v, got %#v", want, decoded)
+	}
+
+	errorTests := []struct {
+		name          string
+		body          string
+		errorContains string
+	}{
+		{name: "Status type", body: `{"status":"400"}`, errorContains: "status has an invalid type"},
+		{name: "Fractional status", body: `{"status":400.5}`, errorContains: "status has an invalid type"},
+		{name: "Title type", body: `{"title":1}`, errorContains: "title has an invalid type"},
+		{name: "Not an object", body: `[]`, errorContains: "cannot unmarshal"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Problem
+			err := json.Unmarshal([]byte(tt.body), &p)
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing '%s', got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+// TestProblemWriteTo tests writing problem details to http.ResponseWriter
+func TestProblemWriteTo(t *testing.T) {
+	rec := httptest.NewRecorder()
+	problem := &Problem{Status: 422, Title: "Invalid", Language: "id"}
+	if _, err := problem.WriteTo(rec); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if rec.Code != 422 {
+		t.Errorf("Expected status 422, got %d", rec.Code)
+	}
+	if rec.Header().Get("Content-Type") != MediaTypeProblemJSON {
+		t.Errorf("Expected Content-Type %s, got %s", MediaTypeProblemJSON, rec.Header().Get("Content-Type"))
+	}
+	if rec.Header().Get("Content-Language") != "id" {
+		t.Errorf("Expected Content-Language id, got %s", rec.Header().Get("Content-Language"))
+	}
+	if rec.Body.String() != `{"type":"about:blank","title":"Invalid","status":422}`+"\n" {
+		t.Errorf("Unexpected body %q", rec.Body.String())
+	}
+}
+
+// TestWriteHTTPProblem tests that error builders are served as application/problem+json
+func TestWriteHTTPProblem(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"not_found": {
+				Key:          "not_found",
+				Template:     "User not found",
+				CodeMappings: map[string]int{"http": 404},
+			},
+		},
+		DefaultLanguage: "en",
+	}
+
+	tests := []struct {
+		name        string
+		accept      string
+		builder     *ResponseBuilder
+		status      int
+		contentType string
+		body        string
+	}{
+		{
+			name:        "Error builder",
+			builder:     NewResponseBuilder("not_found").SetError(errors.New("no rows")).SetData("id", 7),
+			status:      404,
+			contentType: MediaTypeProblemJSON,
+			body:        `{"type":"about:blank","title":"Not Found","status":404,"detail":"User not found","instance":"/users/7","id":7}`,
+		},
+		{
+			name:        "Problem JSON accepted",
+			accept:      "application/problem+json, application/xml;q=0.5",
+			builder:     NewResponseBuilder("not_found").SetError(errors.New("no rows")),
+			status:      404,
+			contentType: MediaTypeProblemJSON,
+			body:        `{"type":"about:blank","title":"Not Found","status":404,"detail":"User not found","instance":"/users/7"}`,
+		},
+		{
+			name:        "Other encoders keep the envelope",
+			accept:      "application/xml",
+			builder:     NewResponseBuilder("not_found").SetError(errors.New("no rows")),
+			status:      404,
+			contentType: "application/xml; charset=utf-8",
+			body:        xmlHeaderLine + `<response><code type="number">404</code><message>User not found</message></response>`,
+		},
+		{
+			name:        "Success responses are not problems",
+			accept:      MediaTypeProblemJSON,
+			builder:     NewResponseBuilder("not_found"),
+			status:      404,
+			contentType: ContentTypeJSON,
+			body:        `{"code":404,"message":"User not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users/7?expand=true", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			if err := WriteHTTP(rec, req, config, tt.builder); err != nil {
+				t.Fatalf("WriteHTTP failed: %v", err)
+			}
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if rec.Header().Get("Content-Type") != tt.contentType {
+				t.Errorf("Expected Content-Type %s, got %s", tt.contentType, rec.Header().Get("Content-Type"))
+			}
+			if body := strings.TrimSpace(rec.Body.String()); body != tt.body {
+				t.Errorf("Expected body %s, got %s", tt.body, body)
+			}
+		})
+	}
+}
+
+// TestMessageTemplateProblemFields tests loading problem fields from JSON
+func TestMessageTemplateProblemFields(t *testing.T) {
+	var template MessageTemplate
+	data := `{"key":"k","template":"t","code_mappings":{"http":409},"problem_type":"https://example.com/conflict","title":"Conflict","title_translations":{"id":"Konflik"}}`
+	if err := json.Unmarshal([]byte(data), &template); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if template.ProblemType != "https://example.com/conflict" || template.Title != "Conflict" || template.TitleTranslations["id"] != "Konflik" {
+		t.Errorf("Unexpected problem fields %+v", template)
+	}
+
+	encoded, err := json.Marshal(template)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"problem_type":"https://example.com/conflict","title":"Conflict","title_translations":{"id":"Konflik"}`) {
+		t.Errorf("Expected problem fields in %s", encoded)
+	}
+}
@@ -0,0 +1,329 @@
+package goresponse
+
+import (
+	"strings"
+	"time"
+)
+
+// Relative is a time parameter rendered as a phrase relative to now ("3 minutes ago", "dalam 2 hari")
+type Relative struct {
+	Time   time.Time     // Absolute time compared with the reference now
+	Offset time.Duration // Offset from now, used when Time is zero (negative values are in the past)
+}
+
+// RelativeTime creates a Relative parameter for an absolute time
+func RelativeTime(t time.Time) Relative {
+	return Relative{Time: t}
+}
+
+// RelativeDuration creates a Relative parameter for an offset from now
+func RelativeDuration(d time.Duration) Relative {
+	return Relative{Offset: d}
+}
+
+// RelativeTimeData holds localized phrases for relative times and durations
+// Phrases are keyed by unit (second, minute, hour, day, week, month, year) and then by plural category
+// "{0}" is replaced with the localized number
+type RelativeTimeData struct {
+	Now      string                       // Phrase used when the offset is zero
+	Future   map[string]map[string]string // "in {0} days"
+	Past     map[string]map[string]string // "{0} days ago"
+	Duration map[string]map[string]string // "{0} days"
+}
+
+// relativeUnit is a time unit used in relative phrases
+type relativeUnit struct {
+	name string
+	size time.Duration
+}
+
+// relativeUnits lists the units from largest to smallest
+var relativeUnits = []relativeUnit{
+	{name: "year", size: 365 * 24 * time.Hour},
+	{name: "month", size: 30 * 24 * time.Hour},
+	{name: "week", size: 7 * 24 * time.Hour},
+	{name: "day", size: 24 * time.Hour},
+	{name: "hour", size: time.Hour},
+	{name: "minute", size: time.Minute},
+	{name: "second", size: time.Second},
+}
+
+// relativePhrases builds RelativeTimeData from future/past patterns and unit names
+// Patterns contain {0} for the number and {unit} for the unit name; durations use "{0} {unit}" with durationNames
+func relativePhrases(now, future, past, duration string, names, durationNames map[string]map[string]string) *RelativeTimeData {
+	data := &RelativeTimeData{
+		Now:      now,
+		Future:   make(map[string]map[string]string),
+		Past:     make(map[string]map[string]string),
+		Duration: make(map[string]map[string]string),
+	}
+	if durationNames == nil {
+		durationNames = names
+	}
+
+	for unit, forms := range names {
+		data.Future[unit] = make(map[string]string)
+		data.Past[unit] = make(map[string]string)
+		for category, name := range forms {
+			data.Future[unit][category] = strings.Replace(future, "{unit}", name, 1)
+			data.Past[unit][category] = strings.Replace(past, "{unit}", name, 1)
+		}
+	}
+	for unit, forms := range durationNames {
+		data.Duration[unit] = make(map[string]string)
+		for category, name := range forms {
+			data.Duration[unit][category] = strings.Replace(duration, "{unit}", name, 1)
+		}
+	}
+	return data
+}
+
+// formsOther creates unit forms for languages without plural distinctions
+func formsOther(name string) map[string]string {
+	return map[string]string{PluralOther: name}
+}
+
+// formsOneOther creates unit forms for languages with singular and plural forms
+func formsOneOther(one, many string) map[string]string {
+	return map[string]string{PluralOne: one, PluralOther: many}
+}
+
+// formsOneFewMany creates unit forms for languages with one, few and many forms
+func formsOneFewMany(one, few, many string) map[string]string {
+	return map[string]string{PluralOne: one, PluralFew: few, PluralMany: many, PluralOther: few}
+}
+
+var (
+	enRelative = relativePhrases("now", "in {0} {unit}", "{0} {unit} ago", "{0} {unit}", map[string]map[string]string{
+		"second": formsOneOther("second", "seconds"),
+		"minute": formsOneOther("minute", "minutes"),
+		"hour":   formsOneOther("hour", "hours"),
+		"day":    formsOneOther("day", "days"),
+		"week":   formsOneOther("week", "weeks"),
+		"month":  formsOneOther("month", "months"),
+		"year":   formsOneOther("year", "years"),
+	}, nil)
+	idRelative = relativePhrases("sekarang", "dalam {0} {unit}", "{0} {unit} yang lalu", "{0} {unit}", map[string]map[string]string{
+		"second": formsOther("detik"),
+		"minute": formsOther("menit"),
+		"hour":   formsOther("jam"),
+		"day":    formsOther("hari"),
+		"week":   formsOther("minggu"),
+		"month":  formsOther("bulan"),
+		"year":   formsOther("tahun"),
+	}, nil)
+	msRelative = relativePhrases("sekarang", "dalam {0} {unit}", "{0} {unit} lalu", "{0} {unit}", map[string]map[string]string{
+		"second": formsOther("saat"),
+		"minute": formsOther("minit"),
+		"hour":   formsOther("jam"),
+		"day":    formsOther("hari"),
+		"week":   formsOther("minggu"),
+		"month":  formsOther("bulan"),
+		"year":   formsOther("tahun"),
+	}, nil)
+	deRelative = relativePhrases("jetzt", "in {0} {unit}", "vor {0} {unit}", "{0} {unit}", map[string]map[string]string{
+		"second": formsOneOther("Sekunde", "Sekunden"),
+		"minute": formsOneOther("Minute", "Minuten"),
+		"hour":   formsOneOther("Stunde", "Stunden"),
+		"day":    formsOneOther("Tag", "Tagen"),
+		"week":   formsOneOther("Woche", "Wochen"),
+		"month":  formsOneOther("Monat", "Monaten"),
+		"year":   formsOneOther("Jahr", "Jahren"),
+	}, map[string]map[string]string{
+		"second": formsOneOther("Sekunde", "Sekunden"),
+		"minute": formsOneOther("Minute", "Minuten"),
+		"hour":   formsOneOther("Stunde", "Stunden"),
+		"day":    formsOneOther("Tag", "Tage"),
+		"week":   formsOneOther("Woche", "Wochen"),
+		"month":  formsOneOther("Monat", "Monate"),
+		"year":   formsOneOther("Jahr", "Jahre"),
+	})
+	frRelative = relativePhrases("maintenant", "dans {0} {unit}", "il y a {0} {unit}", "{0} {unit}", map[string]map[string]string{
+		"second": formsOneOther("seconde", "secondes"),
+		"minute": formsOneOther("minute", "minutes"),
+		"hour":   formsOneOther("heure", "heures"),
+		"day":    formsOneOther("jour", "jours"),
+		"week":   formsOneOther("semaine", "semaines"),
+		"month":  formsOneOther("mois", "mois"),
+		"year":   formsOneOther("an", "ans"),
+	}, nil)
+	esRelative = relativePhrases("ahora", "dentro de {0} {unit}", "hace {0} {unit}", "{0} {unit}", map[string]map[string]string{
+		"second": formsOneOther("segundo", "segundos"),
+		"minute": formsOneOther("minuto", "minutos"),
+		"hour":   formsOneOther("hora", "horas"),
+		"day":    formsOneOther("día", "días"),
+		"week":   formsOneOther("semana", "semanas"),
+		"month":  formsOneOther("mes", "meses"),
+		"year":   formsOneOther("año", "años"),
+	}, nil)
+	ptRelative = relativePhrases("agora", "em {0} {unit}", "há {0} {unit}", "{0} {unit}", map[string]map[string]string{
+		"second": formsOneOther("segundo", "segundos"),
+		"minute": formsOneOther("minuto", "minutos"),
+		"hour":   formsOneOther("hora", "horas"),
+		"day":    formsOneOther("dia", "dias"),
+		"week":   formsOneOther("semana", "semanas"),
+		"month":  formsOneOther("mês", "meses"),
+		"year":   formsOneOther("ano", "anos"),
+	}, nil)
+	itRelative = relativePhrases("ora", "tra {0} {unit}", "{0} {unit} fa", "{0} {unit}", map[string]map[string]string{
+		"second": formsOneOther("secondo", "secondi"),
+		"minute": formsOneOther("minuto", "minuti"),
+		"hour":   formsOneOther("ora", "ore"),
+		"day":    formsOneOther("giorno", "giorni"),
+		"week":   formsOneOther("settimana", "settimane"),
+		"month":  formsOneOther("mese", "mesi"),
+		"year":   formsOneOther("anno", "anni"),
+	}, nil)
+	nlRelative = relativePhrases("nu", "over {0} {unit}", "{0} {unit} geleden", "{0} {unit}", map[string]map[string]string{
+		"second": formsOneOther("seconde", "seconden"),
+		"minute": formsOneOther("minuut", "minuten"),
+		"hour":   formsOneOther("uur", "uur"),
+		"day":    formsOneOther("dag", "dagen"),
+		"week":   formsOneOther("week", "weken"),
+		"month":  formsOneOther("maand", "maanden"),
+		"year":   formsOneOther("jaar", "jaar"),
+	}, nil)
+	ruRelative = relativePhrases("сейчас", "через {0} {unit}", "{0} {unit} назад", "{0} {unit}", map[string]map[string]string{
+		"second": formsOneFewMany("секунду", "секунды", "секунд"),
+		"minute": formsOneFewMany("минуту", "минуты", "минут"),
+		"hour":   formsOneFewMany("час", "часа", "часов"),
+		"day":    formsOneFewMany("день", "дня", "дней"),
+		"week":   formsOneFewMany("неделю", "недели", "недель"),
+		"month":  formsOneFewMany("месяц", "месяца", "месяцев"),
+		"year":   formsOneFewMany("год", "года", "лет"),
+	}, map[string]map[string]string{
+		"second": formsOneFewMany("секунда", "секунды", "секунд"),
+		"minute": formsOneFewMany("минута", "минуты", "минут"),
+		"hour":   formsOneFewMany("час", "часа", "часов"),
+		"day":    formsOneFewMany("день", "дня", "дней"),
+		"week":   formsOneFewMany("неделя", "недели", "недель"),
+		"month":  formsOneFewMany("месяц", "месяца", "месяцев"),
+		"year":   formsOneFewMany("год", "года", "лет"),
+	})
+	trRelative = relativePhrases("şimdi", "{0} {unit} sonra", "{0} {unit} önce", "{0} {unit}", map[string]map[string]string{
+		"second": formsOther("saniye"),
+		"minute": formsOther("dakika"),
+		"hour":   formsOther("saat"),
+		"day":    formsOther("gün"),
+		"week":   formsOther("hafta"),
+		"month":  formsOther("ay"),
+		"year":   formsOther("yıl"),
+	}, nil)
+	viRelative = relativePhrases("bây giờ", "sau {0} {unit} nữa", "{0} {unit} trước", "{0} {unit}", map[string]map[string]string{
+		"second": formsOther("giây"),
+		"minute": formsOther("phút"),
+		"hour":   formsOther("giờ"),
+		"day":    formsOther("ngày"),
+		"week":   formsOther("tuần"),
+		"month":  formsOther("tháng"),
+		"year":   formsOther("năm"),
+	}, nil)
+	thRelative = relativePhrases("ขณะนี้", "ในอีก {0} {unit}", "{0} {unit}ที่ผ่านมา", "{0} {unit}", map[string]map[string]string{
+		"second": formsOther("วินาที"),
+		"minute": formsOther("นาที"),
+		"hour":   formsOther("ชั่วโมง"),
+		"day":    formsOther("วัน"),
+		"week":   formsOther("สัปดาห์"),
+		"month":  formsOther("เดือน"),
+		"year":   formsOther("ปี"),
+	}, nil)
+	jaRelative = relativePhrases("今", "{0} {unit}後", "{0} {unit}前", "{0} {unit}", map[string]map[string]string{
+		"second": formsOther("秒"),
+		"minute": formsOther("分"),
+		"hour":   formsOther("時間"),
+		"day":    formsOther("日"),
+		"week":   formsOther("週間"),
+		"month":  formsOther("か月"),
+		"year":   formsOther("年"),
+	}, nil)
+	zhRelative = relativePhrases("现在", "{0}{unit}后", "{0}{unit}前", "{0}{unit}", map[string]map[string]string{
+		"second": formsOther("秒钟"),
+		"minute": formsOther("分钟"),
+		"hour":   formsOther("小时"),
+		"day":    formsOther("天"),
+		"week":   formsOther("周"),
+		"month":  formsOther("个月"),
+		"year":   formsOther("年"),
+	}, nil)
+	koRelative = relativePhrases("지금", "{0}{unit} 후", "{0}{unit} 전", "{0}{unit}", map[string]map[string]string{
+		"second": formsOther("초"),
+		"minute": formsOther("분"),
+		"hour":   formsOther("시간"),
+		"day":    formsOther("일"),
+		"week":   formsOther("주"),
+		"month":  formsOther("개월"),
+		"year":   formsOther("년"),
+	}, nil)
+)
+
+// FormatRelative renders t as a phrase relative to the formatter's reference now
+func (f *Formatter) FormatRelative(t time.Time) string {
+	return f.FormatRelativeDuration(t.Sub(f.now()))
+}
+
+// FormatRelativeDuration renders an offset from now as a relative phrase
+// Positive offsets are in the future ("in 2 days"), negative offsets in the past ("2 days ago")
+func (f *Formatter) FormatRelativeDuration(d time.Duration) string {
+	data, language := f.relativeData()
+	unit, count := relativeAmount(d)
+	if count == 0 {
+		return data.Now
+	}
+
+	phrases := data.Future
+	if d < 0 {
+		phrases = data.Past
+	}
+	return f.relativePhrase(phrases[unit], language, count)
+}
+
+// FormatDuration renders a duration as a rounded amount of its largest unit ("2 hours", "3 hari")
+func (f *Formatter) FormatDuration(d time.Duration) string {
+	data, language := f.relativeData()
+	unit, count := relativeAmount(d)
+	return f.relativePhrase(data.Duration[unit], language, count)
+}
+
+// relativePhrase selects the plural form for count and inserts the localized number
+func (f *Formatter) relativePhrase(forms map[string]string, language string, count int64) string {
+	phrase, exists := forms[PluralCategory(language, count)]
+	if !exists {
+		phrase = forms[PluralOther]
+	}
+	return strings.Replace(phrase, "{0}", f.FormatInt(count), 1)
+}
+
+// relativeData returns the relative time phrases of the formatter's locale and the language they are in
+// Languages without phrases use English
+func (f *Formatter) relativeData() (*RelativeTimeData, string) {
+	if f != nil && f.Locale != nil && f.Locale.RelativeTime != nil {
+		return f.Locale.RelativeTime, f.Language
+	}
+	return enRelative, "en"
+}
+
+// now returns the formatter's reference time, or the current time when not set
+func (f *Formatter) now() time.Time {
+	if f != nil && !f.Now.IsZero() {
+		return f.Now
+	}
+	return time.Now()
+}
+
+// relativeAmount returns the largest unit reached by d and the amount of d rounded to that unit
+// The unit is picked after rounding d to the next smaller unit, so 59.6 minutes is 1 hour
+func relativeAmount(d time.Duration) (string, int64) {
+	if d < 0 {
+		d = -d
+	}
+	for i, unit := range relativeUnits {
+		rounded := d
+		if i+1 < len(relativeUnits) {
+			rounded = d.Round(relativeUnits[i+1].size)
+		}
+		if rounded >= unit.size {
+			return unit.name, int64(d.Round(unit.size) / unit.size)
+		}
+	}
+	return "second", 0
+}
@@ -0,0 +1,221 @@
+package goresponse
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestFormatRelative tests Formatter relative time rendering
+func TestFormatRelative(t *testing.T) {
+	now := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		language string
+		value    any
+		expected string
+	}{
+		{
+			name:     "English minutes ago",
+			language: "en",
+			value:    RelativeTime(now.Add(-3 * time.Minute)),
+			expected: "3 minutes ago",
+		},
+		{
+			name:     "English singular",
+			language: "en",
+			value:    RelativeTime(now.Add(-time.Hour)),
+			expected: "1 hour ago",
+		},
+		{
+			name:     "English future",
+			language: "en",
+			value:    RelativeDuration(2 * 24 * time.Hour),
+			expected: "in 2 days",
+		},
+		{
+			name:     "English now",
+			language: "en",
+			value:    RelativeTime(now.Add(500 * time.Millisecond)),
+			expected: "now",
+		},
+		{
+			name:     "Indonesian future",
+			language: "id",
+			value:    RelativeTime(now.Add(2 * 24 * time.Hour)),
+			expected: "dalam 2 hari",
+		},
+		{
+			name:     "Indonesian past",
+			language: "id",
+			value:    RelativeDuration(-3 * time.Minute),
+			expected: "3 menit yang lalu",
+		},
+		{
+			name:     "German dative plural",
+			language: "de",
+			value:    RelativeDuration(-3 * 24 * time.Hour),
+			expected: "vor 3 Tagen",
+		},
+		{
+			name:     "Russian few",
+			language: "ru",
+			value:    RelativeDuration(-2 * time.Minute),
+			expected: "2 минуты назад",
+		},
+		{
+			name:     "Russian many",
+			language: "ru",
+			value:    RelativeDuration(5 * time.Minute),
+			expected: "через 5 минут",
+		},
+		{
+			name:     "Weeks",
+			language: "en",
+			value:    RelativeDuration(-15 * 24 * time.Hour),
+			expected: "2 weeks ago",
+		},
+		{
+			name:     "Years",
+			language: "en",
+			value:    RelativeTime(now.AddDate(-2, 0, -1)),
+			expected: "2 years ago",
+		},
+		{
+			name:     "Unknown language uses English",
+			language: "xx",
+			value:    RelativeDuration(time.Second),
+			expected: "in 1 second",
+		},
+		{
+			name:     "Duration English",
+			language: "en",
+			value:    90 * time.Minute,
+			expected: "2 hours",
+		},
+		{
+			name:     "Just under a day",
+			language: "en",
+			value:    RelativeDuration(24*time.Hour - time.Millisecond),
+			expected: "in 1 day",
+		},
+		{
+			name:     "Just under an hour",
+			language: "en",
+			value:    RelativeDuration(-(59*time.Minute + 36*time.Second)),
+			expected: "1 hour ago",
+		},
+		{
+			name:     "Just under a minute",
+			language: "en",
+			value:    59*time.Second + 600*time.Millisecond,
+			expected: "1 minute",
+		},
+		{
+			name:     "Rounded days",
+			language: "en",
+			value:    RelativeDuration(47 * time.Hour),
+			expected: "in 2 days",
+		},
+		{
+			name:     "Rounded down",
+			language: "en",
+			value:    RelativeDuration(-(2*time.Hour + 20*time.Minute)),
+			expected: "2 hours ago",
+		},
+		{
+			name:     "Just under a week",
+			language: "en",
+			value:    RelativeDuration(6*24*time.Hour + 23*time.Hour),
+			expected: "in 1 week",
+		},
+		{
+			name:     "Duration German nominative",
+			language: "de",
+			value:    3 * 24 * time.Hour,
+			expected: "3 Tage",
+		},
+		{
+			name:     "Duration Russian",
+			language: "ru",
+			value:    21 * time.Minute,
+			expected: "21 минута",
+		},
+		{
+			name:     "Zero duration",
+			language: "en",
+			value:    time.Duration(0),
+			expected: "0 seconds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewFormatter(tt.language).WithNow(now).Format(tt.value)
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestNilFormatterDuration tests that a nil Formatter keeps fmt's duration format
+func TestNilFormatterDuration(t *testing.T) {
+	var formatter *Formatter
+	if result := formatter.Format(90 * time.Minute); result != "1h30m0s" {
+		t.Errorf("Expected '1h30m0s', got '%s'", result)
+	}
+}
+
+// TestBuildResponseRelativeTime tests relative time parameters in BuildResponse
+func TestBuildResponseRelativeTime(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"token_expiry": {
+				Key:      "token_expiry",
+				Template: "Token expires $expires, last login $login",
+				Translations: map[string]string{
+					"id": "Token kedaluwarsa $expires, login terakhir $login",
+				},
+			},
+		},
+		DefaultLanguage: "en",
+	}
+
+	now := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Reference time from builder", func(t *testing.T) {
+		builder := NewResponseBuilder("token_expiry").
+			SetLanguage("id").
+			SetNow(now).
+			SetParam("expires", RelativeTime(now.Add(2*24*time.Hour))).
+			SetParam("login", RelativeTime(now.Add(-3*time.Minute)))
+
+		response, err := config.BuildResponse(builder)
+		if err != nil {
+			t.Fatalf("BuildResponse failed: %v", err)
+		}
+		expected := "Token kedaluwarsa dalam 2 hari, login terakhir 3 menit yang lalu"
+		if response.Message != expected {
+			t.Errorf("Expected '%s', got '%s'", expected, response.Message)
+		}
+	})
+
+	t.Run("Reference time from context", func(t *testing.T) {
+		ctx := WithNow(WithLanguage(context.Background(), "en"), now)
+		builder := NewResponseBuilder("token_expiry").
+			WithContext(ctx).
+			SetParam("expires", RelativeTime(now.Add(time.Hour))).
+			SetParam("login", RelativeTime(now.Add(-2*time.Hour)))
+
+		response, err := config.BuildResponse(builder)
+		if err != nil {
+			t.Fatalf("BuildResponse failed: %v", err)
+		}
+		expected := "Token expires in 1 hour, last login 2 hours ago"
+		if response.Message != expected {
+			t.Errorf("Expected '%s', got '%s'", expected, response.Message)
+		}
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"maps"
 	"strings"
+	"time"
 )
 
 // ResponseContextKey represents the type for context keys used in response building
@@ -22,8 +23,21 @@ type ResponseBuilder struct {
 	Context      context.Context // Context containing language and protocol info
 	Language     string          // Language code for message translation
 	Protocol     string          // Protocol type (http, grpc, etc.)
+	Location     *time.Location  // Timezone for rendering time parameters
+	Now          time.Time       // Reference time for relative time parameters, zero uses the current time
+	Timestamp    time.Time       // Build time of the envelope timestamp member, zero uses the current time
+	Register     string          // Formality register of the message (formal, informal), empty for neutral
 	ErrorData    error           // Error information if this is an error response
 	IsBuiltError bool            // Flag indicating if this builder represents an error
+	FieldErrors  []FieldError    // Errors of individual input fields, each rendered from its own template
+}
+
+// FieldError is an error of a single input field, such as a validation failure
+// The message is rendered from its own template in the language of the response builder
+type FieldError struct {
+	Field      string         `json:"field"`            // Field name, with dots separating nested fields ("address.city")
+	MessageKey string         `json:"message_key"`      // Key of the message template
+	Params     map[string]any `json:"params,omitempty"` // Parameters for template substitution
 }
 
 // Response represents the final standardized response structure
@@ -36,6 +50,20 @@ type Response struct {
 	Error    error          `json:"-"`              // Error details if applicable (not serialized)
 	Language string         `json:"-"`              // Language used (not serialized)
 	Protocol string         `json:"-"`              // Protocol used (not serialized)
+
+	FieldErrors []ResponseFieldError `json:"-"` // Rendered field errors of the builder (not serialized)
+
+	MessageKey string         `json:"-"` // Key of the message template (not serialized unless the envelope shape names it)
+	Timestamp  time.Time      `json:"-"` // Build time, set when the envelope shape names a timestamp member
+	Shape      *EnvelopeShape `json:"-"` // Shape of the encoded envelope (default shape when nil)
+}
+
+// ResponseFieldError is a field error rendered in the language of the response
+type ResponseFieldError struct {
+	Field      string // Field name, with dots separating nested fields
+	MessageKey string // Key of the message template
+	Message    string // Rendered message
+	Code       int    // Code of the field template for the response protocol
 }
 
 const (
@@ -43,6 +71,20 @@ const (
 	LanguageKey ResponseContextKey = "goresponse-language"
 	// ProtocolKey is the context key for storing protocol information
 	ProtocolKey ResponseContextKey = "goresponse-protocol"
+	// TimezoneKey is the context key for storing timezone information
+	TimezoneKey ResponseContextKey = "goresponse-timezone"
+	// NowKey is the context key for storing the reference time of relative time parameters
+	NowKey ResponseContextKey = "goresponse-now"
+	// RegisterKey is the context key for storing the formality register (formal, informal)
+	RegisterKey ResponseContextKey = "goresponse-register"
+	// RequestIDKey is the context key for storing the request ID
+	RequestIDKey ResponseContextKey = "goresponse-request-id"
+)
+
+// Common formality registers for RegisterKey and MessageTemplate.RegisterTranslations
+const (
+	RegisterFormal   = "formal"
+	RegisterInformal = "informal"
 )
 
 // NewResponseBuilder creates a new ResponseBuilder instance with the given message key
@@ -65,6 +107,29 @@ func WithLanguage(ctx context.Context, language string) context.Context {
 	return context.WithValue(ctx, LanguageKey, language)
 }
 
+// WithTimezone adds timezone information to the context
+// This is useful for rendering time parameters in the timezone of the requester
+func WithTimezone(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, TimezoneKey, loc)
+}
+
+// WithNow adds the reference time for relative time parameters to the context
+// This is useful for rendering deterministic relative phrases in tests
+func WithNow(ctx context.Context, now time.Time) context.Context {
+	return context.WithValue(ctx, NowKey, now)
+}
+
+// WithRegister adds the formality register (formal, informal) to the context
+// This is useful for addressing enterprise admins formally and end users informally
+func WithRegister(ctx context.Context, register string) context.Context {
+	return context.WithValue(ctx, RegisterKey, register)
+}
+
+// WithRequestID adds the request ID to the context
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, requestID)
+}
+
 // GetLanguageFromContext extracts language information from the context
 // Returns the language string and a boolean indicating if the language was found
 func GetLanguageFromContext(ctx context.Context) (string, bool) {
@@ -85,6 +150,53 @@ func GetProtocolFromContext(ctx context.Context) (string, bool) {
 	return protocol, ok
 }
 
+// GetTimezoneFromContext extracts timezone information from the context
+// Returns the location and a boolean indicating if the timezone was found
+func GetTimezoneFromContext(ctx context.Context) (*time.Location, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	loc, ok := ctx.Value(TimezoneKey).(*time.Location)
+	return loc, ok && loc != nil
+}
+
+// GetNowFromContext extracts the reference time for relative time parameters from the context
+// Returns the time and a boolean indicating if the reference time was found
+func GetNowFromContext(ctx context.Context) (time.Time, bool) {
+	if ctx == nil {
+		return time.Time{}, false
+	}
+	now, ok := ctx.Value(NowKey).(time.Time)
+	return now, ok
+}
+
+// GetRegisterFromContext extracts the formality register from the context
+// Returns the register string and a boolean indicating if the register was found
+func GetRegisterFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	register, ok := ctx.Value(RegisterKey).(string)
+	return register, ok
+}
+
+// GetRequestIDFromContext extracts the request ID from the context
+// Returns the request ID and a boolean indicating if the request ID was found
+func GetRequestIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	requestID, ok := ctx.Value(RequestIDKey).(string)
+	return requestID, ok
+}
+
+// GetRequestID extracts the request ID from the context
+// Returns the request ID, or empty string if not found or context is nil
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := GetRequestIDFromContext(ctx)
+	return requestID
+}
+
 // GetLanguage extracts language information from the context
 // Returns the language string, or empty string if not found or context is nil
 func GetLanguage(ctx context.Context) string {
@@ -109,7 +221,14 @@ func GetProtocol(ctx context.Context) string {
 	return ""
 }
 
-// WithContext sets the context and extracts language and protocol information if available
+// GetTimezone extracts timezone information from the context
+// Returns the location, or nil if not found or context is nil
+func GetTimezone(ctx context.Context) *time.Location {
+	loc, _ := GetTimezoneFromContext(ctx)
+	return loc
+}
+
+// WithContext sets the context and extracts language, protocol, timezone, reference time and register if available
 // This method allows the response builder to inherit language and protocol settings from the request context
 func (rb *ResponseBuilder) WithContext(ctx context.Context) *ResponseBuilder {
 	rb.Context = ctx
@@ -124,6 +243,21 @@ func (rb *ResponseBuilder) WithContext(ctx context.Context) *ResponseBuilder {
 		if proto, ok := ctx.Value(ProtocolKey).(string); ok {
 			rb.Protocol = proto
 		}
+
+		// Extract timezone from context if available
+		if loc, ok := GetTimezoneFromContext(ctx); ok {
+			rb.Location = loc
+		}
+
+		// Extract reference time from context if available
+		if now, ok := GetNowFromContext(ctx); ok {
+			rb.Now = now
+		}
+
+		// Extract formality register from context if available
+		if register, ok := GetRegisterFromContext(ctx); ok {
+			rb.Register = register
+		}
 	}
 
 	return rb
@@ -143,6 +277,34 @@ func (rb *ResponseBuilder) SetProtocol(protocol string) *ResponseBuilder {
 	return rb
 }
 
+// SetTimezone manually sets the timezone used to render time parameters
+// This overrides any timezone setting from context
+func (rb *ResponseBuilder) SetTimezone(loc *time.Location) *ResponseBuilder {
+	rb.Location = loc
+	return rb
+}
+
+// SetNow manually sets the reference time used to render relative time parameters
+// This overrides any reference time from context
+func (rb *ResponseBuilder) SetNow(now time.Time) *ResponseBuilder {
+	rb.Now = now
+	return rb
+}
+
+// SetTimestamp manually sets the build time written in the envelope timestamp member
+// It is independent of the reference time of relative time parameters
+func (rb *ResponseBuilder) SetTimestamp(timestamp time.Time) *ResponseBuilder {
+	rb.Timestamp = timestamp
+	return rb
+}
+
+// SetRegister manually sets the formality register (formal, informal) of the message
+// This overrides any register setting from context
+func (rb *ResponseBuilder) SetRegister(register string) *ResponseBuilder {
+	rb.Register = register
+	return rb
+}
+
 // SetError sets an error for this response builder and marks it as an error response
 // This will cause the final response to be treated as an error
 func (rb *ResponseBuilder) SetError(err error) *ResponseBuilder {
@@ -151,6 +313,42 @@ func (rb *ResponseBuilder) SetError(err error) *ResponseBuilder {
 	return rb
 }
 
+// AddFieldError adds an error of a single input field and marks the builder as an error response
+// The message of the field error is rendered from the messageKey template with params
+func (rb *ResponseBuilder) AddFieldError(field, messageKey string, params map[string]any) *ResponseBuilder {
+	rb.FieldErrors = append(rb.FieldErrors, FieldError{Field: field, MessageKey: messageKey, Params: params})
+	rb.IsBuiltError = true
+	return rb
+}
+
+// fieldErrorObjects returns rendered field errors as objects with field, code (the message key) and message
+func fieldErrorObjects(fieldErrors []ResponseFieldError) []Object {
+	objects := make([]Object, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		objects = append(objects, Object{
+			{Key: "field", Value: fieldError.Field},
+			{Key: "code", Value: fieldError.MessageKey},
+			{Key: "message", Value: fieldError.Message},
+		})
+	}
+	return objects
+}
+
+// fieldErrorBuilder returns a builder rendering a field error with the language, register and timezone of rb
+func (rb *ResponseBuilder) fieldErrorBuilder(fieldError FieldError) *ResponseBuilder {
+	return &ResponseBuilder{
+		MessageKey:   fieldError.MessageKey,
+		Params:       fieldError.Params,
+		Context:      rb.Context,
+		Language:     rb.Language,
+		Protocol:     rb.Protocol,
+		Location:     rb.Location,
+		Now:          rb.Now,
+		Register:     rb.Register,
+		IsBuiltError: true,
+	}
+}
+
 // SetParam adds a single parameter for template substitution
 // Parameters are used to replace placeholders like $name in message templates
 func (rb *ResponseBuilder) SetParam(key string, value any) *ResponseBuilder {
@@ -247,6 +445,104 @@ func ParseResponseBuilderError(err error) (*ResponseBuilder, bool) {
 	return nil, false
 }
 
+// resolveMessage picks the message text for the builder's language
+// Languages are tried along the language chain (zh-Hant-TW -> zh-Hant -> zh -> configured fallbacks -> default)
+// Priority per language: template register translation, template translation, Fluent catalog, config translation
+// The template default is used when no language in the chain has a translation
+// Translations with plural variants are selected by the CLDR plural category of the template's count param
+// Fluent messages are returned already rendered, reported by the second return value
+func (c *ResponseConfig) resolveMessage(template *MessageTemplate, rb *ResponseBuilder, formatter *Formatter) (string, bool) {
+	for _, lang := range c.languageChain(rb.Language, true) {
+		// Register translations fall back to the neutral translation when missing
+		if rb.Register != "" {
+			if translation, _ := lookupLanguage(template.RegisterTranslations[rb.Register], lang); translation != "" {
+				return translation, false
+			}
+			if variants, exists := lookupLanguage(template.RegisterVariants[rb.Register], lang); exists {
+				if translation, ok := selectVariant(variants, lang, rb.Params, template); ok {
+					return translation, false
+				}
+			}
+		}
+
+		if translation, _ := lookupLanguage(template.Translations, lang); translation != "" {
+			return translation, false
+		}
+		if variants, exists := lookupLanguage(template.Variants, lang); exists {
+			if translation, ok := selectVariant(variants, lang, rb.Params, template); ok {
+				return translation, false
+			}
+		}
+
+		bundle, _ := lookupLanguage(c.FluentBundles, lang)
+		if message, exists := bundle.format(rb.MessageKey, rb.Params, formatter); exists {
+			return message, true
+		}
+
+		if translations, exists := lookupLanguage(c.Translations, lang); exists {
+			if translation, exists := translations[rb.MessageKey]; exists {
+				return translation, false
+			}
+		}
+		if variants, exists := c.translationVariants(lang, rb.MessageKey); exists {
+			if translation, ok := selectVariant(variants, lang, rb.Params, template); ok {
+				return translation, false
+			}
+		}
+	}
+
+	return template.Template, false
+}
+
+// selectVariant selects the variant matching the template's select and count params
+// Keys are tried in order: "<select>.<category>", "<select>", "=N", "<category>" and finally "other"
+func selectVariant(variants Variants, lang string, params map[string]any, template *MessageTemplate) (string, bool) {
+	var keys []string
+	category := ""
+	count, hasCount := params[template.countParam()]
+	if hasCount {
+		category = PluralCategory(lang, count)
+	}
+
+	if template.SelectParam != "" {
+		if value, exists := params[template.SelectParam]; exists {
+			selected := fmt.Sprint(value)
+			if hasCount {
+				keys = append(keys, selected+"."+category)
+			}
+			keys = append(keys, selected)
+		}
+	}
+	if hasCount {
+		keys = append(keys, "="+fmt.Sprint(count), category)
+	}
+	return variants.Select(keys...)
+}
+
+// renderMessage substitutes params in a message using the template's syntax
+func (c *ResponseConfig) renderMessage(template *MessageTemplate, message string, params map[string]any, formatter *Formatter) (string, error) {
+	switch template.Syntax {
+	case SyntaxParam:
+		// Inline selects apply to the params the template designates
+		message = expandSelects(message, formatter.language(), params, template.SelectParam, template.CountParam)
+		return substituteLocalizedParams(message, params, formatter), nil
+	case SyntaxICU:
+		rendered, err := formatter.FormatMessage(message, params)
+		if err != nil {
+			return "", fmt.Errorf("failed to render message template %s: %w", template.Key, err)
+		}
+		return rendered, nil
+	case SyntaxTemplate:
+		rendered, err := c.textTemplateCache().execute(template.Key, message, params, formatter)
+		if err != nil {
+			return "", fmt.Errorf("failed to render message template %s: %w", template.Key, err)
+		}
+		return rendered, nil
+	default:
+		return "", fmt.Errorf("unsupported template syntax %q for message template %s", template.Syntax, template.Key)
+	}
+}
+
 // BuildResponse constructs the final Response from a ResponseBuilder using the configuration
 // This method handles message template resolution, parameter substitution, and code mapping
 // Note: This method may experience data inconsistency if called during async configuration reload
@@ -267,20 +563,23 @@ func (c *ResponseConfig) BuildResponse(rb *ResponseBuilder) (*Response, error) {
 		return nil, errors.New("message template not found")
 	}
 
+	// Params are rendered using the locale and timezone of the request
+	formatLanguage := rb.Language
+	if formatLanguage == "" {
+		formatLanguage = c.GetDefaultLanguage()
+	}
+	formatter := NewFormatter(formatLanguage).WithLocation(rb.Location).WithNow(rb.Now).WithGroupIntegers(template.GroupIntegers)
+
 	// Determine the message text based on language preference
 	// Fallback to template default if translation is not available
-	if template.Translations[rb.Language] == "" {
-		if translation, exists := c.GetTranslation(rb.Language, rb.MessageKey); exists {
-			r.Message = translation
-		} else {
-			r.Message = template.Template
+	message, rendered := c.resolveMessage(template, rb, formatter)
+	if !rendered {
+		var err error
+		if message, err = c.renderMessage(template, message, rb.Params, formatter); err != nil {
+			return nil, err
 		}
-	} else {
-		r.Message = template.Translations[rb.Language]
 	}
-
-	// Substitute parameters in the message template
-	r.Message = substituteParams(r.Message, rb.Params)
+	r.Message = message
 
 	// Map the response code based on protocol
 	r.Code = template.CodeMappings[rb.Protocol]
@@ -291,6 +590,29 @@ func (c *ResponseConfig) BuildResponse(rb *ResponseBuilder) (*Response, error) {
 	r.Error = rb.ErrorData
 	r.Language = rb.Language
 	r.Protocol = rb.Protocol
+	r.MessageKey = rb.MessageKey
+
+	// Apply the configured envelope shape
+	if r.Shape = c.GetEnvelope(); r.Shape != nil && r.Shape.TimestampField != "" {
+		r.Timestamp = rb.Timestamp
+		if r.Timestamp.IsZero() {
+			r.Timestamp = time.Now()
+		}
+	}
+
+	// Render field errors with the language and protocol of the response
+	for _, fieldError := range rb.FieldErrors {
+		fieldResponse, err := c.BuildResponse(rb.fieldErrorBuilder(fieldError))
+		if err != nil {
+			return nil, fmt.Errorf("field error %s: %w", fieldError.Field, err)
+		}
+		r.FieldErrors = append(r.FieldErrors, ResponseFieldError{
+			Field:      fieldError.Field,
+			MessageKey: fieldError.MessageKey,
+			Message:    fieldResponse.Message,
+			Code:       fieldResponse.Code,
+		})
+	}
 
 	return r, nil
 }
@@ -298,10 +620,16 @@ func (c *ResponseConfig) BuildResponse(rb *ResponseBuilder) (*Response, error) {
 // substituteParams replaces parameter placeholders in a template string with actual values
 // Placeholders are in the format $paramName and are replaced with string representations of values
 func substituteParams(template string, params map[string]any) string {
+	return substituteLocalizedParams(template, params, nil)
+}
+
+// substituteLocalizedParams replaces parameter placeholders using the formatter to render values
+// A nil formatter renders values with fmt's default format
+func substituteLocalizedParams(template string, params map[string]any, formatter *Formatter) string {
 	result := template
 	for key, value := range params {
 		placeholder := fmt.Sprintf("$%s", key)
-		result = strings.ReplaceAll(result, placeholder, fmt.Sprintf("%v", value))
+		result = strings.ReplaceAll(result, placeholder, formatter.Format(value))
 	}
 	return result
 }
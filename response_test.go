@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestResponseContextKey tests ResponseContextKey type
@@ -26,6 +28,26 @@ func TestResponseContextKey(t *testing.T) {
 			key:      ProtocolKey,
 			expected: "goresponse-protocol",
 		},
+		{
+			name:     "TimezoneKey",
+			key:      TimezoneKey,
+			expected: "goresponse-timezone",
+		},
+		{
+			name:     "NowKey",
+			key:      NowKey,
+			expected: "goresponse-now",
+		},
+		{
+			name:     "RegisterKey",
+			key:      RegisterKey,
+			expected: "goresponse-register",
+		},
+		{
+			name:     "RequestIDKey",
+			key:      RequestIDKey,
+			expected: "goresponse-request-id",
+		},
 	}
 
 	for _, tt := range tests {
@@ -148,6 +170,248 @@ func TestWithLanguage(t *testing.T) {
 	}
 }
 
+// TestWithTimezone tests WithTimezone, GetTimezoneFromContext and GetTimezone functions
+func TestWithTimezone(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		expectedLoc   *time.Location
+		expectedFound bool
+	}{
+		{
+			name:          "Context with timezone",
+			ctx:           WithTimezone(context.Background(), jakarta),
+			expectedLoc:   jakarta,
+			expectedFound: true,
+		},
+		{
+			name:          "Context with nil timezone",
+			ctx:           WithTimezone(context.Background(), nil),
+			expectedLoc:   nil,
+			expectedFound: false,
+		},
+		{
+			name:          "Context without timezone",
+			ctx:           context.Background(),
+			expectedLoc:   nil,
+			expectedFound: false,
+		},
+		{
+			name:          "Nil context",
+			ctx:           nil,
+			expectedLoc:   nil,
+			expectedFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, found := GetTimezoneFromContext(tt.ctx)
+			if loc != tt.expectedLoc {
+				t.Errorf("Expected location %v, got %v", tt.expectedLoc, loc)
+			}
+			if found != tt.expectedFound {
+				t.Errorf("Expected found %v, got %v", tt.expectedFound, found)
+			}
+			if GetTimezone(tt.ctx) != tt.expectedLoc {
+				t.Errorf("Expected GetTimezone %v, got %v", tt.expectedLoc, GetTimezone(tt.ctx))
+			}
+		})
+	}
+
+	t.Run("WithContext extracts timezone", func(t *testing.T) {
+		builder := NewResponseBuilder("test").WithContext(WithTimezone(context.Background(), jakarta))
+		if builder.Location != jakarta {
+			t.Errorf("Expected location %v, got %v", jakarta, builder.Location)
+		}
+	})
+
+	t.Run("SetTimezone overrides context", func(t *testing.T) {
+		builder := NewResponseBuilder("test").
+			WithContext(WithTimezone(context.Background(), jakarta)).
+			SetTimezone(time.UTC)
+		if builder.Location != time.UTC {
+			t.Errorf("Expected location UTC, got %v", builder.Location)
+		}
+	})
+}
+
+// TestWithRegister tests WithRegister and GetRegisterFromContext functions
+func TestWithRegister(t *testing.T) {
+	tests := []struct {
+		name             string
+		ctx              context.Context
+		expectedRegister string
+		expectedFound    bool
+	}{
+		{
+			name:             "Context with register",
+			ctx:              WithRegister(context.Background(), RegisterFormal),
+			expectedRegister: "formal",
+			expectedFound:    true,
+		},
+		{
+			name:             "Context without register",
+			ctx:              context.Background(),
+			expectedRegister: "",
+			expectedFound:    false,
+		},
+		{
+			name:             "Nil context",
+			ctx:              nil,
+			expectedRegister: "",
+			expectedFound:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			register, found := GetRegisterFromContext(tt.ctx)
+			if register != tt.expectedRegister {
+				t.Errorf("Expected register %q, got %q", tt.expectedRegister, register)
+			}
+			if found != tt.expectedFound {
+				t.Errorf("Expected found %v, got %v", tt.expectedFound, found)
+			}
+		})
+	}
+
+	t.Run("WithContext extracts register", func(t *testing.T) {
+		builder := NewResponseBuilder("test").WithContext(WithRegister(context.Background(), RegisterInformal))
+		if builder.Register != RegisterInformal {
+			t.Errorf("Expected register informal, got %q", builder.Register)
+		}
+	})
+
+	t.Run("SetRegister overrides context", func(t *testing.T) {
+		builder := NewResponseBuilder("test").
+			WithContext(WithRegister(context.Background(), RegisterInformal)).
+			SetRegister(RegisterFormal)
+		if builder.Register != RegisterFormal {
+			t.Errorf("Expected register formal, got %q", builder.Register)
+		}
+	})
+}
+
+// TestWithRequestID tests WithRequestID, GetRequestIDFromContext and GetRequestID functions
+func TestWithRequestID(t *testing.T) {
+	tests := []struct {
+		name              string
+		ctx               context.Context
+		expectedRequestID string
+		expectedFound     bool
+	}{
+		{
+			name:              "Context with request ID",
+			ctx:               WithRequestID(context.Background(), "req-123"),
+			expectedRequestID: "req-123",
+			expectedFound:     true,
+		},
+		{
+			name:              "Context without request ID",
+			ctx:               context.Background(),
+			expectedRequestID: "",
+			expectedFound:     false,
+		},
+		{
+			name:              "Nil context",
+			ctx:               nil,
+			expectedRequestID: "",
+			expectedFound:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestID, found := GetRequestIDFromContext(tt.ctx)
+			if requestID != tt.expectedRequestID {
+				t.Errorf("Expected request ID %q, got %q", tt.expectedRequestID, requestID)
+			}
+			if found != tt.expectedFound {
+				t.Errorf("Expected found %v, got %v", tt.expectedFound, found)
+			}
+			if GetRequestID(tt.ctx) != tt.expectedRequestID {
+				t.Errorf("Expected GetRequestID %q, got %q", tt.expectedRequestID, GetRequestID(tt.ctx))
+			}
+		})
+	}
+}
+
+// TestBuildResponseRegister tests formality register translations in BuildResponse
+func TestBuildResponseRegister(t *testing.T) {
+	var config ResponseConfig
+	data := `{
+		"default_language": "en",
+		"message_templates": {
+			"profile_updated": {
+				"key": "profile_updated",
+				"template": "Your profile was updated",
+				"translations": {"id": "Profil diperbarui", "de": "Profil aktualisiert"},
+				"register_translations": {
+					"formal": {"id": "Profil Anda telah diperbarui", "de": "Ihr Profil wurde aktualisiert"},
+					"informal": {"id": "Profil kamu sudah diperbarui", "de": {"one": "Du hast $count Feld geändert", "other": "Du hast $count Felder geändert"}}
+				}
+			}
+		}
+	}`
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatalf("Failed to unmarshal config: %v", err)
+	}
+	config.AddMessageTemplate(NewMessageTemplateBuilder("logout").
+		WithTemplate("You are signed out").
+		WithTranslation("id", "Keluar").
+		WithRegisterTranslation(RegisterFormal, "id", "Anda telah keluar").
+		Build())
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		key      string
+		params   map[string]any
+		expected string
+	}{
+		{name: "Formal Indonesian", ctx: WithRegister(WithLanguage(context.Background(), "id"), RegisterFormal), key: "profile_updated", expected: "Profil Anda telah diperbarui"},
+		{name: "Informal Indonesian", ctx: WithRegister(WithLanguage(context.Background(), "id"), RegisterInformal), key: "profile_updated", expected: "Profil kamu sudah diperbarui"},
+		{name: "Formal German", ctx: WithRegister(WithLanguage(context.Background(), "de"), RegisterFormal), key: "profile_updated", expected: "Ihr Profil wurde aktualisiert"},
+		{name: "Informal German variants", ctx: WithRegister(WithLanguage(context.Background(), "de"), RegisterInformal), key: "profile_updated", params: map[string]any{"count": 2}, expected: "Du hast 2 Felder geändert"},
+		{name: "No register uses neutral", ctx: WithLanguage(context.Background(), "id"), key: "profile_updated", expected: "Profil diperbarui"},
+		{name: "Missing register language falls back to neutral", ctx: WithRegister(WithLanguage(context.Background(), "en"), RegisterFormal), key: "profile_updated", expected: "Your profile was updated"},
+		{name: "Unknown register falls back to neutral", ctx: WithRegister(WithLanguage(context.Background(), "de"), "casual"), key: "profile_updated", expected: "Profil aktualisiert"},
+		{name: "Builder register translation", ctx: WithRegister(WithLanguage(context.Background(), "id"), RegisterFormal), key: "logout", expected: "Anda telah keluar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewResponseBuilder(tt.key).WithContext(tt.ctx).SetParams(tt.params)
+			response, err := config.BuildResponse(builder)
+			if err != nil {
+				t.Fatalf("BuildResponse failed: %v", err)
+			}
+			if response.Message != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, response.Message)
+			}
+		})
+	}
+
+	t.Run("Register translations round-trip", func(t *testing.T) {
+		template, _ := config.GetMessageTemplate("profile_updated")
+		jsonData, err := json.Marshal(template)
+		if err != nil {
+			t.Fatalf("Failed to marshal MessageTemplate: %v", err)
+		}
+		var roundTrip MessageTemplate
+		if err := json.Unmarshal(jsonData, &roundTrip); err != nil {
+			t.Fatalf("Failed to unmarshal MessageTemplate: %v", err)
+		}
+		if roundTrip.RegisterTranslations[RegisterFormal]["de"] != "Ihr Profil wurde aktualisiert" ||
+			roundTrip.RegisterVariants[RegisterInformal]["de"][PluralOne] != "Du hast $count Feld geändert" {
+			t.Errorf("Register translations did not round-trip: %s", jsonData)
+		}
+	})
+}
+
 // TestGetLanguageFromContext tests GetLanguageFromContext function
 func TestGetLanguageFromContext(t *testing.T) {
 	tests := []struct {
@@ -502,6 +766,40 @@ func TestResponseBuilderSetError(t *testing.T) {
 	}
 }
 
+// TestResponseBuilderAddFieldError tests field errors rendered by BuildResponse
+func TestResponseBuilderAddFieldError(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"validation_failed": {Key: "validation_failed", Template: "Invalid input", CodeMappings: map[string]int{"http": 422, "grpc": 3}},
+			"too_short":         {Key: "too_short", Template: "Must be at least $min characters", Translations: map[string]string{"id": "Minimal $min karakter"}, CodeMappings: map[string]int{"grpc": 3}},
+		},
+		DefaultLanguage: "en",
+	}
+
+	builder := NewResponseBuilder("validation_failed")
+	result := builder.AddFieldError("password", "too_short", map[string]any{"min": 8})
+	if result != builder {
+		t.Error("Expected method chaining to return same builder")
+	}
+	if !builder.IsBuiltError || len(builder.FieldErrors) != 1 {
+		t.Fatalf("Expected an error builder with one field error, got %+v", builder)
+	}
+
+	response, err := config.BuildResponse(builder.SetLanguage("id").SetProtocol("grpc"))
+	if err != nil {
+		t.Fatalf("BuildResponse failed: %v", err)
+	}
+	expected := []ResponseFieldError{{Field: "password", MessageKey: "too_short", Message: "Minimal 8 karakter", Code: 3}}
+	if !reflect.DeepEqual(response.FieldErrors, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, response.FieldErrors)
+	}
+
+	_, err = config.BuildResponse(NewResponseBuilder("validation_failed").AddFieldError("email", "missing", nil))
+	if err == nil || !strings.Contains(err.Error(), "field error email: message template not found") {
+		t.Errorf("Expected field error build failure, got %v", err)
+	}
+}
+
 // TestResponseBuilderSetParam tests SetParam method
 func TestResponseBuilderSetParam(t *testing.T) {
 	builder := NewResponseBuilder("test")
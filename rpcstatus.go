@@ -0,0 +1,454 @@
+package goresponse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ProtocolGRPC is the protocol of gRPC statuses, used as the CodeMappings key of status codes
+const ProtocolGRPC = "grpc"
+
+// gRPC canonical status codes
+const (
+	GRPCOK                 = 0
+	GRPCCanceled           = 1
+	GRPCUnknown            = 2
+	GRPCInvalidArgument    = 3
+	GRPCDeadlineExceeded   = 4
+	GRPCNotFound           = 5
+	GRPCAlreadyExists      = 6
+	GRPCPermissionDenied   = 7
+	GRPCResourceExhausted  = 8
+	GRPCFailedPrecondition = 9
+	GRPCAborted            = 10
+	GRPCOutOfRange         = 11
+	GRPCUnimplemented      = 12
+	GRPCInternal           = 13
+	GRPCUnavailable        = 14
+	GRPCDataLoss           = 15
+	GRPCUnauthenticated    = 16
+)
+
+// statusTypeURLPrefix is the prefix of the type URLs of google.rpc error details
+const statusTypeURLPrefix = "type.googleapis.com/google.rpc."
+
+// grpcHTTPStatus maps gRPC codes to HTTP statuses as grpc-gateway does
+var grpcHTTPStatus = map[int]int{
+	GRPCOK:                 http.StatusOK,
+	GRPCCanceled:           499,
+	GRPCUnknown:            http.StatusInternalServerError,
+	GRPCInvalidArgument:    http.StatusBadRequest,
+	GRPCDeadlineExceeded:   http.StatusGatewayTimeout,
+	GRPCNotFound:           http.StatusNotFound,
+	GRPCAlreadyExists:      http.StatusConflict,
+	GRPCPermissionDenied:   http.StatusForbidden,
+	GRPCResourceExhausted:  http.StatusTooManyRequests,
+	GRPCFailedPrecondition: http.StatusBadRequest,
+	GRPCAborted:            http.StatusConflict,
+	GRPCOutOfRange:         http.StatusBadRequest,
+	GRPCUnimplemented:      http.StatusNotImplemented,
+	GRPCInternal:           http.StatusInternalServerError,
+	GRPCUnavailable:        http.StatusServiceUnavailable,
+	GRPCDataLoss:           http.StatusInternalServerError,
+	GRPCUnauthenticated:    http.StatusUnauthorized,
+}
+
+// httpGRPCCode maps HTTP statuses to the gRPC codes they usually stand for
+var httpGRPCCode = map[int]int{
+	http.StatusBadRequest:                   GRPCInvalidArgument,
+	http.StatusUnauthorized:                 GRPCUnauthenticated,
+	http.StatusForbidden:                    GRPCPermissionDenied,
+	http.StatusNotFound:                     GRPCNotFound,
+	http.StatusConflict:                     GRPCAlreadyExists,
+	http.StatusPreconditionFailed:           GRPCFailedPrecondition,
+	http.StatusRequestedRangeNotSatisfiable: GRPCOutOfRange,
+	http.StatusUnprocessableEntity:          GRPCInvalidArgument,
+	http.StatusTooManyRequests:              GRPCResourceExhausted,
+	499:                                     GRPCCanceled,
+	http.StatusInternalServerError:          GRPCInternal,
+	http.StatusNotImplemented:               GRPCUnimplemented,
+	http.StatusServiceUnavailable:           GRPCUnavailable,
+	http.StatusGatewayTimeout:               GRPCDeadlineExceeded,
+}
+
+// StatusDetail is a typed message in the details of an RPCStatus
+type StatusDetail interface {
+	TypeURL() string // Type URL written in the "@type" member
+}
+
+// ErrorInfo describes the cause of an error (google.rpc.ErrorInfo)
+type ErrorInfo struct {
+	Reason   string            `json:"reason"`             // Template key, in UPPER_SNAKE_CASE with WithUpperSnakeReason
+	Domain   string            `json:"domain,omitempty"`   // Logical grouping the reason belongs to, e.g. the service name
+	Metadata map[string]string `json:"metadata,omitempty"` // Additional structured details (the response meta)
+}
+
+// TypeURL returns the type URL of google.rpc.ErrorInfo
+func (ErrorInfo) TypeURL() string { return statusTypeURLPrefix + "ErrorInfo" }
+
+// LocalizedMessage is the error message in a locale (google.rpc.LocalizedMessage)
+type LocalizedMessage struct {
+	Locale  string `json:"locale"`  // BCP 47 language of the message
+	Message string `json:"message"` // Localized message
+}
+
+// TypeURL returns the type URL of google.rpc.LocalizedMessage
+func (LocalizedMessage) TypeURL() string { return statusTypeURLPrefix + "LocalizedMessage" }
+
+// BadRequest lists the invalid fields of a request (google.rpc.BadRequest)
+type BadRequest struct {
+	FieldViolations []FieldViolation `json:"fieldViolations,omitempty"`
+}
+
+// TypeURL returns the type URL of google.rpc.BadRequest
+func (BadRequest) TypeURL() string { return statusTypeURLPrefix + "BadRequest" }
+
+// FieldViolation describes a single invalid field
+type FieldViolation struct {
+	Field       string `json:"field"`       // Path of the field, e.g. "address.city"
+	Description string `json:"description"` // Rendered message of the field error
+}
+
+// RetryInfo tells the client when to retry the request (google.rpc.RetryInfo)
+type RetryInfo struct {
+	RetryDelay time.Duration // Delay before retrying
+}
+
+// TypeURL returns the type URL of google.rpc.RetryInfo
+func (RetryInfo) TypeURL() string { return statusTypeURLPrefix + "RetryInfo" }
+
+// MarshalJSON writes the retry delay as a protobuf duration string such as "1.500s"
+func (r RetryInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Object{{Key: "retryDelay", Value: formatProtoDuration(r.RetryDelay)}})
+}
+
+// UnmarshalJSON reads the retry delay from a protobuf duration string
+func (r *RetryInfo) UnmarshalJSON(data []byte) error {
+	var members struct {
+		RetryDelay string `json:"retryDelay"`
+	}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	*r = RetryInfo{}
+	if members.RetryDelay == "" {
+		return nil
+	}
+	if !strings.HasSuffix(members.RetryDelay, "s") {
+		return fmt.Errorf("invalid retry delay %q", members.RetryDelay)
+	}
+	delay, err := time.ParseDuration(members.RetryDelay)
+	if err != nil {
+		return fmt.Errorf("invalid retry delay %q", members.RetryDelay)
+	}
+	r.RetryDelay = delay
+	return nil
+}
+
+// UnknownDetail holds a detail whose type is not known to this package
+type UnknownDetail struct {
+	Type   string         // Type URL of the detail
+	Fields map[string]any // Members other than "@type"
+}
+
+// TypeURL returns the type URL of the detail
+func (d UnknownDetail) TypeURL() string { return d.Type }
+
+// MarshalJSON writes the detail with its "@type" followed by its fields in key order
+func (d UnknownDetail) MarshalJSON() ([]byte, error) {
+	members := Object{{Key: "@type", Value: d.Type}}
+	for _, key := range sortedKeys(d.Fields) {
+		if key != "@type" {
+			members = append(members, Field{Key: key, Value: d.Fields[key]})
+		}
+	}
+	return json.Marshal(members)
+}
+
+// RPCStatus represents the JSON mapping of google.rpc.Status, as written by gRPC-transcoding gateways
+type RPCStatus struct {
+	Code     int            // gRPC status code
+	Message  string         // Rendered message
+	Details  []StatusDetail // Typed error details
+	Language string         // Language of the message (not serialized)
+}
+
+// RPCStatusOption configures BuildRPCStatus
+type RPCStatusOption func(*rpcStatusOptions)
+
+// rpcStatusOptions holds the settings of BuildRPCStatus
+type rpcStatusOptions struct {
+	domain     string
+	retryDelay time.Duration
+	upperSnake bool
+}
+
+// WithErrorDomain sets the domain of the ErrorInfo detail, e.g. "users.example.com"
+func WithErrorDomain(domain string) RPCStatusOption {
+	return func(o *rpcStatusOptions) {
+		o.domain = domain
+	}
+}
+
+// WithRetryDelay adds a RetryInfo detail with the delay to error statuses
+func WithRetryDelay(delay time.Duration) RPCStatusOption {
+	return func(o *rpcStatusOptions) {
+		o.retryDelay = delay
+	}
+}
+
+// WithUpperSnakeReason converts the template key to an UPPER_SNAKE_CASE ErrorInfo reason, e.g. "user.not-found" to "USER_NOT_FOUND"
+func WithUpperSnakeReason() RPCStatusOption {
+	return func(o *rpcStatusOptions) {
+		o.upperSnake = true
+	}
+}
+
+// BuildRPCStatus builds the response and maps it to a google.rpc.Status
+// The code is the "grpc" code mapping of the template; error responses (SetError, AddFieldError) without one
+// use the code matching the "http" mapping, or Unknown
+// Error statuses carry an ErrorInfo (the template key as reason, metadata from Meta), a LocalizedMessage,
+// a BadRequest with the field errors and a RetryInfo when WithRetryDelay is set
+func (c *ResponseConfig) BuildRPCStatus(rb *ResponseBuilder, opts ...RPCStatusOption) (*RPCStatus, error) {
+	var options rpcStatusOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// The protocol is set on a copy, so the builder can still be written with other adapters
+	if rb != nil && rb.Protocol == "" {
+		builder := *rb
+		builder.Protocol = ProtocolGRPC
+		rb = &builder
+	}
+	response, err := c.BuildResponse(rb)
+	if err != nil {
+		return nil, err
+	}
+
+	template, _ := c.GetMessageTemplate(rb.MessageKey)
+	status := &RPCStatus{
+		Code:     template.CodeMappings[ProtocolGRPC],
+		Message:  response.Message,
+		Language: response.Language,
+	}
+	if !rb.IsBuiltError {
+		return status, nil
+	}
+
+	if status.Code == GRPCOK {
+		status.Code = grpcCodeFromHTTP(template.CodeMappings["http"])
+	}
+
+	reason := rb.MessageKey
+	if options.upperSnake {
+		reason = errorInfoReason(reason)
+	}
+	errorInfo := &ErrorInfo{Reason: reason, Domain: options.domain}
+	for key, value := range response.Meta {
+		if errorInfo.Metadata == nil {
+			errorInfo.Metadata = make(map[string]string, len(response.Meta))
+		}
+		errorInfo.Metadata[key] = fmt.Sprint(value)
+	}
+	locale := response.Language
+	if locale == "" {
+		locale = c.GetDefaultLanguage()
+	}
+	status.Details = append(status.Details, errorInfo, &LocalizedMessage{Locale: locale, Message: response.Message})
+
+	if len(response.FieldErrors) > 0 {
+		badRequest := &BadRequest{FieldViolations: make([]FieldViolation, 0, len(response.FieldErrors))}
+		for _, fe := range response.FieldErrors {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, FieldViolation{Field: fe.Field, Description: fe.Message})
+		}
+		status.Details = append(status.Details, badRequest)
+	}
+	if options.retryDelay > 0 {
+		status.Details = append(status.Details, &RetryInfo{RetryDelay: options.retryDelay})
+	}
+	return status, nil
+}
+
+// grpcCodeFromHTTP returns the gRPC code of an HTTP status, Unknown when there is none
+func grpcCodeFromHTTP(status int) int {
+	if code, exists := httpGRPCCode[status]; exists {
+		return code
+	}
+	return GRPCUnknown
+}
+
+// errorInfoReason converts a template key to an ErrorInfo reason, e.g. "user.not-found" to "USER_NOT_FOUND"
+func errorInfoReason(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		}
+		return '_'
+	}, key)
+}
+
+// formatProtoDuration formats a duration as a protobuf JSON duration with 0, 3, 6 or 9 fractional digits
+func formatProtoDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	seconds, nanos := int64(d/time.Second), int64(d%time.Second)
+	if nanos == 0 {
+		return fmt.Sprintf("%s%ds", sign, seconds)
+	}
+	fraction := fmt.Sprintf("%09d", nanos)
+	for strings.HasSuffix(fraction, "000") {
+		fraction = fraction[:len(fraction)-3]
+	}
+	return fmt.Sprintf("%s%d.%ss", sign, seconds, fraction)
+}
+
+// Members returns the status as it is encoded: code, message and details
+func (s *RPCStatus) Members() Object {
+	members := Object{{Key: "code", Value: s.Code}, {Key: "message", Value: s.Message}}
+	if len(s.Details) > 0 {
+		details := make([]any, 0, len(s.Details))
+		for _, detail := range s.Details {
+			details = append(details, statusDetailJSON{detail})
+		}
+		members = append(members, Field{Key: "details", Value: details})
+	}
+	return members
+}
+
+// statusDetailJSON writes a detail as a google.protobuf.Any, its "@type" followed by its fields
+type statusDetailJSON struct {
+	detail StatusDetail
+}
+
+// MarshalJSON writes the detail with its type URL
+func (d statusDetailJSON) MarshalJSON() ([]byte, error) {
+	switch unknown := d.detail.(type) {
+	case *UnknownDetail:
+		return unknown.MarshalJSON()
+	case UnknownDetail:
+		return unknown.MarshalJSON()
+	}
+
+	fields, err := json.Marshal(d.detail)
+	if err != nil {
+		return nil, err
+	}
+	typeURL, err := json.Marshal(d.detail.TypeURL())
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`{"@type":`)
+	buf.Write(typeURL)
+	if fields = bytes.TrimSpace(fields); len(fields) > 2 {
+		buf.WriteByte(',')
+		buf.Write(fields[1:])
+	} else {
+		buf.WriteByte('}')
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalJSON writes the status as JSON
+func (s RPCStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Members())
+}
+
+// UnmarshalJSON reads a status, decoding details of the google.rpc types this package writes
+// into their types and other details into UnknownDetail
+func (s *RPCStatus) UnmarshalJSON(data []byte) error {
+	var members struct {
+		Code    int               `json:"code"`
+		Message string            `json:"message"`
+		Details []json.RawMessage `json:"details"`
+	}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return fmt.Errorf("invalid status: %w", err)
+	}
+
+	*s = RPCStatus{Code: members.Code, Message: members.Message}
+	for i, raw := range members.Details {
+		detail, err := decodeStatusDetail(raw)
+		if err != nil {
+			return fmt.Errorf("invalid status detail %d: %w", i, err)
+		}
+		s.Details = append(s.Details, detail)
+	}
+	return nil
+}
+
+// decodeStatusDetail decodes a google.protobuf.Any detail by its "@type"
+func decodeStatusDetail(raw json.RawMessage) (StatusDetail, error) {
+	var header struct {
+		Type string `json:"@type"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, err
+	}
+
+	var detail StatusDetail
+	switch header.Type {
+	case ErrorInfo{}.TypeURL():
+		detail = &ErrorInfo{}
+	case LocalizedMessage{}.TypeURL():
+		detail = &LocalizedMessage{}
+	case BadRequest{}.TypeURL():
+		detail = &BadRequest{}
+	case RetryInfo{}.TypeURL():
+		detail = &RetryInfo{}
+	case "":
+		return nil, fmt.Errorf("missing @type")
+	default:
+		var fields map[string]any
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		if err := decoder.Decode(&fields); err != nil {
+			return nil, err
+		}
+		delete(fields, "@type")
+		for key, value := range fields {
+			normalized, err := normalizeValue(value)
+			if err != nil {
+				return nil, err
+			}
+			fields[key] = normalized
+		}
+		return &UnknownDetail{Type: header.Type, Fields: fields}, nil
+	}
+	if err := json.Unmarshal(raw, detail); err != nil {
+		return nil, err
+	}
+	return detail, nil
+}
+
+// HTTPStatus returns the HTTP status matching the gRPC code, 500 for unknown codes
+func (s *RPCStatus) HTTPStatus() int {
+	if status, exists := grpcHTTPStatus[s.Code]; exists {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// WriteTo encodes the status as JSON to w
+// When w is an http.ResponseWriter the status is set from the gRPC code (see HTTPStatus),
+// along with Content-Type and Content-Language
+func (s *RPCStatus) WriteTo(w io.Writer) (int64, error) {
+	var body bytes.Buffer
+	if err := (JSONEncoder{}).Encode(&body, s.Members()); err != nil {
+		return 0, err
+	}
+	writeHTTPHeader(w, ContentTypeJSON, s.Language, s.HTTPStatus())
+	return body.WriteTo(w)
+}
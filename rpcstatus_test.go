@@ -0,0 +1,238 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestBuildRPCStatus tests mapping responses to google.rpc.Status
+func TestBuildRPCStatus(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"user_found": {
+				Key:      "user_found",
+				Template: "User found",
+			},
+			"user_not_found": {
+				Key:          "user_not_found",
+				Template:     "User $id not found",
+				Translations: map[string]string{"id": "Pengguna $id tidak ditemukan"},
+				CodeMappings: map[string]int{"http": 404, "grpc": 5},
+			},
+			"validation.failed": {
+				Key:          "validation.failed",
+				Template:     "Validation failed",
+				CodeMappings: map[string]int{"http": 422},
+			},
+			"rate_limited": {
+				Key:          "rate_limited",
+				Template:     "Too many requests",
+				CodeMappings: map[string]int{"http": 429},
+			},
+			"failure": {
+				Key:      "failure",
+				Template: "Something went wrong",
+			},
+			"required": {
+				Key:      "required",
+				Template: "$field is required",
+			},
+		},
+		DefaultLanguage: "en",
+	}
+
+	tests := []struct {
+		name     string
+		builder  *ResponseBuilder
+		opts     []RPCStatusOption
+		expected string
+	}{
+		{
+			name:     "OK",
+			builder:  NewResponseBuilder("user_found").SetData("id", 1),
+			expected: `{"code":0,"message":"User found"}`,
+		},
+		{
+			name:    "Mapped code with localized message",
+			builder: NewResponseBuilder("user_not_found").SetLanguage("id").SetParam("id", 7).SetError(errors.New("missing")).SetMeta("userId", 7),
+			opts:    []RPCStatusOption{WithErrorDomain("users.example.com"), WithUpperSnakeReason()},
+			expected: `{"code":5,"message":"Pengguna 7 tidak ditemukan","details":[` +
+				`{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"USER_NOT_FOUND","domain":"users.example.com","metadata":{"userId":"7"}},` +
+				`{"@type":"type.googleapis.com/google.rpc.LocalizedMessage","locale":"id","message":"Pengguna 7 tidak ditemukan"}]}`,
+		},
+		{
+			name: "Field violations from HTTP 422",
+			builder: NewResponseBuilder("validation.failed").
+				AddFieldError("address.city", "required", map[string]any{"field": "City"}),
+			expected: `{"code":3,"message":"Validation failed","details":[` +
+				`{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"validation.failed"},` +
+				`{"@type":"type.googleapis.com/google.rpc.LocalizedMessage","locale":"en","message":"Validation failed"},` +
+				`{"@type":"type.googleapis.com/google.rpc.BadRequest","fieldViolations":[{"field":"address.city","description":"City is required"}]}]}`,
+		},
+		{
+			name:    "Retry info",
+			builder: NewResponseBuilder("rate_limited").SetError(errors.New("limit")),
+			opts:    []RPCStatusOption{WithRetryDelay(1500 * time.Millisecond)},
+			expected: `{"code":8,"message":"Too many requests","details":[` +
+				`{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"rate_limited"},` +
+				`{"@type":"type.googleapis.com/google.rpc.LocalizedMessage","locale":"en","message":"Too many requests"},` +
+				`{"@type":"type.googleapis.com/google.rpc.RetryInfo","retryDelay":"1.500s"}]}`,
+		},
+		{
+			name:    "Unknown without mappings",
+			builder: NewResponseBuilder("failure").SetError(errors.New("boom")),
+			expected: `{"code":2,"message":"Something went wrong","details":[` +
+				`{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"failure"},` +
+				`{"@type":"type.googleapis.com/google.rpc.LocalizedMessage","locale":"en","message":"Something went wrong"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := config.BuildRPCStatus(tt.builder, tt.opts...)
+			if err != nil {
+				t.Fatalf("BuildRPCStatus failed: %v", err)
+			}
+			data, err := json.Marshal(status)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+
+	t.Run("Builder is not changed", func(t *testing.T) {
+		builder := NewResponseBuilder("user_not_found").SetError(errors.New("missing"))
+		status, err := config.BuildRPCStatus(builder)
+		if err != nil {
+			t.Fatalf("BuildRPCStatus failed: %v", err)
+		}
+		if status.Code != GRPCNotFound {
+			t.Errorf("Expected code %d, got %d", GRPCNotFound, status.Code)
+		}
+		if builder.Protocol != "" {
+			t.Errorf("Expected builder protocol to stay empty, got %s", builder.Protocol)
+		}
+	})
+
+	t.Run("Missing template", func(t *testing.T) {
+		if _, err := config.BuildRPCStatus(NewResponseBuilder("missing")); err == nil {
+			t.Error("Expected error for missing template")
+		}
+	})
+}
+
+// TestRPCStatusUnmarshalJSON tests decoding statuses with typed details
+func TestRPCStatusUnmarshalJSON(t *testing.T) {
+	data := `{"code":3,"message":"Invalid","details":[` +
+		`{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"INVALID","domain":"example.com","metadata":{"a":"1"}},` +
+		`{"@type":"type.googleapis.com/google.rpc.LocalizedMessage","locale":"id","message":"Tidak valid"},` +
+		`{"@type":"type.googleapis.com/google.rpc.BadRequest","fieldViolations":[{"field":"name","description":"Name is required"}]},` +
+		`{"@type":"type.googleapis.com/google.rpc.RetryInfo","retryDelay":"2s"},` +
+		`{"@type":"type.googleapis.com/google.rpc.Help","links":[{"url":"https://example.com"}]}]}`
+
+	var status RPCStatus
+	if err := json.Unmarshal([]byte(data), &status); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	expected := RPCStatus{
+		Code:    3,
+		Message: "Invalid",
+		Details: []StatusDetail{
+			&ErrorInfo{Reason: "INVALID", Domain: "example.com", Metadata: map[string]string{"a": "1"}},
+			&LocalizedMessage{Locale: "id", Message: "Tidak valid"},
+			&BadRequest{FieldViolations: []FieldViolation{{Field: "name", Description: "Name is required"}}},
+			&RetryInfo{RetryDelay: 2 * time.Second},
+			&UnknownDetail{Type: "type.googleapis.com/google.rpc.Help", Fields: map[string]any{"links": []any{map[string]any{"url": "https://example.com"}}}},
+		},
+	}
+	if !reflect.DeepEqual(status, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, status)
+	}
+
+	encoded, err := json.Marshal(status)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(encoded) != data {
+		t.Errorf("Expected round trip %s, got %s", data, encoded)
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			data          string
+			errorContains string
+		}{
+			{name: "Not an object", data: `[]`, errorContains: "invalid status"},
+			{name: "Missing type", data: `{"code":2,"details":[{"reason":"X"}]}`, errorContains: "missing @type"},
+			{name: "Invalid retry delay", data: `{"code":2,"details":[{"@type":"type.googleapis.com/google.rpc.RetryInfo","retryDelay":"2m"}]}`, errorContains: "invalid retry delay"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := json.Unmarshal([]byte(tt.data), &RPCStatus{})
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("Expected error containing '%s', got %v", tt.errorContains, err)
+				}
+			})
+		}
+	})
+}
+
+// TestFormatProtoDuration tests protobuf JSON durations
+func TestFormatProtoDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{duration: 0, expected: "0s"},
+		{duration: 3 * time.Second, expected: "3s"},
+		{duration: 1500 * time.Millisecond, expected: "1.500s"},
+		{duration: time.Second + time.Microsecond, expected: "1.000001s"},
+		{duration: time.Nanosecond, expected: "0.000000001s"},
+		{duration: -2500 * time.Millisecond, expected: "-2.500s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if formatted := formatProtoDuration(tt.duration); formatted != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, formatted)
+			}
+		})
+	}
+}
+
+// TestRPCStatusWriteTo tests writing statuses over HTTP
+func TestRPCStatusWriteTo(t *testing.T) {
+	tests := []struct {
+		code   int
+		status int
+	}{
+		{code: GRPCOK, status: 200},
+		{code: GRPCNotFound, status: 404},
+		{code: GRPCUnavailable, status: 503},
+		{code: 99, status: 500},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		status := &RPCStatus{Code: tt.code, Message: "Pesan", Language: "id"}
+		if _, err := status.WriteTo(rec); err != nil {
+			t.Fatalf("WriteTo failed: %v", err)
+		}
+		if rec.Code != tt.status {
+			t.Errorf("Expected status %d for code %d, got %d", tt.status, tt.code, rec.Code)
+		}
+		if rec.Header().Get("Content-Type") != ContentTypeJSON || rec.Header().Get("Content-Language") != "id" {
+			t.Errorf("Unexpected headers %v", rec.Header())
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package goresponse
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// selectPattern matches inline select blocks such as $gender{male:er|female:sie|other:sie}
+var selectPattern = regexp.MustCompile(`\$([A-Za-z0-9_]+)\{([^{}]*)\}`)
+
+// expandSelects replaces inline select blocks of the given params with the option matching the param's value
+// Options are tried by exact value, then "=N" and plural category for numbers, then "other"
+// Blocks without a matching option are removed and braces without "key:text" options are kept as text
+// Blocks of other params are kept as text, so only params a template designates are selected on
+// The chosen text may still contain $params
+func expandSelects(message, lang string, params map[string]any, selectParams ...string) string {
+	if !strings.Contains(message, "{") {
+		return message
+	}
+
+	return selectPattern.ReplaceAllStringFunc(message, func(block string) string {
+		match := selectPattern.FindStringSubmatch(block)
+		if match[1] == "" || !slices.Contains(selectParams, match[1]) {
+			return block
+		}
+		options := parseSelectOptions(match[2])
+		if len(options) == 0 {
+			return block
+		}
+
+		var keys []string
+		if value, exists := params[match[1]]; exists {
+			keys = append(keys, fmt.Sprint(value))
+			if _, isNumber := icuNumber(value); isNumber {
+				if _, isText := value.(string); !isText {
+					keys = append(keys, "="+fmt.Sprint(value), PluralCategory(lang, value))
+				}
+			}
+		}
+
+		text, _ := options.Select(keys...)
+		return text
+	})
+}
+
+// parseSelectOptions parses "key:text|key:text" options of an inline select block
+func parseSelectOptions(body string) Variants {
+	options := make(Variants)
+	for _, option := range strings.Split(body, "|") {
+		key, text, found := strings.Cut(option, ":")
+		if !found {
+			continue
+		}
+		options[strings.TrimSpace(key)] = text
+	}
+	return options
+}
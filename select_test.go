@@ -0,0 +1,162 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestExpandSelects tests inline select blocks in the $param syntax
+func TestExpandSelects(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     string
+		message  string
+		params   map[string]any
+		expected string
+	}{
+		{
+			name:     "Select by value",
+			lang:     "de",
+			message:  "$gender{male:Er|female:Sie|other:Die Person} hat geantwortet",
+			params:   map[string]any{"gender": "female"},
+			expected: "Sie hat geantwortet",
+		},
+		{
+			name:     "Fallback to other",
+			lang:     "de",
+			message:  "$gender{male:Er|female:Sie|other:Die Person} hat geantwortet",
+			params:   map[string]any{"gender": "diverse"},
+			expected: "Die Person hat geantwortet",
+		},
+		{
+			name:     "Missing param uses other",
+			lang:     "fr",
+			message:  "Cher $gender{male:Monsieur|female:Madame|other:client}",
+			params:   map[string]any{},
+			expected: "Cher client",
+		},
+		{
+			name:     "No matching option",
+			lang:     "de",
+			message:  "[$gender{male:Herr}]",
+			params:   map[string]any{"gender": "female"},
+			expected: "[]",
+		},
+		{
+			name:     "Plural category",
+			lang:     "en",
+			message:  "$count $count{one:file|other:files}",
+			params:   map[string]any{"count": 1},
+			expected: "$count file",
+		},
+		{
+			name:     "Exact count",
+			lang:     "en",
+			message:  "$count{=0:no files|one:one file|other:many files}",
+			params:   map[string]any{"count": 0},
+			expected: "no files",
+		},
+		{
+			name:     "Multiple blocks",
+			lang:     "de",
+			message:  "$gender{male:Der|female:Die|other:Das} $role{admin:Admin|other:Nutzer}",
+			params:   map[string]any{"gender": "male", "role": "admin"},
+			expected: "Der Admin",
+		},
+		{
+			name:     "Braces without options are kept",
+			lang:     "en",
+			message:  "Price $amount{USD}",
+			params:   map[string]any{"amount": 5},
+			expected: "Price $amount{USD}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := expandSelects(tt.message, tt.lang, tt.params, "gender", "count", "role", "amount"); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+
+	t.Run("Blocks of other params are kept", func(t *testing.T) {
+		message := "$gender{male:Er|female:Sie} $role{admin:Admin|other:Nutzer}"
+		expected := "Sie $role{admin:Admin|other:Nutzer}"
+		if result := expandSelects(message, "de", map[string]any{"gender": "female", "role": "admin"}, "gender", ""); result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+}
+
+// TestBuildResponseSelectVariants tests gender variants in translations and inline selects in BuildResponse
+func TestBuildResponseSelectVariants(t *testing.T) {
+	var config ResponseConfig
+	data := `{
+		"default_language": "en",
+		"message_templates": {
+			"invite": {
+				"key": "invite",
+				"template": "$name invited you",
+				"select_param": "gender",
+				"translations": {
+					"de": {"male": "$name hat dich eingeladen (er)", "female": "$name hat dich eingeladen (sie)", "other": "$name hat dich eingeladen"},
+					"fr": {"female.one": "$name a partagé $count fichier (elle)", "female": "$name a partagé $count fichiers (elle)", "one": "$name a partagé $count fichier", "other": "$name a partagé $count fichiers"}
+				}
+			},
+			"greeting": {
+				"key": "greeting",
+				"template": "Dear $title{male:Mr.|female:Ms.|other:customer} $name",
+				"select_param": "title"
+			},
+			"literal": {
+				"key": "literal",
+				"template": "Use $placeholder{name:value} in templates"
+			}
+		},
+		"translations": {
+			"fr": {"greeting": "$title{male:Cher Monsieur|female:Chère Madame|other:Bonjour} $name"}
+		}
+	}`
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatalf("Failed to unmarshal config: %v", err)
+	}
+	config.AddMessageTemplate(NewMessageTemplateBuilder("welcome").
+		WithTemplate("Welcome").
+		WithSelectParam("gender").
+		WithPluralTranslation("de", map[string]string{"male": "Willkommen, lieber $name", "female": "Willkommen, liebe $name", "other": "Willkommen, $name"}).
+		Build())
+
+	tests := []struct {
+		name     string
+		key      string
+		language string
+		params   map[string]any
+		expected string
+	}{
+		{name: "German male", key: "invite", language: "de", params: map[string]any{"name": "Jonas", "gender": "male"}, expected: "Jonas hat dich eingeladen (er)"},
+		{name: "German female", key: "invite", language: "de", params: map[string]any{"name": "Lena", "gender": "female"}, expected: "Lena hat dich eingeladen (sie)"},
+		{name: "German unknown gender", key: "invite", language: "de", params: map[string]any{"name": "Sam", "gender": "x"}, expected: "Sam hat dich eingeladen"},
+		{name: "German missing gender", key: "invite", language: "de", params: map[string]any{"name": "Sam"}, expected: "Sam hat dich eingeladen"},
+		{name: "French gender and plural", key: "invite", language: "fr", params: map[string]any{"name": "Claire", "gender": "female", "count": 1}, expected: "Claire a partagé 1 fichier (elle)"},
+		{name: "French gender only", key: "invite", language: "fr", params: map[string]any{"name": "Claire", "gender": "female", "count": 3}, expected: "Claire a partagé 3 fichiers (elle)"},
+		{name: "French plural only", key: "invite", language: "fr", params: map[string]any{"name": "Paul", "gender": "male", "count": 0}, expected: "Paul a partagé 0 fichier"},
+		{name: "Inline select default", key: "greeting", language: "en", params: map[string]any{"name": "Smith", "title": "female"}, expected: "Dear Ms. Smith"},
+		{name: "Inline select in config translation", key: "greeting", language: "fr", params: map[string]any{"name": "Dupont", "title": "male"}, expected: "Cher Monsieur Dupont"},
+		{name: "Templates without select param keep braces", key: "literal", language: "en", params: map[string]any{}, expected: "Use $placeholder{name:value} in templates"},
+		{name: "Builder select param", key: "welcome", language: "de", params: map[string]any{"name": "Lena", "gender": "female"}, expected: "Willkommen, liebe Lena"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewResponseBuilder(tt.key).SetLanguage(tt.language).SetParams(tt.params)
+			response, err := config.BuildResponse(builder)
+			if err != nil {
+				t.Fatalf("BuildResponse failed: %v", err)
+			}
+			if response.Message != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, response.Message)
+			}
+		})
+	}
+}
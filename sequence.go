@@ -0,0 +1,236 @@
+package goresponse
+
+import (
+	"bufio"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// sequenceOf returns the function of an iter.Seq or iter.Seq2 value and the number of values it yields
+// The arity is 0 when v is not a sequence
+func sequenceOf(v any) (reflect.Value, int) {
+	fn := reflect.ValueOf(v)
+	if fn.Kind() != reflect.Func || fn.IsNil() {
+		return reflect.Value{}, 0
+	}
+	t := fn.Type()
+	if t.NumIn() != 1 || t.NumOut() != 0 || t.IsVariadic() {
+		return reflect.Value{}, 0
+	}
+	yield := t.In(0)
+	if yield.Kind() != reflect.Func || yield.NumOut() != 1 || yield.Out(0).Kind() != reflect.Bool || yield.IsVariadic() {
+		return reflect.Value{}, 0
+	}
+	if arity := yield.NumIn(); arity == 1 || arity == 2 {
+		return fn, arity
+	}
+	return reflect.Value{}, 0
+}
+
+// rangeSequence calls visit with every element of a sequence, stopping at the first error
+// The key is the zero Value for iter.Seq
+func rangeSequence(seq reflect.Value, arity int, visit func(key, value reflect.Value) error) error {
+	var err error
+	yield := reflect.MakeFunc(seq.Type().In(0), func(args []reflect.Value) []reflect.Value {
+		if arity == 1 {
+			err = visit(reflect.Value{}, args[0])
+		} else {
+			err = visit(args[0], args[1])
+		}
+		return []reflect.Value{reflect.ValueOf(err == nil)}
+	})
+	seq.Call([]reflect.Value{yield})
+	return err
+}
+
+// sequenceKey converts a key of an iter.Seq2 to an object key, following the map key rules of encoding/json
+func sequenceKey(key reflect.Value) (string, error) {
+	if key.Kind() == reflect.String {
+		return key.String(), nil
+	}
+	if marshaler, ok := key.Interface().(encoding.TextMarshaler); ok {
+		if key.Kind() == reflect.Pointer && key.IsNil() {
+			return "", nil
+		}
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("%w: sequence key of type %s", errUnsupportedValue, key.Type())
+}
+
+// collectSequence reads a sequence into a normalized []any, or a map[string]any for iter.Seq2
+func collectSequence(seq reflect.Value, arity int) (any, error) {
+	if arity == 1 {
+		items := []any{}
+		err := rangeSequence(seq, arity, func(_, value reflect.Value) error {
+			item, err := normalizeValue(value.Interface())
+			items = append(items, item)
+			return err
+		})
+		return items, err
+	}
+
+	object := map[string]any{}
+	err := rangeSequence(seq, arity, func(key, value reflect.Value) error {
+		name, err := sequenceKey(key)
+		if err != nil {
+			return err
+		}
+		object[name], err = normalizeValue(value.Interface())
+		return err
+	})
+	return object, err
+}
+
+// containsSequence reports whether an encoder tree holds an iter.Seq or iter.Seq2 value
+func containsSequence(v any) bool {
+	switch v := v.(type) {
+	case Object:
+		for _, field := range v {
+			if containsSequence(field.Value) {
+				return true
+			}
+		}
+		return false
+	case map[string]any:
+		for _, item := range v {
+			if containsSequence(item) {
+				return true
+			}
+		}
+		return false
+	case []any:
+		for _, item := range v {
+			if containsSequence(item) {
+				return true
+			}
+		}
+		return false
+	}
+	_, arity := sequenceOf(v)
+	return arity > 0
+}
+
+// writeJSON writes v as JSON to w, producing the same output as json.Marshal
+// Sequences are written element by element as they are yielded: iter.Seq as an array
+// and iter.Seq2 as an object in iteration order
+func writeJSON(w *bufio.Writer, v any) error {
+	switch v := v.(type) {
+	case Object:
+		w.WriteByte('{')
+		for i, field := range v {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			if err := writeJSONMember(w, field.Key, field.Value); err != nil {
+				return err
+			}
+		}
+		w.WriteByte('}')
+		return nil
+	case map[string]any:
+		if v == nil {
+			_, err := w.WriteString("null")
+			return err
+		}
+		w.WriteByte('{')
+		for i, key := range sortedKeys(v) {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			if err := writeJSONMember(w, key, v[key]); err != nil {
+				return err
+			}
+		}
+		w.WriteByte('}')
+		return nil
+	case []any:
+		if v == nil {
+			_, err := w.WriteString("null")
+			return err
+		}
+		w.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			if err := writeJSON(w, item); err != nil {
+				return err
+			}
+		}
+		w.WriteByte(']')
+		return nil
+	}
+
+	if seq, arity := sequenceOf(v); arity > 0 {
+		return writeJSONSequence(w, seq, arity)
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+// writeJSONMember writes a key and value of a JSON object
+func writeJSONMember(w *bufio.Writer, key string, value any) error {
+	name, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	w.Write(name)
+	w.WriteByte(':')
+	return writeJSON(w, value)
+}
+
+// writeJSONSequence writes the elements of a sequence as they are yielded
+func writeJSONSequence(w *bufio.Writer, seq reflect.Value, arity int) error {
+	open, end := byte('['), byte(']')
+	if arity == 2 {
+		open, end = '{', '}'
+	}
+	w.WriteByte(open)
+	first := true
+	err := rangeSequence(seq, arity, func(key, value reflect.Value) error {
+		if !first {
+			w.WriteByte(',')
+		}
+		first = false
+		if arity == 1 {
+			return writeJSON(w, value.Interface())
+		}
+		name, err := sequenceKey(key)
+		if err != nil {
+			return err
+		}
+		return writeJSONMember(w, name, value.Interface())
+	})
+	if err != nil {
+		return err
+	}
+	return w.WriteByte(end)
+}
+
+// countingWriter counts the bytes written to w
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write writes p to the underlying writer
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
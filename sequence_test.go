@@ -0,0 +1,188 @@
+package goresponse
+
+import (
+	"bytes"
+	"encoding/json"
+	"iter"
+	"maps"
+	"math"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// userRow is an element of streamed test data
+type userRow struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// TestWriteJSONSequence tests encoding iter.Seq and iter.Seq2 values as JSON
+func TestWriteJSONSequence(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{
+			name:     "Seq of structs",
+			value:    slices.Values([]userRow{{ID: 1, Name: "Ana"}, {ID: 2, Name: "Budi"}}),
+			expected: `[{"id":1,"name":"Ana"},{"id":2,"name":"Budi"}]`,
+		},
+		{
+			name:     "Empty Seq",
+			value:    slices.Values([]int(nil)),
+			expected: `[]`,
+		},
+		{
+			name:     "Seq2 with string keys in iteration order",
+			value:    iter.Seq2[string, int](func(yield func(string, int) bool) { _ = yield("b", 2) && yield("a", 1) }),
+			expected: `{"b":2,"a":1}`,
+		},
+		{
+			name:     "Seq2 with integer keys",
+			value:    slices.All([]string{"x", "y"}),
+			expected: `{"0":"x","1":"y"}`,
+		},
+		{
+			name:     "Seq2 with named integer keys",
+			value:    maps.All(map[time.Month]bool{time.March: true}),
+			expected: `{"3":true}`,
+		},
+		{
+			name:     "Nested sequences",
+			value:    Object{{Key: "rows", Value: slices.Values([]any{slices.Values([]string{"<a>"}), map[string]any{"n": nil}})}},
+			expected: `{"rows":[["\u003ca\u003e"],{"n":null}]}`,
+		},
+		{
+			name:     "Plain values match json.Marshal",
+			value:    Object{{Key: "m", Value: map[string]any{"z": 1, "a": []any{true, nil}}}, {Key: "nil", Value: []any(nil)}, {Key: "s", Value: "&"}},
+			expected: `{"m":{"a":[true,null],"z":1},"nil":null,"s":"\u0026"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := (JSONEncoder{}).Encode(&buf, tt.value); err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if buf.String() != tt.expected+"\n" {
+				t.Errorf("Expected %s, got %s", tt.expected, buf.String())
+			}
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			value any
+		}{
+			{name: "Unsupported key", value: iter.Seq2[float64, int](func(yield func(float64, int) bool) { yield(1.5, 1) })},
+			{name: "Unsupported element", value: slices.Values([]float64{math.Inf(1)})},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if err := (JSONEncoder{}).Encode(&bytes.Buffer{}, Object{{Key: "data", Value: tt.value}}); err == nil {
+					t.Error("Expected encoding error")
+				}
+			})
+		}
+	})
+}
+
+// TestSequenceOf tests detecting sequence functions
+func TestSequenceOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected int
+	}{
+		{name: "Seq", value: slices.Values([]int{1}), expected: 1},
+		{name: "Seq2", value: slices.All([]int{1}), expected: 2},
+		{name: "Plain yield function", value: func(func(string) bool) {}, expected: 1},
+		{name: "Nil Seq", value: iter.Seq[int](nil), expected: 0},
+		{name: "Other function", value: func(int) {}, expected: 0},
+		{name: "Yield without bool", value: func(func(int)) {}, expected: 0},
+		{name: "Not a function", value: []int{1}, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, arity := sequenceOf(tt.value); arity != tt.expected {
+				t.Errorf("Expected arity %d, got %d", tt.expected, arity)
+			}
+		})
+	}
+}
+
+// TestNormalizeSequence tests collecting sequences for buffering encoders
+func TestNormalizeSequence(t *testing.T) {
+	envelope := Object{{Key: "rows", Value: slices.Values([]userRow{{ID: 1, Name: "Ana"}})}, {Key: "totals", Value: maps.All(map[string]int{"all": 1})}}
+
+	var buf bytes.Buffer
+	if err := (XMLEncoder{}).Encode(&buf, envelope); err != nil {
+		t.Fatalf("XML Encode failed: %v", err)
+	}
+	expected := xmlHeaderLine + `<response><rows type="array"><item type="object"><id type="number">1</id><name>Ana</name></item></rows>` +
+		`<totals type="object"><all type="number">1</all></totals></response>`
+	if buf.String() != expected+"\n" {
+		t.Errorf("Expected %s, got %s", expected, buf.String())
+	}
+}
+
+// TestWriteEncodedStreamsSequence tests that sequence data is written before the sequence ends
+func TestWriteEncodedStreamsSequence(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"users_exported": {
+				Key:          "users_exported",
+				Template:     "Users exported",
+				Translations: map[string]string{"id": "Pengguna diekspor"},
+				CodeMappings: map[string]int{"http": 200},
+			},
+		},
+		DefaultLanguage: "en",
+	}
+
+	rec := httptest.NewRecorder()
+	var seenBeforeEnd bool
+	rows := func(yield func(userRow) bool) {
+		for i := range 2000 {
+			if !yield(userRow{ID: i, Name: strings.Repeat("x", 16)}) {
+				return
+			}
+		}
+		seenBeforeEnd = rec.Body.Len() > 0
+	}
+	response, err := config.BuildResponse(NewResponseBuilder("users_exported").SetLanguage("id").SetProtocol("http").SetData("users", iter.Seq[userRow](rows)))
+	if err != nil {
+		t.Fatalf("BuildResponse failed: %v", err)
+	}
+
+	n, err := response.WriteTo(rec)
+	if err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if !seenBeforeEnd {
+		t.Error("Expected output to be written while the sequence was iterated")
+	}
+	if n != int64(rec.Body.Len()) || rec.Code != 200 || rec.Header().Get("Content-Language") != "id" {
+		t.Errorf("Unexpected result: %d bytes of %d, status %d, headers %v", n, rec.Body.Len(), rec.Code, rec.Header())
+	}
+
+	var decoded struct {
+		Code    int                  `json:"code"`
+		Message string               `json:"message"`
+		Data    map[string][]userRow `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Message != "Pengguna diekspor" || len(decoded.Data["users"]) != 2000 || decoded.Data["users"][1999].ID != 1999 {
+		t.Errorf("Unexpected envelope: %s, %d users", decoded.Message, len(decoded.Data["users"]))
+	}
+}
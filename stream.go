@@ -0,0 +1,229 @@
+package goresponse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"net/http"
+	"strings"
+)
+
+// MediaTypeEventStream is the media type of Server-Sent Events
+const MediaTypeEventStream = "text/event-stream"
+
+// MediaTypeNDJSON is the media type of newline-delimited JSON
+const MediaTypeNDJSON = "application/x-ndjson"
+
+// StreamFormat selects how a StreamWriter frames responses
+type StreamFormat int
+
+const (
+	// StreamNDJSON writes one JSON envelope per line
+	StreamNDJSON StreamFormat = iota
+	// StreamSSE writes one Server-Sent Event per response, named after the template key
+	StreamSSE
+)
+
+// ContentType returns the Content-Type of the format
+func (f StreamFormat) ContentType() string {
+	if f == StreamSSE {
+		return MediaTypeEventStream + "; charset=utf-8"
+	}
+	return MediaTypeNDJSON
+}
+
+// StreamWriter writes a sequence of responses to w, flushing after each one
+// Messages are localized with the language of the context when the builder has none
+type StreamWriter struct {
+	ctx     context.Context
+	w       io.Writer
+	config  Provider
+	format  StreamFormat
+	started bool
+}
+
+// NewStreamWriter creates a stream writer for responses built with cfg
+// Writing stops once ctx is canceled
+func NewStreamWriter(ctx context.Context, w io.Writer, cfg Provider, format StreamFormat) *StreamWriter {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &StreamWriter{ctx: ctx, w: w, config: cfg, format: format}
+}
+
+// Send builds a response and writes it as the next item of the stream
+// When the response cannot be built the internal error template is written instead and the build error is returned
+// The context error is returned without writing when the context is canceled
+func (s *StreamWriter) Send(rb *ResponseBuilder) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	// The defaults are applied to a copy, so the builder can be sent to other streams or writers
+	if rb != nil {
+		builder := *rb
+		if builder.Language == "" {
+			builder.Language = GetLanguage(s.ctx)
+		}
+		if builder.Protocol == "" {
+			builder.Protocol = "http"
+		}
+		rb = &builder
+	}
+
+	var config *ResponseConfig
+	if s.config != nil {
+		config = s.config.GetConfig()
+	}
+
+	buildErr := errors.New("config is nil")
+	if config != nil {
+		response, err := config.BuildResponse(rb)
+		if err == nil {
+			return s.write(rb.MessageKey, response)
+		}
+		buildErr = err
+	}
+
+	key, response := internalErrorResponse(config, rb, buildErr)
+	if err := s.write(key, response); err != nil {
+		return errors.Join(buildErr, err)
+	}
+	return buildErr
+}
+
+// Stream sends every builder of items until the sequence ends or the context is canceled
+// Build errors do not stop the stream and are returned joined; write and context errors stop it
+func (s *StreamWriter) Stream(items iter.Seq[*ResponseBuilder]) error {
+	var errs []error
+	for rb := range items {
+		if err := s.ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		err := s.Send(rb)
+		errs = append(errs, err)
+		var writeErr *streamWriteError
+		if errors.As(err, &writeErr) {
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// streamWriteError marks errors writing to the stream, which end Stream
+type streamWriteError struct {
+	err error
+}
+
+// Error returns the message of the write error
+func (e *streamWriteError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the write error
+func (e *streamWriteError) Unwrap() error {
+	return e.err
+}
+
+// write frames the envelope of a response built from the key template and flushes it
+func (s *StreamWriter) write(key string, response *Response) error {
+	var body bytes.Buffer
+	payload, err := json.Marshal(response.Envelope())
+	if err != nil {
+		return err
+	}
+	if s.format == StreamSSE {
+		if key != "" {
+			body.WriteString("event: " + sseField(key) + "\n")
+		}
+		body.WriteString("data: ")
+		body.Write(payload)
+		body.WriteString("\n\n")
+	} else {
+		body.Write(payload)
+		body.WriteByte('\n')
+	}
+
+	if !s.started {
+		s.started = true
+		if hw, ok := s.w.(http.ResponseWriter); ok {
+			header := hw.Header()
+			header.Set("Content-Type", s.format.ContentType())
+			header.Set("Cache-Control", "no-cache")
+			header.Set("X-Content-Type-Options", "nosniff")
+			hw.WriteHeader(http.StatusOK)
+		}
+	}
+	if _, err := body.WriteTo(s.w); err != nil {
+		return &streamWriteError{err: err}
+	}
+	if err := s.flush(); err != nil {
+		return &streamWriteError{err: err}
+	}
+	return nil
+}
+
+// flush flushes buffered output of http.ResponseWriter, http.Flusher and Flush() error writers
+func (s *StreamWriter) flush() error {
+	switch w := s.w.(type) {
+	case http.ResponseWriter:
+		if err := http.NewResponseController(w).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+	case interface{ Flush() error }:
+		return w.Flush()
+	case http.Flusher:
+		w.Flush()
+	}
+	return nil
+}
+
+// sseField strips line breaks, which would end an SSE field early
+func sseField(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
+
+// StreamHTTP streams responses to w as Server-Sent Events when the Accept header prefers text/event-stream
+// and as newline-delimited JSON otherwise
+// Messages are localized with the request context language and the stream stops when the request is canceled
+func StreamHTTP(w http.ResponseWriter, r *http.Request, cfg Provider, items iter.Seq[*ResponseBuilder]) error {
+	ctx := context.Background()
+	accept := ""
+	if r != nil {
+		ctx = r.Context()
+		accept = r.Header.Get("Accept")
+	}
+	addVary(w.Header(), "Accept")
+	return NewStreamWriter(ctx, w, cfg, NegotiateStreamFormat(accept)).Stream(items)
+}
+
+// NegotiateStreamFormat returns StreamSSE when the Accept header prefers text/event-stream over
+// application/x-ndjson, and StreamNDJSON otherwise
+func NegotiateStreamFormat(accept string) StreamFormat {
+	qualities := map[string]float64{}
+	for _, element := range strings.Split(accept, ",") {
+		parts := strings.Split(element, ";")
+		mediaType := normalizeMediaType(parts[0])
+		quality := 1.0
+		for _, param := range parts[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(strings.TrimSpace(name), "q") {
+				if q, ok := parseQuality(strings.TrimSpace(value)); ok {
+					quality = q
+				}
+			}
+		}
+		qualities[mediaType] = quality
+	}
+
+	if sse, exists := qualities[MediaTypeEventStream]; exists && sse > 0 {
+		if ndjson, exists := qualities[MediaTypeNDJSON]; !exists || sse >= ndjson {
+			return StreamSSE
+		}
+	}
+	return StreamNDJSON
+}
@@ -0,0 +1,261 @@
+package goresponse
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// progressItems returns builders reporting export progress
+func progressItems() iter.Seq[*ResponseBuilder] {
+	return slices.Values([]*ResponseBuilder{
+		NewResponseBuilder("export_progress").SetParam("done", 5).SetParam("total", 10),
+		NewResponseBuilder("missing"),
+		NewResponseBuilder("export_done").SetLanguage("en").SetData("file", "export.csv"),
+	})
+}
+
+// flushRecorder counts flushes of a buffered writer
+type flushRecorder struct {
+	strings.Builder
+	flushes int
+}
+
+// Flush records a flush
+func (f *flushRecorder) Flush() error {
+	f.flushes++
+	return nil
+}
+
+// TestStreamWriter tests writing responses as SSE and NDJSON
+func TestStreamWriter(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"export_progress": {
+				Key:          "export_progress",
+				Template:     "Exported $done of $total rows",
+				Translations: map[string]string{"id": "$done dari $total baris diekspor"},
+				CodeMappings: map[string]int{"http": 200},
+			},
+			"export_done": {
+				Key:          "export_done",
+				Template:     "Export finished",
+				Translations: map[string]string{"id": "Ekspor selesai"},
+				CodeMappings: map[string]int{"http": 200},
+			},
+			"internal_error": {
+				Key:          "internal_error",
+				Template:     "Something went wrong",
+				CodeMappings: map[string]int{"http": 500},
+			},
+		},
+		DefaultLanguage: "en",
+	}
+	ctx := WithLanguage(context.Background(), "id")
+
+	tests := []struct {
+		name     string
+		format   StreamFormat
+		expected string
+	}{
+		{
+			name:   "NDJSON",
+			format: StreamNDJSON,
+			expected: `{"code":200,"message":"5 dari 10 baris diekspor"}` + "\n" +
+				`{"code":500,"message":"Something went wrong"}` + "\n" +
+				`{"code":200,"message":"Export finished","data":{"file":"export.csv"}}` + "\n",
+		},
+		{
+			name:   "Server-Sent Events",
+			format: StreamSSE,
+			expected: "event: export_progress\ndata: {\"code\":200,\"message\":\"5 dari 10 baris diekspor\"}\n\n" +
+				"event: internal_error\ndata: {\"code\":500,\"message\":\"Something went wrong\"}\n\n" +
+				"event: export_done\ndata: {\"code\":200,\"message\":\"Export finished\",\"data\":{\"file\":\"export.csv\"}}\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out flushRecorder
+			err := NewStreamWriter(ctx, &out, config, tt.format).Stream(progressItems())
+			if err == nil || !strings.Contains(err.Error(), "message template not found") {
+				t.Errorf("Expected build error of the missing template, got %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, out.String())
+			}
+			if out.flushes != 3 {
+				t.Errorf("Expected 3 flushes, got %d", out.flushes)
+			}
+		})
+	}
+
+	t.Run("Shared builder is not changed", func(t *testing.T) {
+		builder := NewResponseBuilder("export_progress").SetParam("done", 5).SetParam("total", 10)
+		var first, second strings.Builder
+		if err := NewStreamWriter(ctx, &first, config, StreamNDJSON).Send(builder); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+		if err := NewStreamWriter(context.Background(), &second, config, StreamNDJSON).Send(builder); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+		if builder.Language != "" || builder.Protocol != "" {
+			t.Errorf("Expected builder defaults to stay empty, got %q and %q", builder.Language, builder.Protocol)
+		}
+		if second.String() != `{"code":200,"message":"Exported 5 of 10 rows"}`+"\n" {
+			t.Errorf("Expected the default language in the second stream, got %q", second.String())
+		}
+	})
+
+	t.Run("Canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var out strings.Builder
+		writer := NewStreamWriter(ctx, &out, config, StreamNDJSON)
+		items := func(yield func(*ResponseBuilder) bool) {
+			if !yield(NewResponseBuilder("export_progress").SetParam("done", 1).SetParam("total", 2)) {
+				return
+			}
+			cancel()
+			yield(NewResponseBuilder("export_done"))
+		}
+		err := writer.Stream(items)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if out.String() != `{"code":200,"message":"Exported 1 of 2 rows"}`+"\n" {
+			t.Errorf("Expected only the first item, got %q", out.String())
+		}
+		if err := writer.Send(NewResponseBuilder("export_done")); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected Send to fail after cancel, got %v", err)
+		}
+	})
+
+	t.Run("Write error stops the stream", func(t *testing.T) {
+		writer := NewStreamWriter(context.Background(), failingWriter{}, config, StreamNDJSON)
+		sent := 0
+		items := func(yield func(*ResponseBuilder) bool) {
+			for sent < 3 {
+				sent++
+				if !yield(NewResponseBuilder("export_done")) {
+					return
+				}
+			}
+		}
+		if err := writer.Stream(items); err == nil || sent != 1 {
+			t.Errorf("Expected the stream to stop after the first write error, got %v after %d items", err, sent)
+		}
+	})
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+// Write returns an error
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+// TestStreamHTTP tests streaming over HTTP with format negotiation
+func TestStreamHTTP(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"export_progress": {
+				Key:          "export_progress",
+				Template:     "Exported $done of $total rows",
+				Translations: map[string]string{"id": "$done dari $total baris diekspor"},
+				CodeMappings: map[string]int{"http": 200},
+			},
+			"export_done": {
+				Key:          "export_done",
+				Template:     "Export finished",
+				Translations: map[string]string{"id": "Ekspor selesai"},
+				CodeMappings: map[string]int{"http": 200},
+			},
+		},
+		DefaultLanguage: "en",
+	}
+	items := slices.Values([]*ResponseBuilder{
+		NewResponseBuilder("export_progress").SetParam("done", 1).SetParam("total", 2),
+		NewResponseBuilder("export_done"),
+	})
+
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+		lines       []string
+	}{
+		{
+			name:        "Event stream",
+			accept:      "text/event-stream",
+			contentType: "text/event-stream; charset=utf-8",
+			lines: []string{
+				"event: export_progress", `data: {"code":200,"message":"1 dari 2 baris diekspor"}`, "",
+				"event: export_done", `data: {"code":200,"message":"Ekspor selesai"}`, "",
+			},
+		},
+		{
+			name:        "NDJSON by default",
+			accept:      "",
+			contentType: MediaTypeNDJSON,
+			lines:       []string{`{"code":200,"message":"1 dari 2 baris diekspor"}`, `{"code":200,"message":"Ekspor selesai"}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/exports", nil).WithContext(WithLanguage(context.Background(), "id"))
+			req.Header.Set("Accept", tt.accept)
+			rec := httptest.NewRecorder()
+
+			if err := StreamHTTP(rec, req, config, items); err != nil {
+				t.Fatalf("StreamHTTP failed: %v", err)
+			}
+			if rec.Code != 200 || !rec.Flushed {
+				t.Errorf("Expected flushed 200 response, got %d (flushed %v)", rec.Code, rec.Flushed)
+			}
+			if rec.Header().Get("Content-Type") != tt.contentType || rec.Header().Get("Cache-Control") != "no-cache" {
+				t.Errorf("Unexpected headers %v", rec.Header())
+			}
+
+			var lines []string
+			scanner := bufio.NewScanner(rec.Body)
+			for scanner.Scan() {
+				lines = append(lines, scanner.Text())
+			}
+			if !slices.Equal(lines, tt.lines) {
+				t.Errorf("Expected lines %q, got %q", tt.lines, lines)
+			}
+		})
+	}
+}
+
+// TestNegotiateStreamFormat tests choosing between SSE and NDJSON
+func TestNegotiateStreamFormat(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected StreamFormat
+	}{
+		{accept: "", expected: StreamNDJSON},
+		{accept: "*/*", expected: StreamNDJSON},
+		{accept: "text/event-stream", expected: StreamSSE},
+		{accept: "Text/Event-Stream; charset=utf-8", expected: StreamSSE},
+		{accept: "application/x-ndjson, text/event-stream;q=0.5", expected: StreamNDJSON},
+		{accept: "application/x-ndjson;q=0.5, text/event-stream", expected: StreamSSE},
+		{accept: "text/event-stream;q=0", expected: StreamNDJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			if format := NegotiateStreamFormat(tt.accept); format != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, format)
+			}
+		})
+	}
+}
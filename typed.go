@@ -0,0 +1,191 @@
+package goresponse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+// TypedResponse is a Response whose payload is a value of type T instead of a map
+// Any type can be the top-level data, such as a struct or a slice
+type TypedResponse[T any] struct {
+	Code     int            `json:"code"`           // Response code (HTTP status, gRPC code, etc.)
+	Message  string         `json:"message"`        // Human-readable message
+	Data     T              `json:"data"`           // Response payload data
+	Meta     map[string]any `json:"meta,omitempty"` // Additional metadata
+	Error    error          `json:"-"`              // Error details if applicable (not serialized)
+	Language string         `json:"-"`              // Language used (not serialized)
+	Protocol string         `json:"-"`              // Protocol used (not serialized)
+
+	FieldErrors []ResponseFieldError `json:"-"` // Rendered field errors of the builder (not serialized)
+
+	MessageKey string         `json:"-"` // Key of the message template (not serialized unless the envelope shape names it)
+	Timestamp  time.Time      `json:"-"` // Build time, set when the envelope shape names a timestamp member
+	Shape      *EnvelopeShape `json:"-"` // Shape of the encoded envelope (default shape when nil)
+}
+
+// TypedResponseBuilder builds a TypedResponse with data of type T
+// The message key, params, meta and context are held by Builder, so the setters mirror those of ResponseBuilder
+type TypedResponseBuilder[T any] struct {
+	Builder *ResponseBuilder // Builder of the message, code and meta
+	Data    T                // Response data payload
+}
+
+// NewTypedResponseBuilder creates a new typed response builder with the specified message key
+func NewTypedResponseBuilder[T any](messageKey string) *TypedResponseBuilder[T] {
+	return &TypedResponseBuilder[T]{Builder: NewResponseBuilder(messageKey)}
+}
+
+// WithData sets the response data payload
+func (b *TypedResponseBuilder[T]) WithData(data T) *TypedResponseBuilder[T] {
+	b.Data = data
+	return b
+}
+
+// WithContext sets the context and extracts language, protocol, timezone, reference time and register from it
+func (b *TypedResponseBuilder[T]) WithContext(ctx context.Context) *TypedResponseBuilder[T] {
+	b.Builder.WithContext(ctx)
+	return b
+}
+
+// SetLanguage manually sets the language for message translation
+func (b *TypedResponseBuilder[T]) SetLanguage(language string) *TypedResponseBuilder[T] {
+	b.Builder.SetLanguage(language)
+	return b
+}
+
+// SetProtocol manually sets the protocol type for response code mapping
+func (b *TypedResponseBuilder[T]) SetProtocol(protocol string) *TypedResponseBuilder[T] {
+	b.Builder.SetProtocol(protocol)
+	return b
+}
+
+// SetTimezone manually sets the timezone used to render time parameters
+func (b *TypedResponseBuilder[T]) SetTimezone(loc *time.Location) *TypedResponseBuilder[T] {
+	b.Builder.SetTimezone(loc)
+	return b
+}
+
+// SetNow manually sets the reference time used to render relative time parameters
+func (b *TypedResponseBuilder[T]) SetNow(now time.Time) *TypedResponseBuilder[T] {
+	b.Builder.SetNow(now)
+	return b
+}
+
+// SetTimestamp manually sets the build time written in the envelope timestamp member
+func (b *TypedResponseBuilder[T]) SetTimestamp(timestamp time.Time) *TypedResponseBuilder[T] {
+	b.Builder.SetTimestamp(timestamp)
+	return b
+}
+
+// SetRegister manually sets the formality register (formal, informal) of the message
+func (b *TypedResponseBuilder[T]) SetRegister(register string) *TypedResponseBuilder[T] {
+	b.Builder.SetRegister(register)
+	return b
+}
+
+// SetError sets an error and marks the response as an error response
+func (b *TypedResponseBuilder[T]) SetError(err error) *TypedResponseBuilder[T] {
+	b.Builder.SetError(err)
+	return b
+}
+
+// AddFieldError adds an error of a single field and marks the response as an error response
+func (b *TypedResponseBuilder[T]) AddFieldError(field, messageKey string, params map[string]any) *TypedResponseBuilder[T] {
+	b.Builder.AddFieldError(field, messageKey, params)
+	return b
+}
+
+// SetParam adds a single parameter for message template substitution
+func (b *TypedResponseBuilder[T]) SetParam(key string, value any) *TypedResponseBuilder[T] {
+	b.Builder.SetParam(key, value)
+	return b
+}
+
+// SetParams adds multiple parameters for message template substitution
+func (b *TypedResponseBuilder[T]) SetParams(params map[string]any) *TypedResponseBuilder[T] {
+	b.Builder.SetParams(params)
+	return b
+}
+
+// SetMeta adds a single metadata field to the response
+func (b *TypedResponseBuilder[T]) SetMeta(key string, value any) *TypedResponseBuilder[T] {
+	b.Builder.SetMeta(key, value)
+	return b
+}
+
+// SetMetas adds multiple metadata fields to the response
+func (b *TypedResponseBuilder[T]) SetMetas(meta map[string]any) *TypedResponseBuilder[T] {
+	b.Builder.SetMetas(meta)
+	return b
+}
+
+// BuildTypedResponse constructs the final TypedResponse using the configuration
+// The message, code, meta and field errors are resolved by ResponseConfig.BuildResponse
+func BuildTypedResponse[T any](c *ResponseConfig, b *TypedResponseBuilder[T]) (*TypedResponse[T], error) {
+	if b == nil {
+		return nil, errors.New("response builder is nil")
+	}
+	if c == nil {
+		return nil, errors.New("config is nil")
+	}
+
+	response, err := c.BuildResponse(b.Builder)
+	if err != nil {
+		return nil, err
+	}
+	return &TypedResponse[T]{
+		Code:        response.Code,
+		Message:     response.Message,
+		Data:        b.Data,
+		Meta:        response.Meta,
+		Error:       response.Error,
+		Language:    response.Language,
+		Protocol:    response.Protocol,
+		FieldErrors: response.FieldErrors,
+		MessageKey:  response.MessageKey,
+		Timestamp:   response.Timestamp,
+		Shape:       response.Shape,
+	}, nil
+}
+
+// Envelope returns the response as it is encoded in its shape
+// The default shape holds code, message, data, and meta and field errors when not empty
+func (r *TypedResponse[T]) Envelope() Object {
+	shape := r.Shape
+	if shape == nil {
+		shape = &EnvelopeShape{}
+	}
+	response := &Response{
+		Code:        r.Code,
+		Message:     r.Message,
+		Meta:        r.Meta,
+		Error:       r.Error,
+		Language:    r.Language,
+		Protocol:    r.Protocol,
+		FieldErrors: r.FieldErrors,
+		MessageKey:  r.MessageKey,
+		Timestamp:   r.Timestamp,
+	}
+	return shape.envelope(response, r.Data, true)
+}
+
+// MarshalJSON writes the response envelope in its configured shape
+func (r TypedResponse[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Envelope())
+}
+
+// WriteTo encodes the response envelope as JSON to w
+// When w is an http.ResponseWriter the status and headers are set as in Response.WriteTo
+func (r *TypedResponse[T]) WriteTo(w io.Writer) (int64, error) {
+	var body bytes.Buffer
+	if err := (JSONEncoder{}).Encode(&body, r.Envelope()); err != nil {
+		return 0, err
+	}
+	status := (&Response{Code: r.Code, Error: r.Error}).httpStatus()
+	writeHTTPHeader(w, ContentTypeJSON, r.Language, status)
+	return body.WriteTo(w)
+}
@@ -0,0 +1,174 @@
+package goresponse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// typedUser is the payload of typed test responses
+type typedUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// TestBuildTypedResponse tests building responses with typed data
+func TestBuildTypedResponse(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"users_found": {
+				Key:          "users_found",
+				Template:     "Found $count users",
+				Translations: map[string]string{"id": "Ditemukan $count pengguna"},
+				CodeMappings: map[string]int{"http": 200, "grpc": 0},
+			},
+			"user_invalid": {
+				Key:          "user_invalid",
+				Template:     "User is invalid",
+				CodeMappings: map[string]int{"http": 422},
+			},
+			"required": {
+				Key:      "required",
+				Template: "$field is required",
+			},
+		},
+		DefaultLanguage: "en",
+	}
+	users := []typedUser{{ID: 1, Name: "Ana"}, {ID: 2, Name: "Budi"}}
+
+	response, err := BuildTypedResponse(config, NewTypedResponseBuilder[[]typedUser]("users_found").
+		WithContext(WithProtocol(WithLanguage(context.Background(), "id"), "http")).
+		SetParam("count", len(users)).
+		SetMeta("page", 1).
+		WithData(users))
+	if err != nil {
+		t.Fatalf("BuildTypedResponse failed: %v", err)
+	}
+	if response.Code != 200 || response.Message != "Ditemukan 2 pengguna" || response.Language != "id" || len(response.Data) != 2 {
+		t.Errorf("Unexpected response %+v", response)
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	expected := `{"code":200,"message":"Ditemukan 2 pengguna","data":[{"id":1,"name":"Ana"},{"id":2,"name":"Budi"}],"meta":{"page":1}}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	var decoded TypedResponse[[]typedUser]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Data[1] != users[1] {
+		t.Errorf("Expected decoded user %+v, got %+v", users[1], decoded.Data[1])
+	}
+
+	t.Run("Matches BuildResponse", func(t *testing.T) {
+		builder := NewTypedResponseBuilder[typedUser]("user_invalid").SetProtocol("http").
+			AddFieldError("name", "required", map[string]any{"field": "Name"}).WithData(typedUser{ID: 3})
+		typed, err := BuildTypedResponse(config, builder)
+		if err != nil {
+			t.Fatalf("BuildTypedResponse failed: %v", err)
+		}
+		untyped, err := config.BuildResponse(builder.Builder)
+		if err != nil {
+			t.Fatalf("BuildResponse failed: %v", err)
+		}
+		if typed.Code != untyped.Code || typed.Message != untyped.Message || len(typed.FieldErrors) != 1 || typed.FieldErrors[0] != untyped.FieldErrors[0] {
+			t.Errorf("Expected %+v to match %+v", typed, untyped)
+		}
+		if typed.Data.ID != 3 {
+			t.Errorf("Expected data to be kept, got %+v", typed.Data)
+		}
+	})
+
+	t.Run("Envelope shape", func(t *testing.T) {
+		shaped := *config
+		shaped.SetEnvelope(&EnvelopeShape{SuccessField: "success", ErrorField: "error", DataField: "result", KeyField: "key", TimestampField: "timestamp"})
+		builder := NewTypedResponseBuilder[typedUser]("user_invalid").SetProtocol("http").SetTimestamp(time.Date(2025, 3, 1, 10, 30, 0, 0, time.UTC)).
+			AddFieldError("name", "required", map[string]any{"field": "Name"}).WithData(typedUser{ID: 3})
+		typed, err := BuildTypedResponse(&shaped, builder)
+		if err != nil {
+			t.Fatalf("BuildTypedResponse failed: %v", err)
+		}
+		data, err := json.Marshal(typed)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		expected := `{"success":false,"error":{"code":422,"message":"User is invalid","errors":[{"field":"name","code":"required","message":"Name is required"}]},` +
+			`"result":{"id":3,"name":""},"key":"user_invalid","timestamp":"2025-03-01T10:30:00Z"}`
+		if string(data) != expected {
+			t.Errorf("Expected %s, got %s", expected, data)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			config  *ResponseConfig
+			builder *TypedResponseBuilder[int]
+		}{
+			{name: "Nil builder", config: config, builder: nil},
+			{name: "Nil config", config: nil, builder: NewTypedResponseBuilder[int]("users_found")},
+			{name: "Missing template", config: config, builder: NewTypedResponseBuilder[int]("missing")},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if _, err := BuildTypedResponse(tt.config, tt.builder); err == nil {
+					t.Error("Expected error")
+				}
+			})
+		}
+	})
+}
+
+// TestTypedResponseWriteTo tests writing typed responses over HTTP
+func TestTypedResponseWriteTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		response *TypedResponse[*typedUser]
+		status   int
+		body     string
+	}{
+		{
+			name:     "Struct data",
+			response: &TypedResponse[*typedUser]{Code: 201, Message: "Dibuat", Data: &typedUser{ID: 1, Name: "Ana"}, Language: "id"},
+			status:   201,
+			body:     `{"code":201,"message":"Dibuat","data":{"id":1,"name":"Ana"}}`,
+		},
+		{
+			name:     "Error without HTTP code",
+			response: &TypedResponse[*typedUser]{Code: 5, Message: "Tidak ada", Error: errors.New("missing"), Language: "id"},
+			status:   500,
+			body:     `{"code":5,"message":"Tidak ada","data":null}`,
+		},
+		{
+			name: "Field errors",
+			response: &TypedResponse[*typedUser]{Code: 422, Message: "Tidak valid", Language: "id",
+				FieldErrors: []ResponseFieldError{{Field: "name", MessageKey: "required", Message: "Nama wajib diisi"}}},
+			status: 422,
+			body:   `{"code":422,"message":"Tidak valid","data":null,"errors":[{"field":"name","code":"required","message":"Nama wajib diisi"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			if _, err := tt.response.WriteTo(rec); err != nil {
+				t.Fatalf("WriteTo failed: %v", err)
+			}
+			if rec.Code != tt.status || rec.Header().Get("Content-Language") != "id" {
+				t.Errorf("Unexpected status %d and headers %v", rec.Code, rec.Header())
+			}
+			if rec.Body.String() != tt.body+"\n" {
+				t.Errorf("Expected body %s, got %s", tt.body, rec.Body.String())
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 )
 
 // ConfigSource struct to specify configuration source
@@ -15,31 +16,288 @@ type ConfigSource struct {
 
 // TranslationSource struct to specify translation source per language
 type TranslationSource struct {
-	Method string `json:"method"` // "file" or "url"
-	Path   string `json:"path"`   // file path or URL
+	Method string `json:"method"`           // "file" or "url"
+	Path   string `json:"path"`             // file path or URL
+	Format string `json:"format,omitempty"` // "json" (default) or "fluent", paths ending in .ftl default to "fluent"
+}
+
+// Translation source formats supported by TranslationSource.Format
+const (
+	SourceFormatJSON   = "json"
+	SourceFormatFluent = "fluent"
+)
+
+// sourceFormat returns the format of the translation source
+func (ts TranslationSource) sourceFormat() string {
+	if ts.Format != "" {
+		return strings.ToLower(ts.Format)
+	}
+	if strings.HasSuffix(strings.ToLower(ts.Path), ".ftl") {
+		return SourceFormatFluent
+	}
+	return SourceFormatJSON
 }
 
 // ResponseConfig struct to store response configuration
 type ResponseConfig struct {
-	MessageTemplates       map[string]MessageTemplate   `json:"message_templates"`
-	ManualMessageTemplates map[string]MessageTemplate   `json:"-"` // Manual templates (high priority)
-	DefaultLanguage        string                       `json:"default_language"`
-	Languages              []string                     `json:"languages"`
-	Translations           map[string]map[string]string `json:"translations"`       // Inline translations
-	TranslationSources     map[string]TranslationSource `json:"translation_source"` // Separate translation sources
+	MessageTemplates       map[string]MessageTemplate     `json:"message_templates"`
+	ManualMessageTemplates map[string]MessageTemplate     `json:"-"` // Manual templates (high priority)
+	DefaultLanguage        string                         `json:"default_language"`
+	Languages              []string                       `json:"languages"`
+	Translations           map[string]map[string]string   `json:"translations"`       // Inline translations
+	TranslationVariants    map[string]map[string]Variants `json:"-"`                  // Inline translations in object form (per language, per key)
+	TranslationSources     map[string]TranslationSource   `json:"translation_source"` // Separate translation sources
+	FluentBundles          map[string]*FluentBundle       `json:"-"`                  // Fluent catalogs loaded from translation sources (per language)
+
+	LanguageFallbacks     map[string][]string `json:"language_fallbacks,omitempty"`      // Extra languages tried per language before the default (ms -> id)
+	InternalErrorTemplate string              `json:"internal_error_template,omitempty"` // Template written by WriteHTTP when a build fails ("internal_error" when empty)
+
+	Envelope       *EnvelopeShape `json:"envelope,omitempty"` // Shape of encoded response envelopes (default shape when nil)
+	ManualEnvelope *EnvelopeShape `json:"-"`                  // Shape set with SetEnvelope (high priority)
+
+	textTemplates *textTemplateCache // Parsed text/template messages, created on first use
 }
 
 // MessageTemplate struct for message template
 type MessageTemplate struct {
-	Key          string            `json:"key"`
-	Template     string            `json:"template"`
-	CodeMappings map[string]int    `json:"code_mappings"`
-	Translations map[string]string `json:"translations,omitempty"` // Translations per language
+	Key          string              `json:"key"`
+	Template     string              `json:"template"`
+	CodeMappings map[string]int      `json:"code_mappings"`
+	Translations map[string]string   `json:"translations,omitempty"` // Translations per language
+	Variants     map[string]Variants `json:"-"`                      // Translations in object form per language
+	CountParam   string              `json:"count_param,omitempty"`  // Param selecting the plural variant ("count" when empty)
+	SelectParam  string              `json:"select_param,omitempty"` // Param selecting the variant by value (gender, etc.)
+	Syntax       string              `json:"syntax,omitempty"`       // Template syntax: "" for $param placeholders, "icu" or "template"
+
+	GroupIntegers bool `json:"group_integers,omitempty"` // Render integer params with the locale's group separator (1,234,567)
+
+	RegisterTranslations map[string]map[string]string   `json:"-"` // Translations per register (formal, informal) and language
+	RegisterVariants     map[string]map[string]Variants `json:"-"` // Register translations in object form
+
+	ProblemType       string            `json:"problem_type,omitempty"`       // RFC 9457 type URI ("about:blank" when empty)
+	Title             string            `json:"title,omitempty"`              // RFC 9457 title (HTTP status text when empty)
+	TitleTranslations map[string]string `json:"title_translations,omitempty"` // RFC 9457 title per language
+}
+
+// Template syntaxes supported by MessageTemplate.Syntax
+const (
+	SyntaxParam    = ""         // $param placeholders (default)
+	SyntaxICU      = "icu"      // ICU MessageFormat ({count, plural, one {# file} other {# files}})
+	SyntaxTemplate = "template" // Go text/template with params as data ({{range .items}}...{{end}})
+)
+
+// Variants holds the forms of a translation keyed by CLDR plural category (zero, one, two, few, many, other),
+// exact count ("=0"), select value ("male", "female") or both ("female.one"), with "other" as the fallback
+// In JSON a translation is either a string or an object of variants
+type Variants map[string]string
+
+// DefaultCountParam is the param used to select plural variants when MessageTemplate.CountParam is empty
+const DefaultCountParam = "count"
+
+// Select returns the first variant found for the given keys, falling back to the "other" variant
+func (v Variants) Select(keys ...string) (string, bool) {
+	for _, key := range keys {
+		if text, exists := v[key]; exists {
+			return text, true
+		}
+	}
+	text, exists := v[PluralOther]
+	return text, exists
+}
+
+// translation returns the translation of the template for a language
+// For translations with plural variants the "other" variant is returned
+func (mt *MessageTemplate) translation(lang string) (string, bool) {
+	if translation, exists := lookupLanguage(mt.Translations, lang); exists {
+		return translation, true
+	}
+	if variants, exists := lookupLanguage(mt.Variants, lang); exists {
+		return variants.Select()
+	}
+	return "", false
+}
+
+// countParam returns the param used to select plural variants
+func (mt *MessageTemplate) countParam() string {
+	if mt.CountParam == "" {
+		return DefaultCountParam
+	}
+	return mt.CountParam
+}
+
+// messageTemplateJSON is MessageTemplate without its JSON methods
+type messageTemplateJSON MessageTemplate
+
+// MarshalJSON writes string translations and variants into a single "translations" object
+func (mt MessageTemplate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		messageTemplateJSON
+		Translations         map[string]any            `json:"translations,omitempty"`
+		RegisterTranslations map[string]map[string]any `json:"register_translations,omitempty"`
+	}{
+		messageTemplateJSON:  messageTemplateJSON(mt),
+		Translations:         mergeTranslations(mt.Translations, mt.Variants),
+		RegisterTranslations: mergeLanguageTranslations(mt.RegisterTranslations, mt.RegisterVariants),
+	})
+}
+
+// UnmarshalJSON accepts translations either as strings or as objects of variants
+func (mt *MessageTemplate) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		*messageTemplateJSON
+		Translations         map[string]json.RawMessage            `json:"translations,omitempty"`
+		RegisterTranslations map[string]map[string]json.RawMessage `json:"register_translations,omitempty"`
+	}{
+		messageTemplateJSON: (*messageTemplateJSON)(mt),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	translations, variants, err := splitTranslations(aux.Translations)
+	if err != nil {
+		return fmt.Errorf("invalid translations for template %s: %w", mt.Key, err)
+	}
+	mt.Translations = translations
+	mt.Variants = variants
+
+	registerTranslations, registerVariants, err := splitLanguageTranslations(aux.RegisterTranslations)
+	if err != nil {
+		return fmt.Errorf("invalid register translations for template %s: %w", mt.Key, err)
+	}
+	mt.RegisterTranslations = registerTranslations
+	mt.RegisterVariants = registerVariants
+	return nil
+}
+
+// responseConfigJSON is ResponseConfig without its JSON methods
+type responseConfigJSON ResponseConfig
+
+// MarshalJSON writes string translations and variants into a single "translations" object
+func (c ResponseConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		responseConfigJSON
+		Translations map[string]map[string]any `json:"translations"`
+	}{
+		responseConfigJSON: responseConfigJSON(c),
+		Translations:       mergeLanguageTranslations(c.Translations, c.TranslationVariants),
+	})
+}
+
+// UnmarshalJSON accepts inline translations either as strings or as objects of variants
+func (c *ResponseConfig) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		*responseConfigJSON
+		Translations map[string]map[string]json.RawMessage `json:"translations"`
+	}{
+		responseConfigJSON: (*responseConfigJSON)(c),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	translations, variants, err := splitLanguageTranslations(aux.Translations)
+	if err != nil {
+		return err
+	}
+	c.Translations = translations
+	c.TranslationVariants = variants
+	return nil
+}
+
+// splitTranslations separates translations given as strings from translations given as objects of variants
+func splitTranslations(raw map[string]json.RawMessage) (map[string]string, map[string]Variants, error) {
+	if raw == nil {
+		return nil, nil, nil
+	}
+
+	translations := make(map[string]string)
+	var variants map[string]Variants
+	for key, value := range raw {
+		var text string
+		if err := json.Unmarshal(value, &text); err == nil {
+			translations[key] = text
+			continue
+		}
+
+		var forms Variants
+		if err := json.Unmarshal(value, &forms); err != nil {
+			return nil, nil, fmt.Errorf("translation %s must be a string or an object of strings", key)
+		}
+		if variants == nil {
+			variants = make(map[string]Variants)
+		}
+		variants[key] = forms
+	}
+	return translations, variants, nil
+}
+
+// splitLanguageTranslations separates per-group (language or register) string translations from variants
+func splitLanguageTranslations(raw map[string]map[string]json.RawMessage) (map[string]map[string]string, map[string]map[string]Variants, error) {
+	var translations map[string]map[string]string
+	var variants map[string]map[string]Variants
+	for group, values := range raw {
+		texts, forms, err := splitTranslations(values)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid translations for %s: %w", group, err)
+		}
+		if translations == nil {
+			translations = make(map[string]map[string]string)
+		}
+		translations[group] = texts
+		if len(forms) > 0 {
+			if variants == nil {
+				variants = make(map[string]map[string]Variants)
+			}
+			variants[group] = forms
+		}
+	}
+	return translations, variants, nil
+}
+
+// mergeTranslations combines string translations and variants into a single map for JSON output
+func mergeTranslations(translations map[string]string, variants map[string]Variants) map[string]any {
+	if translations == nil && variants == nil {
+		return nil
+	}
+
+	merged := make(map[string]any, len(translations)+len(variants))
+	for key, text := range translations {
+		merged[key] = text
+	}
+	for key, forms := range variants {
+		merged[key] = forms
+	}
+	return merged
+}
+
+// mergeLanguageTranslations combines per-language string translations and variants for JSON output
+func mergeLanguageTranslations(translations map[string]map[string]string, variants map[string]map[string]Variants) map[string]map[string]any {
+	if translations == nil && variants == nil {
+		return nil
+	}
+
+	merged := make(map[string]map[string]any)
+	for lang, texts := range translations {
+		merged[lang] = mergeTranslations(texts, variants[lang])
+	}
+	for lang, forms := range variants {
+		if _, exists := merged[lang]; !exists {
+			merged[lang] = mergeTranslations(nil, forms)
+		}
+	}
+	return merged
 }
 
 // ConfigChangeCallback is function type for callback when config changes
 type ConfigChangeCallback func(oldConfig, newConfig *ResponseConfig)
 
+// Provider supplies the current configuration
+// ResponseConfig, ConfigManager and AsyncConfigManager implement it
+type Provider interface {
+	GetConfig() *ResponseConfig
+}
+
 // MessageTemplateBuilder for method chaining in creating message template
 type MessageTemplateBuilder struct {
 	template *MessageTemplate
@@ -82,6 +340,72 @@ func (mtb *MessageTemplateBuilder) WithTranslations(translations map[string]stri
 	return mtb
 }
 
+// WithPluralTranslation adds translation variants keyed by plural category for specific language
+func (mtb *MessageTemplateBuilder) WithPluralTranslation(lang string, forms map[string]string) *MessageTemplateBuilder {
+	if mtb.template.Variants == nil {
+		mtb.template.Variants = make(map[string]Variants)
+	}
+	mtb.template.Variants[lang] = Variants(forms)
+	return mtb
+}
+
+// WithRegisterTranslation adds translation for a formality register (formal, informal) and language
+func (mtb *MessageTemplateBuilder) WithRegisterTranslation(register, lang, translation string) *MessageTemplateBuilder {
+	if mtb.template.RegisterTranslations == nil {
+		mtb.template.RegisterTranslations = make(map[string]map[string]string)
+	}
+	if mtb.template.RegisterTranslations[register] == nil {
+		mtb.template.RegisterTranslations[register] = make(map[string]string)
+	}
+	mtb.template.RegisterTranslations[register][lang] = translation
+	return mtb
+}
+
+// WithCountParam sets the param used to select plural variants
+func (mtb *MessageTemplateBuilder) WithCountParam(param string) *MessageTemplateBuilder {
+	mtb.template.CountParam = param
+	return mtb
+}
+
+// WithSelectParam sets the param used to select variants by value (for example "gender")
+func (mtb *MessageTemplateBuilder) WithSelectParam(param string) *MessageTemplateBuilder {
+	mtb.template.SelectParam = param
+	return mtb
+}
+
+// WithSyntax sets the template syntax (SyntaxParam, SyntaxICU or SyntaxTemplate)
+func (mtb *MessageTemplateBuilder) WithSyntax(syntax string) *MessageTemplateBuilder {
+	mtb.template.Syntax = syntax
+	return mtb
+}
+
+// WithGroupIntegers sets whether integer params are rendered with the locale's group separator
+func (mtb *MessageTemplateBuilder) WithGroupIntegers(group bool) *MessageTemplateBuilder {
+	mtb.template.GroupIntegers = group
+	return mtb
+}
+
+// WithProblemType sets the RFC 9457 problem type URI
+func (mtb *MessageTemplateBuilder) WithProblemType(uri string) *MessageTemplateBuilder {
+	mtb.template.ProblemType = uri
+	return mtb
+}
+
+// WithTitle sets the RFC 9457 problem title
+func (mtb *MessageTemplateBuilder) WithTitle(title string) *MessageTemplateBuilder {
+	mtb.template.Title = title
+	return mtb
+}
+
+// WithTitleTranslation adds the RFC 9457 problem title for specific language
+func (mtb *MessageTemplateBuilder) WithTitleTranslation(lang, title string) *MessageTemplateBuilder {
+	if mtb.template.TitleTranslations == nil {
+		mtb.template.TitleTranslations = make(map[string]string)
+	}
+	mtb.template.TitleTranslations[lang] = title
+	return mtb
+}
+
 // WithCodeMapping adds code mapping (HTTP status, etc.)
 func (mtb *MessageTemplateBuilder) WithCodeMapping(mappingType string, code int) *MessageTemplateBuilder {
 	mtb.template.CodeMappings[mappingType] = code
@@ -164,13 +488,19 @@ func (cp *ConfigPrinter) toJSON() ([]byte, error) {
 		MessageTemplates   map[string]MessageTemplate   `json:"message_templates"`
 		DefaultLanguage    string                       `json:"default_language"`
 		Languages          []string                     `json:"languages"`
-		Translations       map[string]map[string]string `json:"translations"`
+		LanguageFallbacks  map[string][]string          `json:"language_fallbacks,omitempty"`
+		InternalError      string                       `json:"internal_error_template,omitempty"`
+		Envelope           *EnvelopeShape               `json:"envelope,omitempty"`
+		Translations       map[string]map[string]any    `json:"translations"`
 		TranslationSources map[string]TranslationSource `json:"translation_source,omitempty"`
 	}{
 		MessageTemplates:   make(map[string]MessageTemplate),
 		DefaultLanguage:    cp.config.DefaultLanguage,
 		Languages:          cp.config.Languages,
-		Translations:       cp.config.Translations,
+		LanguageFallbacks:  cp.config.LanguageFallbacks,
+		InternalError:      cp.config.InternalErrorTemplate,
+		Envelope:           cp.config.GetEnvelope(),
+		Translations:       mergeLanguageTranslations(cp.config.Translations, cp.config.TranslationVariants),
 		TranslationSources: cp.config.TranslationSources,
 	}
 
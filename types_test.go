@@ -3,6 +3,7 @@ package goresponse
 import (
 	"encoding/json"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -452,6 +453,91 @@ func TestMessageTemplateJSON(t *testing.T) {
 	}
 }
 
+// TestTranslationVariantsJSON tests translations given as strings or objects of plural variants
+func TestTranslationVariantsJSON(t *testing.T) {
+	t.Run("MessageTemplate", func(t *testing.T) {
+		data := `{
+			"key": "items_found",
+			"template": "Found $count items",
+			"count_param": "count",
+			"translations": {
+				"en": {"one": "Found $count item", "other": "Found $count items"},
+				"id": "Ditemukan $count item"
+			}
+		}`
+
+		var template MessageTemplate
+		if err := json.Unmarshal([]byte(data), &template); err != nil {
+			t.Fatalf("Failed to unmarshal MessageTemplate: %v", err)
+		}
+		if template.Translations["id"] != "Ditemukan $count item" {
+			t.Errorf("Expected string translation for id, got %q", template.Translations["id"])
+		}
+		if template.Variants["en"][PluralOne] != "Found $count item" {
+			t.Errorf("Expected one variant for en, got %q", template.Variants["en"][PluralOne])
+		}
+		if _, exists := template.Translations["en"]; exists {
+			t.Error("Expected en to be stored as variants only")
+		}
+
+		jsonData, err := json.Marshal(template)
+		if err != nil {
+			t.Fatalf("Failed to marshal MessageTemplate: %v", err)
+		}
+		var roundTrip MessageTemplate
+		if err := json.Unmarshal(jsonData, &roundTrip); err != nil {
+			t.Fatalf("Failed to unmarshal marshaled MessageTemplate: %v", err)
+		}
+		if roundTrip.Variants["en"][PluralOther] != "Found $count items" || roundTrip.Translations["id"] == "" {
+			t.Errorf("Translations did not round-trip: %s", jsonData)
+		}
+		if roundTrip.CountParam != "count" {
+			t.Errorf("Expected count_param to round-trip, got %q", roundTrip.CountParam)
+		}
+	})
+
+	t.Run("ResponseConfig", func(t *testing.T) {
+		data := `{
+			"default_language": "en",
+			"translations": {
+				"en": {"files": {"one": "$count file", "other": "$count files"}, "hello": "Hello"},
+				"ru": {"files": {"one": "$count файл", "few": "$count файла", "many": "$count файлов"}}
+			}
+		}`
+
+		var config ResponseConfig
+		if err := json.Unmarshal([]byte(data), &config); err != nil {
+			t.Fatalf("Failed to unmarshal ResponseConfig: %v", err)
+		}
+		if config.Translations["en"]["hello"] != "Hello" {
+			t.Errorf("Expected string translation, got %q", config.Translations["en"]["hello"])
+		}
+		if config.TranslationVariants["ru"]["files"][PluralFew] != "$count файла" {
+			t.Errorf("Expected few variant, got %q", config.TranslationVariants["ru"]["files"][PluralFew])
+		}
+
+		jsonData, err := json.Marshal(config)
+		if err != nil {
+			t.Fatalf("Failed to marshal ResponseConfig: %v", err)
+		}
+		var roundTrip ResponseConfig
+		if err := json.Unmarshal(jsonData, &roundTrip); err != nil {
+			t.Fatalf("Failed to unmarshal marshaled ResponseConfig: %v", err)
+		}
+		if roundTrip.TranslationVariants["en"]["files"][PluralOne] != "$count file" || roundTrip.Translations["en"]["hello"] != "Hello" {
+			t.Errorf("Translations did not round-trip: %s", jsonData)
+		}
+	})
+
+	t.Run("Invalid translation", func(t *testing.T) {
+		var template MessageTemplate
+		err := json.Unmarshal([]byte(`{"key": "bad", "translations": {"en": 42}}`), &template)
+		if err == nil || !strings.Contains(err.Error(), "translation en must be a string or an object of strings") {
+			t.Errorf("Expected invalid translation error, got %v", err)
+		}
+	})
+}
+
 // TestConfigChangeCallback tests ConfigChangeCallback function type
 func TestConfigChangeCallback(t *testing.T) {
 	var callback ConfigChangeCallback
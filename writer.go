@@ -0,0 +1,148 @@
+package goresponse
+
+import (
+	"errors"
+	"io"
+	"net/http"
+)
+
+// DefaultInternalErrorTemplate is the message template written by WriteHTTP when a response cannot be built
+// ResponseConfig.InternalErrorTemplate selects a different template
+const DefaultInternalErrorTemplate = "internal_error"
+
+// ContentTypeJSON is the Content-Type of JSON response envelopes
+const ContentTypeJSON = "application/json; charset=utf-8"
+
+// WriteTo encodes the response envelope as JSON to w
+// When w is an http.ResponseWriter the status is set from Code, along with Content-Type and Content-Language
+// Codes outside the HTTP status range are written as 500 for error responses and 200 otherwise
+func (r *Response) WriteTo(w io.Writer) (int64, error) {
+	return r.WriteEncoded(w, JSONEncoder{})
+}
+
+// writeHeader sets the status, Content-Type and Content-Language when w is an http.ResponseWriter
+func (r *Response) writeHeader(w io.Writer, contentType string) {
+	writeHTTPHeader(w, contentType, r.Language, r.httpStatus())
+}
+
+// writeHTTPHeader sets the status, Content-Type and Content-Language when w is an http.ResponseWriter
+func writeHTTPHeader(w io.Writer, contentType, language string, status int) {
+	if hw, ok := w.(http.ResponseWriter); ok {
+		header := hw.Header()
+		header.Set("Content-Type", contentType)
+		if language != "" {
+			header.Set("Content-Language", language)
+		}
+		hw.WriteHeader(status)
+	}
+}
+
+// httpStatus returns the HTTP status of the response
+func (r *Response) httpStatus() int {
+	if r.Code >= 100 && r.Code <= 599 {
+		return r.Code
+	}
+	if r.Error != nil {
+		return http.StatusInternalServerError
+	}
+	return http.StatusOK
+}
+
+// WriteHTTP builds the response and writes it to w with the encoder negotiated from the Accept header
+// The language is taken from the request context when the builder has none and the protocol defaults to "http"
+// Error responses (SetError) negotiated as JSON are written as application/problem+json with the request path as instance
+// and application/vnd.api+json requests get JSON:API documents
+// When the response cannot be built the internal error template is written instead (status 500 unless mapped)
+// and the build error is returned so the caller can log it
+func WriteHTTP(w http.ResponseWriter, r *http.Request, cfg Provider, rb *ResponseBuilder) error {
+	var config *ResponseConfig
+	if cfg != nil {
+		config = cfg.GetConfig()
+	}
+
+	// Defaults are applied to a copy, so builders shared between requests are not changed
+	if rb != nil {
+		builder := *rb
+		if builder.Language == "" && r != nil {
+			builder.Language = GetLanguage(r.Context())
+		}
+		if builder.Protocol == "" {
+			builder.Protocol = "http"
+		}
+		rb = &builder
+	}
+
+	accept := ""
+	if r != nil {
+		accept = r.Header.Get("Accept")
+	}
+	mediaType, encoder := NegotiateEncoder(accept)
+	addVary(w.Header(), "Accept")
+
+	buildErr := errors.New("config is nil")
+	if config != nil {
+		response, err := config.BuildResponse(rb)
+		if err == nil {
+			return writeResponse(w, r, config, rb.MessageKey, rb.IsBuiltError, response, mediaType, encoder)
+		}
+		buildErr = err
+	}
+
+	key, response := internalErrorResponse(config, rb, buildErr)
+	if err := writeResponse(w, r, config, key, true, response, mediaType, encoder); err != nil {
+		return errors.Join(buildErr, err)
+	}
+	return buildErr
+}
+
+// writeResponse writes a response built from the key template in the negotiated format
+// JSON:API documents are written for application/vnd.api+json, error responses negotiated as JSON
+// are written as problem details and other responses are encoded as envelopes
+func writeResponse(w http.ResponseWriter, r *http.Request, config *ResponseConfig, key string, isError bool, response *Response, mediaType string, encoder Encoder) error {
+	switch {
+	case mediaType == MediaTypeJSONAPI && config != nil:
+		_, err := config.jsonapiDocument(key, response, isError).WriteTo(w)
+		return err
+	case isError && (mediaType == MediaTypeJSON || mediaType == MediaTypeProblemJSON || mediaType == MediaTypeJSONAPI):
+		problem := config.problem(key, response)
+		if r != nil && r.URL != nil {
+			problem.Instance = r.URL.Path
+		}
+		_, err := problem.WriteTo(w)
+		return err
+	}
+
+	_, err := response.WriteEncoded(w, encoder)
+	return err
+}
+
+// internalErrorResponse builds the internal error template for a failed build and returns its key
+// A plain 500 response is used when the template is missing or cannot be built either
+func internalErrorResponse(config *ResponseConfig, rb *ResponseBuilder, buildErr error) (string, *Response) {
+	language := ""
+	if rb != nil {
+		language = rb.Language
+	}
+
+	if config != nil {
+		key := config.InternalErrorTemplate
+		if key == "" {
+			key = DefaultInternalErrorTemplate
+		}
+		fallback := NewResponseBuilder(key).SetLanguage(language).SetProtocol("http").SetError(buildErr)
+		if response, err := config.BuildResponse(fallback); err == nil {
+			if response.Code == 0 {
+				response.Code = http.StatusInternalServerError
+			}
+			return key, response
+		}
+	}
+
+	return "", &Response{
+		Code:     http.StatusInternalServerError,
+		Message:  http.StatusText(http.StatusInternalServerError),
+		Error:    buildErr,
+		Language: language,
+		Protocol: "http",
+	}
+}
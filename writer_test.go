@@ -0,0 +1,301 @@
+package goresponse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestResponseWriteTo tests writing a response envelope to http.ResponseWriter and io.Writer
+func TestResponseWriteTo(t *testing.T) {
+	tests := []struct {
+		name             string
+		response         *Response
+		expectedStatus   int
+		expectedBody     string
+		expectedLanguage string
+	}{
+		{
+			name:             "Status from code",
+			response:         &Response{Code: 201, Message: "Created", Data: map[string]any{"id": 7}, Language: "en"},
+			expectedStatus:   201,
+			expectedBody:     `{"code":201,"message":"Created","data":{"id":7}}` + "\n",
+			expectedLanguage: "en",
+		},
+		{
+			name:           "Non-HTTP code",
+			response:       &Response{Code: 5, Message: "Not found"},
+			expectedStatus: 200,
+			expectedBody:   `{"code":5,"message":"Not found"}` + "\n",
+		},
+		{
+			name:           "Non-HTTP error code",
+			response:       &Response{Code: 13, Message: "Internal", Error: errors.New("boom")},
+			expectedStatus: 500,
+			expectedBody:   `{"code":13,"message":"Internal"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			n, err := tt.response.WriteTo(rec)
+			if err != nil {
+				t.Fatalf("WriteTo failed: %v", err)
+			}
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+			if rec.Body.String() != tt.expectedBody || n != int64(len(tt.expectedBody)) {
+				t.Errorf("Expected body %q (%d bytes), got %q (%d bytes)", tt.expectedBody, len(tt.expectedBody), rec.Body.String(), n)
+			}
+			if rec.Header().Get("Content-Type") != ContentTypeJSON {
+				t.Errorf("Expected Content-Type %q, got %q", ContentTypeJSON, rec.Header().Get("Content-Type"))
+			}
+			if rec.Header().Get("Content-Language") != tt.expectedLanguage {
+				t.Errorf("Expected Content-Language %q, got %q", tt.expectedLanguage, rec.Header().Get("Content-Language"))
+			}
+		})
+	}
+
+	t.Run("Plain writer", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := (&Response{Code: 200, Message: "OK"}).WriteTo(&buf); err != nil {
+			t.Fatalf("WriteTo failed: %v", err)
+		}
+		if buf.String() != `{"code":200,"message":"OK"}`+"\n" {
+			t.Errorf("Unexpected body %q", buf.String())
+		}
+	})
+}
+
+// TestWriteHTTP tests building and writing responses, including the internal error fallback
+func TestWriteHTTP(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"user_found": {
+				Key:          "user_found",
+				Template:     "User $name found",
+				CodeMappings: map[string]int{"http": 200, "grpc": 0},
+				Translations: map[string]string{"id": "Pengguna $name ditemukan"},
+			},
+			"internal_error": {
+				Key:          "internal_error",
+				Template:     "Something went wrong",
+				CodeMappings: map[string]int{"http": 500},
+				Translations: map[string]string{"id": "Terjadi kesalahan"},
+			},
+			"unavailable": {
+				Key:          "unavailable",
+				Template:     "Try again later",
+				CodeMappings: map[string]int{"http": 503},
+			},
+			"broken": {
+				Key:      "broken",
+				Syntax:   SyntaxICU,
+				Template: "{name",
+			},
+		},
+		DefaultLanguage: "en",
+	}
+
+	tests := []struct {
+		name             string
+		config           *ResponseConfig
+		builder          *ResponseBuilder
+		language         string
+		expectedStatus   int
+		expectedBody     string
+		expectedLanguage string
+		expectError      bool
+	}{
+		{
+			name:             "Language from request context",
+			config:           config,
+			builder:          NewResponseBuilder("user_found").SetParam("name", "Budi"),
+			language:         "id",
+			expectedStatus:   200,
+			expectedBody:     `{"code":200,"message":"Pengguna Budi ditemukan"}`,
+			expectedLanguage: "id",
+		},
+		{
+			name:             "Builder language kept",
+			config:           config,
+			builder:          NewResponseBuilder("user_found").SetLanguage("en").SetParam("name", "Ann"),
+			language:         "id",
+			expectedStatus:   200,
+			expectedBody:     `{"code":200,"message":"User Ann found"}`,
+			expectedLanguage: "en",
+		},
+		{
+			name:             "Missing template uses internal error template as problem details",
+			config:           config,
+			builder:          NewResponseBuilder("missing"),
+			language:         "id",
+			expectedStatus:   500,
+			expectedBody:     `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Terjadi kesalahan","instance":"/"}`,
+			expectedLanguage: "id",
+			expectError:      true,
+		},
+		{
+			name:           "Render error uses internal error template",
+			config:         config,
+			builder:        NewResponseBuilder("broken"),
+			expectedStatus: 500,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/"}`,
+			expectError:    true,
+		},
+		{
+			name: "Configured internal error template",
+			config: &ResponseConfig{
+				MessageTemplates:      config.MessageTemplates,
+				DefaultLanguage:       "en",
+				InternalErrorTemplate: "unavailable",
+			},
+			builder:        NewResponseBuilder("missing"),
+			expectedStatus: 503,
+			expectedBody:   `{"type":"about:blank","title":"Service Unavailable","status":503,"detail":"Try again later","instance":"/"}`,
+			expectError:    true,
+		},
+		{
+			name:           "Without internal error template",
+			config:         &ResponseConfig{DefaultLanguage: "en"},
+			builder:        NewResponseBuilder("missing"),
+			expectedStatus: 500,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Internal Server Error","instance":"/"}`,
+			expectError:    true,
+		},
+		{
+			name:           "Nil builder",
+			config:         config,
+			builder:        nil,
+			expectedStatus: 500,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/"}`,
+			expectError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.language != "" {
+				req = req.WithContext(WithLanguage(context.Background(), tt.language))
+			}
+			rec := httptest.NewRecorder()
+
+			err := WriteHTTP(rec, req, tt.config, tt.builder)
+			if (err != nil) != tt.expectError {
+				t.Errorf("Expected error %v, got %v", tt.expectError, err)
+			}
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+			if body := strings.TrimSpace(rec.Body.String()); body != tt.expectedBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedBody, body)
+			}
+			if rec.Header().Get("Content-Language") != tt.expectedLanguage {
+				t.Errorf("Expected Content-Language %q, got %q", tt.expectedLanguage, rec.Header().Get("Content-Language"))
+			}
+		})
+	}
+
+	t.Run("Shared builder is not changed", func(t *testing.T) {
+		shared := NewResponseBuilder("user_found").SetParam("name", "Budi")
+		for _, language := range []string{"id", "en"} {
+			req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(WithLanguage(context.Background(), language))
+			rec := httptest.NewRecorder()
+			if err := WriteHTTP(rec, req, config, shared); err != nil {
+				t.Fatalf("WriteHTTP failed: %v", err)
+			}
+			if rec.Header().Get("Content-Language") != language {
+				t.Errorf("Expected Content-Language %q, got %q", language, rec.Header().Get("Content-Language"))
+			}
+		}
+		if shared.Language != "" || shared.Protocol != "" {
+			t.Errorf("Expected builder defaults to stay unset, got language %q and protocol %q", shared.Language, shared.Protocol)
+		}
+	})
+
+	t.Run("Nil provider", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		err := WriteHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil), nil, NewResponseBuilder("user_found"))
+		if err == nil || rec.Code != 500 {
+			t.Errorf("Expected error and status 500, got %v and %d", err, rec.Code)
+		}
+	})
+}
+
+// TestWriteHTTPFieldErrors tests that field errors are written in every negotiated format
+func TestWriteHTTPFieldErrors(t *testing.T) {
+	config := &ResponseConfig{
+		MessageTemplates: map[string]MessageTemplate{
+			"validation_failed": {
+				Key:          "validation_failed",
+				Template:     "Validation failed",
+				CodeMappings: map[string]int{"http": 422},
+			},
+			"required": {
+				Key:          "required",
+				Template:     "$field is required",
+				Translations: map[string]string{"id": "$field wajib diisi"},
+			},
+		},
+		DefaultLanguage: "en",
+	}
+	builder := func() *ResponseBuilder {
+		return NewResponseBuilder("validation_failed").SetLanguage("id").AddFieldError("email", "required", map[string]any{"field": "Email"})
+	}
+	expected := []ResponseFieldError{{Field: "email", MessageKey: "required", Message: "Email wajib diisi"}}
+
+	t.Run("Problem details", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		if err := WriteHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", nil), config, builder()); err != nil {
+			t.Fatalf("WriteHTTP failed: %v", err)
+		}
+		body := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"Validation failed","instance":"/users",` +
+			`"errors":[{"field":"email","code":"required","message":"Email wajib diisi"}]}`
+		if got := strings.TrimSpace(rec.Body.String()); got != body {
+			t.Errorf("Expected body %s, got %s", body, got)
+		}
+	})
+
+	for _, mediaType := range []string{MediaTypeXML, MediaTypeMsgPack, MediaTypeCBOR} {
+		t.Run(mediaType, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users", nil)
+			req.Header.Set("Accept", mediaType)
+			rec := httptest.NewRecorder()
+			if err := WriteHTTP(rec, req, config, builder()); err != nil {
+				t.Fatalf("WriteHTTP failed: %v", err)
+			}
+			encoder, _ := LookupEncoder(mediaType)
+			decoded, err := DecodeResponse(rec.Body, encoder)
+			if err != nil {
+				t.Fatalf("DecodeResponse failed: %v", err)
+			}
+			if rec.Code != 422 || decoded.Message != "Validation failed" || !slices.Equal(decoded.FieldErrors, expected) {
+				t.Errorf("Expected field errors %+v with status 422, got %+v with status %d", expected, decoded.FieldErrors, rec.Code)
+			}
+		})
+	}
+
+	t.Run("Shaped envelope nests field errors", func(t *testing.T) {
+		shaped := *config
+		shaped.SetEnvelope(&EnvelopeShape{ErrorField: "error", ErrorsField: "fields"})
+		req := httptest.NewRequest(http.MethodPost, "/users", nil)
+		req.Header.Set("Accept", MediaTypeXML)
+		rec := httptest.NewRecorder()
+		if err := WriteHTTP(rec, req, &shaped, builder()); err != nil {
+			t.Fatalf("WriteHTTP failed: %v", err)
+		}
+		body := xmlHeaderLine + `<response><error type="object"><code type="number">422</code><message>Validation failed</message>` +
+			`<fields type="array"><item type="object"><code>required</code><field>email</field><message>Email wajib diisi</message></item></fields></error></response>`
+		if got := strings.TrimSpace(rec.Body.String()); got != body {
+			t.Errorf("Expected body %s, got %s", body, got)
+		}
+	})
+}
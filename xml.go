@@ -0,0 +1,273 @@
+package goresponse
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// xmlRootElement is the root element of XML response envelopes
+const xmlRootElement = "response"
+
+// XMLEncoder encodes values as XML
+// Object fields become elements named after their key, or <entry key="..."> when the key is not a valid XML name
+// Array items are <item> elements, non-string values carry a type attribute (number, boolean, null, array, object)
+//
+//	<response><code type="number">200</code><message>OK</message>
+//	<data type="object"><tags type="array"><item>a</item></tags></data></response>
+type XMLEncoder struct{}
+
+// ContentType returns the XML Content-Type
+func (XMLEncoder) ContentType() string {
+	return "application/xml; charset=utf-8"
+}
+
+// Encode writes v as a <response> document followed by a newline
+// The root value must be an object
+func (XMLEncoder) Encode(w io.Writer, v any) error {
+	value, err := normalizeValue(v)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(xml.Header)
+	bw.WriteString("<" + xmlRootElement + ">")
+	switch root := value.(type) {
+	case Object:
+		for _, field := range root {
+			if err := writeXMLField(bw, field.Key, field.Value); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		for _, key := range sortedKeys(root) {
+			if err := writeXMLField(bw, key, root[key]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("xml: root value must be an object, got %T", value)
+	}
+	bw.WriteString("</" + xmlRootElement + ">\n")
+	return bw.Flush()
+}
+
+// writeXMLField writes an object field as an element
+func writeXMLField(w *bufio.Writer, key string, value any) error {
+	if isXMLName(key) {
+		return writeXMLElement(w, key, "", value)
+	}
+	var attr strings.Builder
+	xml.EscapeText(&attr, []byte(key))
+	return writeXMLElement(w, "entry", ` key="`+attr.String()+`"`, value)
+}
+
+// writeXMLElement writes a value as an element, attr holds escaped attributes written after the name
+func writeXMLElement(w *bufio.Writer, name, attr string, value any) error {
+	w.WriteString("<" + name + attr)
+
+	var text string
+	switch v := value.(type) {
+	case string:
+		w.WriteString(">")
+		xml.EscapeText(w, []byte(v))
+		w.WriteString("</" + name + ">")
+		return nil
+	case nil:
+		w.WriteString(` type="null"/>`)
+		return nil
+	case bool:
+		w.WriteString(` type="boolean">`)
+		text = strconv.FormatBool(v)
+	case int64:
+		w.WriteString(` type="number">`)
+		text = strconv.FormatInt(v, 10)
+	case uint64:
+		w.WriteString(` type="number">`)
+		text = strconv.FormatUint(v, 10)
+	case float64:
+		w.WriteString(` type="number">`)
+		text = strconv.FormatFloat(v, 'g', -1, 64)
+	case []any:
+		w.WriteString(` type="array">`)
+		for _, item := range v {
+			if err := writeXMLElement(w, "item", "", item); err != nil {
+				return err
+			}
+		}
+	case Object:
+		w.WriteString(` type="object">`)
+		for _, field := range v {
+			if err := writeXMLField(w, field.Key, field.Value); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		w.WriteString(` type="object">`)
+		for _, item := range sortedKeys(v) {
+			if err := writeXMLField(w, item, v[item]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("xml: %w %T", errUnsupportedValue, value)
+	}
+	w.WriteString(text + "</" + name + ">")
+	return nil
+}
+
+// isXMLName reports whether a key can be used as an element name
+// Names starting with "xml" are reserved and written as <entry> elements
+func isXMLName(key string) bool {
+	if key == "" || strings.HasPrefix(strings.ToLower(key), "xml") {
+		return false
+	}
+	for i, r := range key {
+		switch {
+		case r == '_' || unicode.IsLetter(r):
+		case i > 0 && (r == '-' || r == '.' || unicode.IsDigit(r)):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Decode reads a <response> document into an object
+func (XMLEncoder) Decode(r io.Reader) (any, error) {
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return decodeXMLObject(decoder, start)
+		}
+	}
+}
+
+// decodeXMLElement decodes the value of an element according to its type attribute
+func decodeXMLElement(decoder *xml.Decoder, start xml.StartElement) (any, error) {
+	switch xmlAttr(start, "type") {
+	case "":
+		return decodeXMLText(decoder, start)
+	case "null":
+		if err := decoder.Skip(); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	case "boolean":
+		text, err := decodeXMLText(decoder, start)
+		if err != nil {
+			return nil, err
+		}
+		return strconv.ParseBool(strings.TrimSpace(text))
+	case "number":
+		text, err := decodeXMLText(decoder, start)
+		if err != nil {
+			return nil, err
+		}
+		return parseXMLNumber(strings.TrimSpace(text))
+	case "array":
+		items := []any{}
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			switch t := token.(type) {
+			case xml.StartElement:
+				item, err := decodeXMLElement(decoder, t)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			case xml.EndElement:
+				return items, nil
+			}
+		}
+	case "object":
+		return decodeXMLObject(decoder, start)
+	default:
+		return nil, fmt.Errorf("xml: unknown type %q of element <%s>", xmlAttr(start, "type"), start.Name.Local)
+	}
+}
+
+// decodeXMLObject decodes the child elements of an element into an object
+func decodeXMLObject(decoder *xml.Decoder, start xml.StartElement) (any, error) {
+	object := make(map[string]any)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			key := t.Name.Local
+			if attr, exists := xmlAttrLookup(t, "key"); exists && key == "entry" {
+				key = attr
+			}
+			value, err := decodeXMLElement(decoder, t)
+			if err != nil {
+				return nil, err
+			}
+			object[key] = value
+		case xml.EndElement:
+			return object, nil
+		case xml.CharData:
+			if strings.TrimSpace(string(t)) != "" {
+				return nil, fmt.Errorf("xml: unexpected text in object <%s>", start.Name.Local)
+			}
+		}
+	}
+}
+
+// decodeXMLText reads the text content of an element
+func decodeXMLText(decoder *xml.Decoder, start xml.StartElement) (string, error) {
+	var sb strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		switch t := token.(type) {
+		case xml.CharData:
+			sb.Write(t)
+		case xml.StartElement:
+			return "", fmt.Errorf("xml: unexpected element <%s> in <%s>", t.Name.Local, start.Name.Local)
+		case xml.EndElement:
+			return sb.String(), nil
+		}
+	}
+}
+
+// parseXMLNumber parses a number, including the NaN and infinity values XML can carry
+func parseXMLNumber(text string) (any, error) {
+	n, err := parseNumber(text)
+	if err != nil {
+		return nil, fmt.Errorf("xml: invalid number %q", text)
+	}
+	return n, nil
+}
+
+// xmlAttr returns the value of an attribute, or "" when missing
+func xmlAttr(start xml.StartElement, name string) string {
+	value, _ := xmlAttrLookup(start, name)
+	return value
+}
+
+// xmlAttrLookup finds an attribute of an element
+func xmlAttrLookup(start xml.StartElement, name string) (string, bool) {
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "" && attr.Name.Local == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,94 @@
+package goresponse
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestXMLEncode tests element naming and type attributes
+func TestXMLEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{name: "Scalars", value: Object{{Key: "s", Value: "a<b"}, {Key: "n", Value: 1.5}, {Key: "b", Value: false}, {Key: "z", Value: nil}}, expected: `<s>a&lt;b</s><n type="number">1.5</n><b type="boolean">false</b><z type="null"/>`},
+		{name: "Entry keys", value: map[string]any{"a b": 1, "xmlFoo": "x", "": "empty"}, expected: `<entry key="">empty</entry><entry key="a b" type="number">1</entry><entry key="xmlFoo">x</entry>`},
+		{name: "Nested", value: Object{{Key: "list", Value: []any{"a", []any{}, map[string]any{"k": "v"}}}}, expected: `<list type="array"><item>a</item><item type="array"></item><item type="object"><k>v</k></item></list>`},
+		{name: "Unicode names", value: Object{{Key: "名前", Value: "x"}, {Key: "a-1.b", Value: "y"}}, expected: `<名前>x</名前><a-1.b>y</a-1.b>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := (XMLEncoder{}).Encode(&buf, tt.value); err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			expected := xmlHeaderLine + "<response>" + tt.expected + "</response>\n"
+			if buf.String() != expected {
+				t.Errorf("Expected %q, got %q", expected, buf.String())
+			}
+		})
+	}
+
+	t.Run("Root must be an object", func(t *testing.T) {
+		if err := (XMLEncoder{}).Encode(&bytes.Buffer{}, []any{1}); err == nil || !strings.Contains(err.Error(), "root value must be an object") {
+			t.Errorf("Expected root error, got %v", err)
+		}
+	})
+}
+
+// TestXMLDecode tests decoding of documents, including whitespace and special numbers
+func TestXMLDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{name: "Indented", input: "<response>\n  <a type=\"number\"> 7 </a>\n  <b> text </b>\n</response>", expected: map[string]any{"a": int64(7), "b": " text "}},
+		{name: "Empty string", input: `<response><s></s><t/></response>`, expected: map[string]any{"s": "", "t": ""}},
+		{name: "Entry without key is an element", input: `<response><entry>x</entry></response>`, expected: map[string]any{"entry": "x"}},
+		{name: "Infinity", input: `<response><n type="number">-Inf</n></response>`, expected: map[string]any{"n": math.Inf(-1)}},
+		{name: "Boolean", input: `<response><b type="boolean">true</b></response>`, expected: map[string]any{"b": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := (XMLEncoder{}).Decode(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if !reflect.DeepEqual(value, tt.expected) {
+				t.Errorf("Expected %#v, got %#v", tt.expected, value)
+			}
+		})
+	}
+}
+
+// TestXMLDecodeErrors tests malformed XML documents
+func TestXMLDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		errorContains string
+	}{
+		{name: "Unknown type", input: `<response><a type="date">x</a></response>`, errorContains: `unknown type "date"`},
+		{name: "Invalid number", input: `<response><a type="number">x</a></response>`, errorContains: "invalid number"},
+		{name: "Invalid boolean", input: `<response><a type="boolean">yes</a></response>`, errorContains: "invalid syntax"},
+		{name: "Element in string", input: `<response><a><b/></a></response>`, errorContains: "unexpected element <b>"},
+		{name: "Text in object", input: `<response>text</response>`, errorContains: "unexpected text"},
+		{name: "Unclosed", input: `<response><a>`, errorContains: "EOF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := (XMLEncoder{}).Decode(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing '%s', got %v", tt.errorContains, err)
+			}
+		})
+	}
+}